@@ -6,12 +6,14 @@ import (
 	"fmt"
 	"github.com/epmd-edp/perf-operator/v2/pkg/apis"
 	"github.com/epmd-edp/perf-operator/v2/pkg/controller"
+	"github.com/epmd-edp/perf-operator/v2/pkg/exporter"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/watch"
+	"github.com/epmd-edp/perf-operator/v2/pkg/webhook"
 	"os"
 	"runtime"
 
 	edpCompApi "github.com/epmd-edp/edp-component-operator/pkg/apis"
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
-	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
 	"github.com/operator-framework/operator-sdk/pkg/leader"
 	"github.com/operator-framework/operator-sdk/pkg/log/zap"
 	"github.com/operator-framework/operator-sdk/pkg/restmapper"
@@ -61,7 +63,9 @@ func main() {
 
 	printVersion()
 
-	namespace, err := k8sutil.GetWatchNamespace()
+	// WATCH_NAMESPACE may list several namespaces or be empty to watch all of them,
+	// the cache is cluster-wide then and the controllers filter the namespaces they serve
+	namespace, err := watch.GetCacheNamespace()
 	if err != nil {
 		log.Error(err, "Failed to get watch namespace")
 		os.Exit(1)
@@ -112,6 +116,24 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Setup PERF KPIs exporter
+	if err := exporter.Add(mgr, namespace); err != nil {
+		log.Error(err, "")
+		os.Exit(1)
+	}
+
+	// Setup admission webhooks
+	if err := webhook.Add(mgr, namespace); err != nil {
+		log.Error(err, "")
+		os.Exit(1)
+	}
+
+	// Setup conversion webhook of the perf CRDs
+	if err := webhook.AddConversion(mgr, namespace); err != nil {
+		log.Error(err, "")
+		os.Exit(1)
+	}
+
 	// Start the Cmd
 	if err := mgr.Start(signals.SetupSignalHandler()); err != nil {
 		log.Error(err, "Manager exited non-zero")
@@ -12,6 +12,7 @@ require (
 	github.com/go-openapi/spec v0.19.3
 	github.com/operator-framework/operator-sdk v0.0.0-20190530173525-d6f9cdf2f52e
 	github.com/pkg/errors v0.8.1
+	github.com/prometheus/client_golang v1.1.0
 	github.com/spf13/pflag v1.0.3
 	github.com/stretchr/testify v1.4.0
 	gopkg.in/resty.v1 v1.12.0
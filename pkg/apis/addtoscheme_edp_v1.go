@@ -0,0 +1,9 @@
+package apis
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1"
+)
+
+func init() {
+	AddToSchemes = append(AddToSchemes, v1.SchemeBuilder.AddToScheme)
+}
@@ -0,0 +1,48 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterPerfServerSpec defines the desired state of ClusterPerfServer
+// +k8s:openapi-gen=true
+type ClusterPerfServerSpec struct {
+	// PerfServerSpec defines the PERF instance. Its Secrets and ConfigMaps, including luminatesec-conf,
+	// are taken from the operator namespace.
+	PerfServerSpec `json:",inline"`
+	// AllowedNamespaces selects the namespaces by labels whose CRs can refer to the server.
+	// An empty selector allows all namespaces.
+	AllowedNamespaces *metav1.LabelSelector `json:"allowedNamespaces"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterPerfServer is the Schema for the clusterperfservers API
+// +k8s:openapi-gen=true
+// +genclient:nonNamespaced
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Api Url",type="string",JSONPath=".spec.apiUrl"
+// +kubebuilder:printcolumn:name="Available",type="boolean",JSONPath=".status.available"
+// +kubebuilder:printcolumn:name="Project",type="integer",JSONPath=".status.projectId"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type ClusterPerfServer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterPerfServerSpec `json:"spec,omitempty"`
+	Status PerfServerStatus      `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterPerfServerList contains a list of ClusterPerfServer
+type ClusterPerfServerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterPerfServer `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterPerfServer{}, &ClusterPerfServerList{})
+}
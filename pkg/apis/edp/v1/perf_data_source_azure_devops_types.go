@@ -0,0 +1,66 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PerfDataSourceAzureDevOpsSpec defines the desired state of PerfDataSourceAzureDevOps
+// +k8s:openapi-gen=true
+type PerfDataSourceAzureDevOpsSpec struct {
+	Name           string                      `json:"name"`
+	Type           string                      `json:"type"`
+	Config         DataSourceAzureDevOpsConfig `json:"config"`
+	PerfServerName string                      `json:"perfServerName"`
+	CodebaseName   string                      `json:"codebaseName"`
+	// PerfServerKind is the kind of the PERF server, PerfServer or ClusterPerfServer. PerfServer is used if empty.
+	PerfServerKind string `json:"perfServerKind,omitempty"`
+	// PerfNode routes the data source to a child node of the PerfServer project, given by its name or
+	// slash-separated path relative to the project node. The project node itself is used if empty.
+	PerfNode string `json:"perfNode,omitempty"`
+	// CreatePerfNode allows creating the missing nodes of PerfNode.
+	CreatePerfNode bool `json:"createPerfNode,omitempty"`
+}
+
+type DataSourceAzureDevOpsConfig struct {
+	Project        string   `json:"project"`
+	Repositories   []string `json:"repositories"`
+	Url            string   `json:"url"`
+	Branches       []string `json:"branches"`
+	CredentialName string   `json:"credentialName,omitempty"`
+}
+
+// PerfDataSourceAzureDevOpsStatus defines the observed state of PerfDataSourceAzureDevOps
+// +k8s:openapi-gen=true
+type PerfDataSourceAzureDevOpsStatus struct {
+	Status string `json:"status"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerfDataSourceAzureDevOps is the Schema for the perfdatasourceazuredevopses API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.type"
+// +kubebuilder:printcolumn:name="Perf Server",type="string",JSONPath=".spec.perfServerName"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type PerfDataSourceAzureDevOps struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PerfDataSourceAzureDevOpsSpec   `json:"spec,omitempty"`
+	Status PerfDataSourceAzureDevOpsStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerfDataSourceAzureDevOpsList contains a list of PerfDataSourceAzureDevOps
+type PerfDataSourceAzureDevOpsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PerfDataSourceAzureDevOps `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PerfDataSourceAzureDevOps{}, &PerfDataSourceAzureDevOpsList{})
+}
@@ -0,0 +1,81 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PerfDataSourceTektonSpec defines the desired state of PerfDataSourceTekton
+// +k8s:openapi-gen=true
+type PerfDataSourceTektonSpec struct {
+	Name           string                 `json:"name"`
+	Type           string                 `json:"type"`
+	Config         DataSourceTektonConfig `json:"config"`
+	PerfServerName string                 `json:"perfServerName"`
+	// PerfServerKind is the kind of the PERF server, PerfServer or ClusterPerfServer. PerfServer is used if empty.
+	PerfServerKind string `json:"perfServerKind,omitempty"`
+	// PerfNode routes the data source to a child node of the PerfServer project, given by its name or
+	// slash-separated path relative to the project node. The project node itself is used if empty.
+	PerfNode string `json:"perfNode,omitempty"`
+	// CreatePerfNode allows creating the missing nodes of PerfNode.
+	CreatePerfNode bool `json:"createPerfNode,omitempty"`
+}
+
+type DataSourceTektonConfig struct {
+	// CodebaseSelector selects Codebase CRs whose PipelineRuns are collected.
+	CodebaseSelector *metav1.LabelSelector `json:"codebaseSelector,omitempty"`
+	// PipelineRunSelector narrows down collected PipelineRuns, e.g. to build pipelines only.
+	PipelineRunSelector *metav1.LabelSelector `json:"pipelineRunSelector,omitempty"`
+	// CodebaseLabel is the PipelineRun label that holds the codebase name.
+	CodebaseLabel string `json:"codebaseLabel,omitempty"`
+	// Window is the period the metrics are calculated for, e.g. 168h.
+	Window string `json:"window,omitempty"`
+	// Interval defines how often PipelineRuns are collected, e.g. 15m.
+	Interval string `json:"interval,omitempty"`
+}
+
+// PerfDataSourceTektonStatus defines the observed state of PerfDataSourceTekton
+// +k8s:openapi-gen=true
+type PerfDataSourceTektonStatus struct {
+	Status          string               `json:"status"`
+	LastTimeUpdated metav1.Time          `json:"lastTimeUpdated,omitempty"`
+	Codebases       []PipelineRunMetrics `json:"codebases,omitempty"`
+}
+
+type PipelineRunMetrics struct {
+	Codebase               string `json:"codebase"`
+	Total                  int    `json:"total"`
+	Succeeded              int    `json:"succeeded"`
+	Failed                 int    `json:"failed"`
+	SuccessRate            int    `json:"successRate"`
+	AverageDurationSeconds int64  `json:"averageDurationSeconds"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerfDataSourceTekton is the Schema for the perfdatasourcetektons API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.type"
+// +kubebuilder:printcolumn:name="Perf Server",type="string",JSONPath=".spec.perfServerName"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type PerfDataSourceTekton struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PerfDataSourceTektonSpec   `json:"spec,omitempty"`
+	Status PerfDataSourceTektonStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerfDataSourceTektonList contains a list of PerfDataSourceTekton
+type PerfDataSourceTektonList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PerfDataSourceTekton `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PerfDataSourceTekton{}, &PerfDataSourceTektonList{})
+}
@@ -0,0 +1,86 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PerfDoraMetricsSpec defines the desired state of PerfDoraMetrics
+// +k8s:openapi-gen=true
+type PerfDoraMetricsSpec struct {
+	Name           string `json:"name"`
+	Type           string `json:"type"`
+	PerfServerName string `json:"perfServerName"`
+	// PerfServerKind is the kind of the PERF server, PerfServer or ClusterPerfServer. PerfServer is used if empty.
+	PerfServerKind string `json:"perfServerKind,omitempty"`
+	// PerfNode routes the data source to a child node of the PerfServer project, given by its name or
+	// slash-separated path relative to the project node. The project node itself is used if empty.
+	PerfNode string `json:"perfNode,omitempty"`
+	// CreatePerfNode allows creating the missing nodes of PerfNode.
+	CreatePerfNode bool        `json:"createPerfNode,omitempty"`
+	Stages         []DoraStage `json:"stages"`
+	// CommitLabel is the pod template label or annotation that holds the commit SHA of the deployed image.
+	CommitLabel string `json:"commitLabel,omitempty"`
+	// CommitTimeAnnotation is the pod template annotation that holds the RFC3339 commit time.
+	CommitTimeAnnotation string `json:"commitTimeAnnotation,omitempty"`
+	// ArgoCd enables reading deployment history from Argo CD Applications.
+	ArgoCd          bool   `json:"argoCd,omitempty"`
+	ArgoCdNamespace string `json:"argoCdNamespace,omitempty"`
+	// Window is the period the metrics are calculated for, e.g. 720h.
+	Window string `json:"window,omitempty"`
+	// Interval defines how often the metrics are recalculated, e.g. 1h.
+	Interval string `json:"interval,omitempty"`
+}
+
+// DoraStage maps an EDP CD stage to the namespace it is deployed to.
+type DoraStage struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// PerfDoraMetricsStatus defines the observed state of PerfDoraMetrics
+// +k8s:openapi-gen=true
+type PerfDoraMetricsStatus struct {
+	Status          string             `json:"status"`
+	LastTimeUpdated metav1.Time        `json:"lastTimeUpdated,omitempty"`
+	Stages          []DoraStageMetrics `json:"stages,omitempty"`
+}
+
+type DoraStageMetrics struct {
+	Stage       string `json:"stage"`
+	Deployments int    `json:"deployments"`
+	// DeploymentFrequency is the average number of deployments per day.
+	DeploymentFrequency      string `json:"deploymentFrequency"`
+	LeadTimeSeconds          int64  `json:"leadTimeSeconds"`
+	ChangeFailureRate        int    `json:"changeFailureRate"`
+	MeanTimeToRestoreSeconds int64  `json:"meanTimeToRestoreSeconds"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerfDoraMetrics is the Schema for the perfdorametrics API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.type"
+// +kubebuilder:printcolumn:name="Perf Server",type="string",JSONPath=".spec.perfServerName"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type PerfDoraMetrics struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PerfDoraMetricsSpec   `json:"spec,omitempty"`
+	Status PerfDoraMetricsStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerfDoraMetricsList contains a list of PerfDoraMetrics
+type PerfDoraMetricsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PerfDoraMetrics `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PerfDoraMetrics{}, &PerfDoraMetricsList{})
+}
@@ -0,0 +1,122 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PerfServerSpec defines the desired state of PerfServer
+// +k8s:openapi-gen=true
+type PerfServerSpec struct {
+	ApiUrl         string `json:"apiUrl"`
+	RootUrl        string `json:"rootUrl"`
+	CredentialName string `json:"credentialName"`
+	// ProjectName is the name of the PERF project node, it has to be unique in the whole node tree.
+	ProjectName string `json:"projectName,omitempty"`
+	// ProjectPath is the slash-separated path of the project node from the root one, e.g. EPAM/Delivery/Backend.
+	// It's used instead of ProjectName if set.
+	ProjectPath string `json:"projectPath,omitempty"`
+	// ProjectId pins the project node by its PERF id, so it's never re-resolved by name or path.
+	ProjectId int `json:"projectId,omitempty"`
+	// ExporterNodes lists child nodes of the project whose KPIs are exported to Prometheus along with the project ones.
+	ExporterNodes []string `json:"exporterNodes,omitempty"`
+	// Auth selects how the operator authenticates in PERF. Luminate is used if omitted.
+	Auth *PerfServerAuth `json:"auth,omitempty"`
+	// Luminate defines the Luminate tunnel of the server. The namespace-wide luminatesec-conf ConfigMap is used if omitted.
+	Luminate *PerfServerLuminate `json:"luminate,omitempty"`
+	// Transport configures TLS and proxy of both PERF and Luminate connections.
+	Transport *PerfServerTransport `json:"transport,omitempty"`
+	// EdpComponent configures the EDPComponent that represents the server in the admin console.
+	EdpComponent *PerfServerEdpComponent `json:"edpComponent,omitempty"`
+}
+
+// Visibility modes of the PERF EDPComponent.
+const (
+	EdpComponentVisible   = "visible"
+	EdpComponentHidden    = "hidden"
+	EdpComponentAvailable = "available"
+)
+
+// PerfServerEdpComponent defines the visibility and the icon of the PERF EDPComponent.
+type PerfServerEdpComponent struct {
+	// Visibility is one of visible, hidden or available (visible only while PERF is available). visible is used if empty.
+	Visibility string `json:"visibility,omitempty"`
+	// IconConfigMapName refers to a ConfigMap with the icon. The icon of the operator image is used if empty.
+	IconConfigMapName string `json:"iconConfigMapName,omitempty"`
+	// IconKey is the key of the icon in the ConfigMap, perf.svg by default.
+	IconKey string `json:"iconKey,omitempty"`
+}
+
+// PerfServerTransport defines TLS and proxy settings of the HTTP clients.
+type PerfServerTransport struct {
+	// CaBundleName refers to a ConfigMap with PEM encoded CA certificates trusted in addition to the system ones.
+	CaBundleName string `json:"caBundleName,omitempty"`
+	// CaBundleKey is the key of the CA bundle in the ConfigMap, ca.crt by default.
+	CaBundleKey string `json:"caBundleKey,omitempty"`
+	// ClientCertSecretName refers to a kubernetes.io/tls Secret with the client certificate for mTLS.
+	ClientCertSecretName string `json:"clientCertSecretName,omitempty"`
+	// ProxyUrl is the HTTP(S) proxy. The proxy environment variables are used if empty.
+	ProxyUrl string   `json:"proxyUrl,omitempty"`
+	NoProxy  []string `json:"noProxy,omitempty"`
+	// Insecure disables verification of server certificates.
+	Insecure bool `json:"insecure,omitempty"`
+}
+
+// PerfServerLuminate defines the Luminate API and the Secret with its client credentials.
+type PerfServerLuminate struct {
+	ApiUrl         string `json:"apiUrl"`
+	CredentialName string `json:"credentialName"`
+}
+
+// PerfServerAuth defines the authentication provider for PERF API calls.
+type PerfServerAuth struct {
+	// Type is one of sso, luminate, bearer or oauth2.
+	Type string `json:"type"`
+	// SecretName refers to a Secret with the bearer token (token key) for bearer auth
+	// or with the client credentials (clientId and clientSecret keys) for oauth2 auth.
+	SecretName string `json:"secretName,omitempty"`
+	// TokenUrl is the OAuth2 token endpoint.
+	TokenUrl string   `json:"tokenUrl,omitempty"`
+	Scopes   []string `json:"scopes,omitempty"`
+}
+
+// PerfServerStatus defines the observed state of PerfServer
+// +k8s:openapi-gen=true
+type PerfServerStatus struct {
+	Available       bool        `json:"available"`
+	LastTimeUpdated metav1.Time `json:"lastTimeUpdated,omitempty"`
+	DetailedMessage string      `json:"detailedMessage,omitempty"`
+	// ProjectId is the resolved id of the PERF project node, data sources are managed under it.
+	ProjectId int `json:"projectId,omitempty"`
+	// ProjectPath is the path of the resolved project node as of the last reconciliation.
+	ProjectPath string `json:"projectPath,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerfServer is the Schema for the perfservers API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Api Url",type="string",JSONPath=".spec.apiUrl"
+// +kubebuilder:printcolumn:name="Available",type="boolean",JSONPath=".status.available"
+// +kubebuilder:printcolumn:name="Project",type="integer",JSONPath=".status.projectId"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type PerfServer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PerfServerSpec   `json:"spec,omitempty"`
+	Status PerfServerStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerfServerList contains a list of PerfServer
+type PerfServerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PerfServer `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PerfServer{}, &PerfServerList{})
+}
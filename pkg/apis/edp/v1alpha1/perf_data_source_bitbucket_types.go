@@ -0,0 +1,71 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// PerfDataSourceBitbucketSpec defines the desired state of PerfDataSourceBitbucket
+// +k8s:openapi-gen=true
+type PerfDataSourceBitbucketSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "operator-sdk generate k8s" to regenerate code after modifying this file
+	// Add custom validation using kubebuilder tags: https://book.kubebuilder.io/beyond_basics/generating_crd.html
+	Name           string                    `json:"name"`
+	Type           string                    `json:"type"`
+	Config         DataSourceBitbucketConfig `json:"config"`
+	PerfServerName string                    `json:"perfServerName"`
+	CodebaseName   string                    `json:"codebaseName"`
+	// PerfServerKind is the kind of the PERF server, PerfServer or ClusterPerfServer. PerfServer is used if empty.
+	PerfServerKind string `json:"perfServerKind,omitempty"`
+	// PerfNode routes the data source to a child node of the PerfServer project, given by its name or
+	// slash-separated path relative to the project node. The project node itself is used if empty.
+	PerfNode string `json:"perfNode,omitempty"`
+	// CreatePerfNode allows creating the missing nodes of PerfNode.
+	CreatePerfNode bool `json:"createPerfNode,omitempty"`
+}
+
+type DataSourceBitbucketConfig struct {
+	Workspace      string   `json:"workspace"`
+	Repositories   []string `json:"repositories"`
+	Url            string   `json:"url"`
+	Branches       []string `json:"branches"`
+	CredentialName string   `json:"credentialName,omitempty"`
+}
+
+// PerfDataSourceBitbucketStatus defines the observed state of PerfDataSourceBitbucket
+// +k8s:openapi-gen=true
+
+type PerfDataSourceBitbucketStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "operator-sdk generate k8s" to regenerate code after modifying this file
+	// Add custom validation using kubebuilder tags: https://book.kubebuilder.io/beyond_basics/generating_crd.html
+	Status string `json:"status"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerfDataSourceBitbucket is the Schema for the perfdatasourcebitbuckets API
+// +k8s:openapi-gen=true
+type PerfDataSourceBitbucket struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PerfDataSourceBitbucketSpec   `json:"spec,omitempty"`
+	Status PerfDataSourceBitbucketStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerfDataSourceBitbucketList contains a list of PerfDataSourceBitbucket
+type PerfDataSourceBitbucketList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PerfDataSourceBitbucket `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PerfDataSourceBitbucket{}, &PerfDataSourceBitbucketList{})
+}
@@ -18,12 +18,52 @@ type PerfDataSourceGitLabSpec struct {
 	Config         DataSourceGitLabConfig `json:"config"`
 	PerfServerName string                 `json:"perfServerName"`
 	CodebaseName   string                 `json:"codebaseName"`
+	// PerfServerKind is the kind of the PERF server, PerfServer or ClusterPerfServer. PerfServer is used if empty.
+	PerfServerKind string `json:"perfServerKind,omitempty"`
+	// PerfNode routes the data source to a child node of the PerfServer project, given by its name or
+	// slash-separated path relative to the project node. The project node itself is used if empty.
+	PerfNode string `json:"perfNode,omitempty"`
+	// CreatePerfNode allows creating the missing nodes of PerfNode.
+	CreatePerfNode bool `json:"createPerfNode,omitempty"`
+	// Validation enables checking the repositories and branches against GitLab before they're sent to PERF.
+	// It's one of skip, block or warn. The entries aren't checked if empty.
+	Validation string `json:"validation,omitempty"`
+	// EdpComponent names the EDPComponent whose url is used if Config.Url is empty. The credentials are taken from
+	// its Secret, if it exists, when Config.CredentialName is empty.
+	EdpComponent string `json:"edpComponent,omitempty"`
+	// Discovery resolves more repositories from GitLab periodically. They're sent to PERF along with Repositories.
+	Discovery *GitLabRepositoryDiscovery `json:"discovery,omitempty"`
+}
+
+// GitLabRepositoryDiscovery selects the repositories of GitLab groups, including their subgroups.
+type GitLabRepositoryDiscovery struct {
+	// Groups are given by their full paths, e.g. edp/backend.
+	Groups []string `json:"groups"`
+	// Include and Exclude filter the repositories by path with namespace, e.g. edp/*-api.
+	// A pattern is a glob unless it starts with regex:. All repositories are included if Include is empty.
+	Include []string `json:"include,omitempty"`
+	Exclude []string `json:"exclude,omitempty"`
+	// Interval is the period of resolving the repositories in GitLab, 15m by default.
+	Interval string `json:"interval,omitempty"`
 }
 
 type DataSourceGitLabConfig struct {
 	Repositories []string `json:"repositories"`
-	Url          string   `json:"url"`
+	Url          string   `json:"url,omitempty"`
 	Branches     []string `json:"branches"`
+	// CredentialName refers to a Secret with the username and password keys. It's resolved along with the url
+	// if empty.
+	CredentialName string `json:"credentialName,omitempty"`
+	// InstanceId identifies the GitLab instance in PERF. Url is used on creation and the PERF value is kept
+	// on update if it's empty.
+	InstanceId string `json:"instanceId,omitempty"`
+	// WithMembership limits the collected repositories to the ones the PERF user is a member of.
+	// WithMembership, AllPublic and AllBranches keep the values set in PERF if they're omitted.
+	WithMembership *bool `json:"withMembership,omitempty"`
+	// AllPublic collects all public repositories available to the PERF user.
+	AllPublic *bool `json:"allPublic,omitempty"`
+	// AllBranches collects all branches of the repositories instead of Branches.
+	AllBranches *bool `json:"allBranches,omitempty"`
 }
 
 // PerfDataSourceGitLabStatus defines the observed state of PerfDataSourceGitLab
@@ -34,6 +74,21 @@ type PerfDataSourceGitLabStatus struct {
 	// Important: Run "operator-sdk generate k8s" to regenerate code after modifying this file
 	// Add custom validation using kubebuilder tags: https://book.kubebuilder.io/beyond_basics/generating_crd.html
 	Status string `json:"status"`
+	// Url is the url in use, taken from Config.Url or the EDPComponent.
+	Url string `json:"url,omitempty"`
+	// CredentialName is the Secret in use, taken from Config.CredentialName or resolved along with the url.
+	CredentialName string `json:"credentialName,omitempty"`
+	// PreviousUrl is the url PERF holds after Url has changed. The data source is looked up by it to be updated
+	// instead of a new one being created, it's cleared once PERF is updated.
+	PreviousUrl string `json:"previousUrl,omitempty"`
+	// Conditions report the validation of the data source entries.
+	Conditions []DataSourceCondition `json:"conditions,omitempty"`
+	// UnknownRepositories holds the repositories that haven't been found in GitLab.
+	UnknownRepositories []string `json:"unknownRepositories,omitempty"`
+	// UnknownBranches holds the branches that haven't been found in any of the repositories.
+	UnknownBranches []string `json:"unknownBranches,omitempty"`
+	// DiscoveredRepositories holds the repositories resolved by the last discovery.
+	DiscoveredRepositories []string `json:"discoveredRepositories,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -18,11 +18,43 @@ type PerfDataSourceJenkinsSpec struct {
 	Config         DataSourceJenkinsConfig `json:"config"`
 	PerfServerName string                  `json:"perfServerName"`
 	CodebaseName   string                  `json:"codebaseName"`
+	// PerfServerKind is the kind of the PERF server, PerfServer or ClusterPerfServer. PerfServer is used if empty.
+	PerfServerKind string `json:"perfServerKind,omitempty"`
+	// PerfNode routes the data source to a child node of the PerfServer project, given by its name or
+	// slash-separated path relative to the project node. The project node itself is used if empty.
+	PerfNode string `json:"perfNode,omitempty"`
+	// CreatePerfNode allows creating the missing nodes of PerfNode.
+	CreatePerfNode bool `json:"createPerfNode,omitempty"`
+	// Validation enables checking JobNames against Jenkins before they're sent to PERF.
+	// It's one of skip, block or warn. The job names aren't checked if empty.
+	Validation string `json:"validation,omitempty"`
+	// EdpComponent names the EDPComponent whose url is used if Config.Url is empty. The credentials are taken from
+	// its Secret, if it exists, when Config.CredentialName is empty.
+	EdpComponent string `json:"edpComponent,omitempty"`
+	// JenkinsName names the Jenkins CR of the EDP Jenkins operator whose url and credentials are used if they aren't set
+	// in Config. It takes precedence over EdpComponent.
+	JenkinsName string `json:"jenkinsName,omitempty"`
+	// Discovery resolves more job names from Jenkins periodically. They're sent to PERF along with JobNames.
+	Discovery *JenkinsJobDiscovery `json:"discovery,omitempty"`
+}
+
+// JenkinsJobDiscovery selects Jenkins jobs by folders and full name patterns.
+type JenkinsJobDiscovery struct {
+	// Folders are searched for jobs recursively, e.g. /fake-name. The root is searched if empty.
+	Folders []string `json:"folders,omitempty"`
+	// Patterns match the full job names, e.g. /*/MASTER-Build-*. A pattern is a glob unless it starts with regex:,
+	// e.g. regex:^/.+/MASTER-Build-.+$. All jobs of the folders are taken if empty.
+	Patterns []string `json:"patterns,omitempty"`
+	// Interval is the period of resolving the jobs in Jenkins, 15m by default.
+	Interval string `json:"interval,omitempty"`
 }
 
 type DataSourceJenkinsConfig struct {
 	JobNames []string `json:"jobNames"`
-	Url      string   `json:"url"`
+	Url      string   `json:"url,omitempty"`
+	// CredentialName refers to a Secret with the username and password keys. It's resolved along with the url
+	// if empty.
+	CredentialName string `json:"credentialName,omitempty"`
 }
 
 // PerfDataSourceJenkinsStatus defines the observed state of PerfDataSource
@@ -33,6 +65,19 @@ type PerfDataSourceJenkinsStatus struct {
 	// Important: Run "operator-sdk generate k8s" to regenerate code after modifying this file
 	// Add custom validation using kubebuilder tags: https://book.kubebuilder.io/beyond_basics/generating_crd.html
 	Status string `json:"status"`
+	// Url is the url in use, taken from Config.Url, the Jenkins CR or the EDPComponent.
+	Url string `json:"url,omitempty"`
+	// CredentialName is the Secret in use, taken from Config.CredentialName or resolved along with the url.
+	CredentialName string `json:"credentialName,omitempty"`
+	// PreviousUrl is the url PERF holds after Url has changed. The data source is looked up by it to be updated
+	// instead of a new one being created, it's cleared once PERF is updated.
+	PreviousUrl string `json:"previousUrl,omitempty"`
+	// Conditions report the validation of the data source entries.
+	Conditions []DataSourceCondition `json:"conditions,omitempty"`
+	// UnknownJobNames holds the job names that haven't been found in Jenkins.
+	UnknownJobNames []string `json:"unknownJobNames,omitempty"`
+	// DiscoveredJobNames holds the job names resolved by the last discovery.
+	DiscoveredJobNames []string `json:"discoveredJobNames,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -18,11 +18,42 @@ type PerfDataSourceSonarSpec struct {
 	Config         DataSourceSonarConfig `json:"config"`
 	PerfServerName string                `json:"perfServerName"`
 	CodebaseName   string                `json:"codebaseName"`
+	// PerfServerKind is the kind of the PERF server, PerfServer or ClusterPerfServer. PerfServer is used if empty.
+	PerfServerKind string `json:"perfServerKind,omitempty"`
+	// PerfNode routes the data source to a child node of the PerfServer project, given by its name or
+	// slash-separated path relative to the project node. The project node itself is used if empty.
+	PerfNode string `json:"perfNode,omitempty"`
+	// CreatePerfNode allows creating the missing nodes of PerfNode.
+	CreatePerfNode bool `json:"createPerfNode,omitempty"`
+	// Validation enables checking the project keys against SonarQube before they're sent to PERF.
+	// It's one of skip, block or warn. The entries aren't checked if empty.
+	Validation string `json:"validation,omitempty"`
+	// EdpComponent names the EDPComponent whose url is used if Config.Url is empty. The credentials are taken from
+	// its Secret, if it exists, when Config.CredentialName is empty.
+	EdpComponent string `json:"edpComponent,omitempty"`
+	// SonarName names the Sonar CR of the EDP Sonar operator whose url and credentials are used if they aren't set
+	// in Config. It takes precedence over EdpComponent.
+	SonarName string `json:"sonarName,omitempty"`
+	// Discovery resolves more project keys from SonarQube periodically. They're sent to PERF along with ProjectKeys.
+	Discovery *SonarProjectDiscovery `json:"discovery,omitempty"`
+}
+
+// SonarProjectDiscovery selects SonarQube projects by key prefixes or tags.
+type SonarProjectDiscovery struct {
+	// KeyPrefixes select the projects whose keys start with any of them.
+	KeyPrefixes []string `json:"keyPrefixes,omitempty"`
+	// Tags select the projects having any of them.
+	Tags []string `json:"tags,omitempty"`
+	// Interval is the period of resolving the projects in SonarQube, 15m by default.
+	Interval string `json:"interval,omitempty"`
 }
 
 type DataSourceSonarConfig struct {
 	ProjectKeys []string `json:"projectKeys"`
-	Url         string   `json:"url"`
+	Url         string   `json:"url,omitempty"`
+	// CredentialName refers to a Secret with the username and password keys. It's resolved along with the url
+	// if empty.
+	CredentialName string `json:"credentialName,omitempty"`
 }
 
 // PerfDataSourceSonartatus defines the observed state of PerfDataSourceSonar
@@ -33,6 +64,19 @@ type PerfDataSourceSonarStatus struct {
 	// Important: Run "operator-sdk generate k8s" to regenerate code after modifying this file
 	// Add custom validation using kubebuilder tags: https://book.kubebuilder.io/beyond_basics/generating_crd.html
 	Status string `json:"status"`
+	// Url is the url in use, taken from Config.Url, the Sonar CR or the EDPComponent.
+	Url string `json:"url,omitempty"`
+	// CredentialName is the Secret in use, taken from Config.CredentialName or resolved along with the url.
+	CredentialName string `json:"credentialName,omitempty"`
+	// PreviousUrl is the url PERF holds after Url has changed. The data source is looked up by it to be updated
+	// instead of a new one being created, it's cleared once PERF is updated.
+	PreviousUrl string `json:"previousUrl,omitempty"`
+	// Conditions report the validation of the data source entries.
+	Conditions []DataSourceCondition `json:"conditions,omitempty"`
+	// UnknownProjectKeys holds the project keys that haven't been found in SonarQube.
+	UnknownProjectKeys []string `json:"unknownProjectKeys,omitempty"`
+	// DiscoveredProjectKeys holds the project keys resolved by the last discovery.
+	DiscoveredProjectKeys []string `json:"discoveredProjectKeys,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
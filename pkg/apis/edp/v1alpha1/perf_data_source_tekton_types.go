@@ -0,0 +1,87 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"time"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// PerfDataSourceTektonSpec defines the desired state of PerfDataSourceTekton
+// +k8s:openapi-gen=true
+type PerfDataSourceTektonSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "operator-sdk generate k8s" to regenerate code after modifying this file
+	// Add custom validation using kubebuilder tags: https://book.kubebuilder.io/beyond_basics/generating_crd.html
+	Name           string                 `json:"name"`
+	Type           string                 `json:"type"`
+	Config         DataSourceTektonConfig `json:"config"`
+	PerfServerName string                 `json:"perfServerName"`
+	// PerfServerKind is the kind of the PERF server, PerfServer or ClusterPerfServer. PerfServer is used if empty.
+	PerfServerKind string `json:"perfServerKind,omitempty"`
+	// PerfNode routes the data source to a child node of the PerfServer project, given by its name or
+	// slash-separated path relative to the project node. The project node itself is used if empty.
+	PerfNode string `json:"perfNode,omitempty"`
+	// CreatePerfNode allows creating the missing nodes of PerfNode.
+	CreatePerfNode bool `json:"createPerfNode,omitempty"`
+}
+
+type DataSourceTektonConfig struct {
+	// CodebaseSelector selects Codebase CRs whose PipelineRuns are collected.
+	CodebaseSelector *metav1.LabelSelector `json:"codebaseSelector,omitempty"`
+	// PipelineRunSelector narrows down collected PipelineRuns, e.g. to build pipelines only.
+	PipelineRunSelector *metav1.LabelSelector `json:"pipelineRunSelector,omitempty"`
+	// CodebaseLabel is the PipelineRun label that holds the codebase name.
+	CodebaseLabel string `json:"codebaseLabel,omitempty"`
+	// Window is the period the metrics are calculated for, e.g. 168h.
+	Window string `json:"window,omitempty"`
+	// Interval defines how often PipelineRuns are collected, e.g. 15m.
+	Interval string `json:"interval,omitempty"`
+}
+
+// PerfDataSourceTektonStatus defines the observed state of PerfDataSourceTekton
+// +k8s:openapi-gen=true
+
+type PerfDataSourceTektonStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "operator-sdk generate k8s" to regenerate code after modifying this file
+	// Add custom validation using kubebuilder tags: https://book.kubebuilder.io/beyond_basics/generating_crd.html
+	Status          string               `json:"status"`
+	LastTimeUpdated time.Time            `json:"last_time_updated"`
+	Codebases       []PipelineRunMetrics `json:"codebases,omitempty"`
+}
+
+type PipelineRunMetrics struct {
+	Codebase               string `json:"codebase"`
+	Total                  int    `json:"total"`
+	Succeeded              int    `json:"succeeded"`
+	Failed                 int    `json:"failed"`
+	SuccessRate            int    `json:"successRate"`
+	AverageDurationSeconds int64  `json:"averageDurationSeconds"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerfDataSourceTekton is the Schema for the perfdatasourcetektons API
+// +k8s:openapi-gen=true
+type PerfDataSourceTekton struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PerfDataSourceTektonSpec   `json:"spec,omitempty"`
+	Status PerfDataSourceTektonStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerfDataSourceTektonList contains a list of PerfDataSourceTekton
+type PerfDataSourceTektonList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PerfDataSourceTekton `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PerfDataSourceTekton{}, &PerfDataSourceTektonList{})
+}
@@ -0,0 +1,72 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"time"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// PerfReportSpec defines the desired state of PerfReport
+// +k8s:openapi-gen=true
+type PerfReportSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "operator-sdk generate k8s" to regenerate code after modifying this file
+	// Add custom validation using kubebuilder tags: https://book.kubebuilder.io/beyond_basics/generating_crd.html
+	PerfServerName string `json:"perfServerName"`
+	// PerfServerKind is the kind of the PERF server, PerfServer or ClusterPerfServer. PerfServer is used if empty.
+	PerfServerKind string `json:"perfServerKind,omitempty"`
+	// NodeName is the PERF project or child node to report on. The PerfServer project is used if empty.
+	NodeName string `json:"nodeName,omitempty"`
+	// Metrics is the set of KPI names to snapshot. All node KPIs are taken if empty.
+	Metrics []string `json:"metrics,omitempty"`
+	// Interval defines how often the snapshot is refreshed, e.g. 1h.
+	Interval string `json:"interval,omitempty"`
+}
+
+// PerfReportStatus defines the observed state of PerfReport
+// +k8s:openapi-gen=true
+
+type PerfReportStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "operator-sdk generate k8s" to regenerate code after modifying this file
+	// Add custom validation using kubebuilder tags: https://book.kubebuilder.io/beyond_basics/generating_crd.html
+	Status          string    `json:"status"`
+	LastTimeUpdated time.Time `json:"last_time_updated"`
+	NodeId          int       `json:"nodeId,omitempty"`
+	Kpis            []PerfKpi `json:"kpis,omitempty"`
+}
+
+type PerfKpi struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	Unit  string `json:"unit,omitempty"`
+	// Health is the PERF rating of the KPI value, e.g. GREEN, AMBER or RED.
+	Health string `json:"health,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerfReport is the Schema for the perfreports API
+// +k8s:openapi-gen=true
+type PerfReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PerfReportSpec   `json:"spec,omitempty"`
+	Status PerfReportStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerfReportList contains a list of PerfReport
+type PerfReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PerfReport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PerfReport{}, &PerfReportList{})
+}
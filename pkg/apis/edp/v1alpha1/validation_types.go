@@ -0,0 +1,29 @@
+package v1alpha1
+
+import "time"
+
+// Validation policies of the data source entries checked against the source system before they're sent to PERF.
+const (
+	// ValidationSkip sends only the entries that exist in the source system to PERF.
+	ValidationSkip = "skip"
+	// ValidationBlock doesn't update the data source in PERF until all entries are found.
+	ValidationBlock = "block"
+	// ValidationWarn reports the unknown entries and sends all entries to PERF.
+	ValidationWarn = "warn"
+)
+
+const (
+	ConditionTrue    = "True"
+	ConditionFalse   = "False"
+	ConditionUnknown = "Unknown"
+)
+
+// DataSourceCondition describes the observed state of a data source aspect, e.g. the validity of its entries.
+type DataSourceCondition struct {
+	Type string `json:"type"`
+	// Status is True, False or Unknown.
+	Status             string    `json:"status"`
+	Reason             string    `json:"reason,omitempty"`
+	Message            string    `json:"message,omitempty"`
+	LastTransitionTime time.Time `json:"lastTransitionTime,omitempty"`
+}
@@ -1,3 +1,4 @@
+//go:build !ignore_autogenerated
 // +build !ignore_autogenerated
 
 // Code generated by operator-sdk. DO NOT EDIT.
@@ -5,6 +6,7 @@
 package v1alpha1
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -13,7 +15,7 @@ func (in *PerfServer) DeepCopyInto(out *PerfServer) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
@@ -36,6 +38,27 @@ func (in *PerfServer) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerfServerAuth) DeepCopyInto(out *PerfServerAuth) {
+	*out = *in
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerfServerAuth.
+func (in *PerfServerAuth) DeepCopy() *PerfServerAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(PerfServerAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PerfServerList) DeepCopyInto(out *PerfServerList) {
 	*out = *in
@@ -69,9 +92,66 @@ func (in *PerfServerList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerfServerEdpComponent) DeepCopyInto(out *PerfServerEdpComponent) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerfServerEdpComponent.
+func (in *PerfServerEdpComponent) DeepCopy() *PerfServerEdpComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(PerfServerEdpComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerfServerLuminate) DeepCopyInto(out *PerfServerLuminate) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerfServerLuminate.
+func (in *PerfServerLuminate) DeepCopy() *PerfServerLuminate {
+	if in == nil {
+		return nil
+	}
+	out := new(PerfServerLuminate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PerfServerSpec) DeepCopyInto(out *PerfServerSpec) {
 	*out = *in
+	if in.ExporterNodes != nil {
+		in, out := &in.ExporterNodes, &out.ExporterNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(PerfServerAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Luminate != nil {
+		in, out := &in.Luminate, &out.Luminate
+		*out = new(PerfServerLuminate)
+		**out = **in
+	}
+	if in.Transport != nil {
+		in, out := &in.Transport, &out.Transport
+		*out = new(PerfServerTransport)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EdpComponent != nil {
+		in, out := &in.EdpComponent, &out.EdpComponent
+		*out = new(PerfServerEdpComponent)
+		**out = **in
+	}
 	return
 }
 
@@ -85,6 +165,27 @@ func (in *PerfServerSpec) DeepCopy() *PerfServerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerfServerTransport) DeepCopyInto(out *PerfServerTransport) {
+	*out = *in
+	if in.NoProxy != nil {
+		in, out := &in.NoProxy, &out.NoProxy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerfServerTransport.
+func (in *PerfServerTransport) DeepCopy() *PerfServerTransport {
+	if in == nil {
+		return nil
+	}
+	out := new(PerfServerTransport)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PerfServerStatus) DeepCopyInto(out *PerfServerStatus) {
 	*out = *in
@@ -101,12 +202,95 @@ func (in *PerfServerStatus) DeepCopy() *PerfServerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPerfServer) DeepCopyInto(out *ClusterPerfServer) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPerfServer.
+func (in *ClusterPerfServer) DeepCopy() *ClusterPerfServer {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPerfServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterPerfServer) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPerfServerList) DeepCopyInto(out *ClusterPerfServerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterPerfServer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPerfServerList.
+func (in *ClusterPerfServerList) DeepCopy() *ClusterPerfServerList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPerfServerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterPerfServerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPerfServerSpec) DeepCopyInto(out *ClusterPerfServerSpec) {
+	*out = *in
+	in.PerfServerSpec.DeepCopyInto(&out.PerfServerSpec)
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPerfServerSpec.
+func (in *ClusterPerfServerSpec) DeepCopy() *ClusterPerfServerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPerfServerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PerfDataSourceJenkins) DeepCopyInto(out *PerfDataSourceJenkins) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
@@ -165,6 +349,11 @@ func (in *PerfDataSourceJenkinsList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PerfDataSourceJenkinsSpec) DeepCopyInto(out *PerfDataSourceJenkinsSpec) {
 	*out = *in
+	if in.Discovery != nil {
+		in, out := &in.Discovery, &out.Discovery
+		*out = new(JenkinsJobDiscovery)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -178,9 +367,50 @@ func (in *PerfDataSourceJenkinsSpec) DeepCopy() *PerfDataSourceJenkinsSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsJobDiscovery) DeepCopyInto(out *JenkinsJobDiscovery) {
+	*out = *in
+	if in.Folders != nil {
+		in, out := &in.Folders, &out.Folders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Patterns != nil {
+		in, out := &in.Patterns, &out.Patterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsJobDiscovery.
+func (in *JenkinsJobDiscovery) DeepCopy() *JenkinsJobDiscovery {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsJobDiscovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PerfDataSourceJenkinsStatus) DeepCopyInto(out *PerfDataSourceJenkinsStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]DataSourceCondition, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnknownJobNames != nil {
+		in, out := &in.UnknownJobNames, &out.UnknownJobNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DiscoveredJobNames != nil {
+		in, out := &in.DiscoveredJobNames, &out.DiscoveredJobNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -199,7 +429,7 @@ func (in *PerfDataSourceSonar) DeepCopyInto(out *PerfDataSourceSonar) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
@@ -258,6 +488,11 @@ func (in *PerfDataSourceSonarList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PerfDataSourceSonarSpec) DeepCopyInto(out *PerfDataSourceSonarSpec) {
 	*out = *in
+	if in.Discovery != nil {
+		in, out := &in.Discovery, &out.Discovery
+		*out = new(SonarProjectDiscovery)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -271,9 +506,50 @@ func (in *PerfDataSourceSonarSpec) DeepCopy() *PerfDataSourceSonarSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SonarProjectDiscovery) DeepCopyInto(out *SonarProjectDiscovery) {
+	*out = *in
+	if in.KeyPrefixes != nil {
+		in, out := &in.KeyPrefixes, &out.KeyPrefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SonarProjectDiscovery.
+func (in *SonarProjectDiscovery) DeepCopy() *SonarProjectDiscovery {
+	if in == nil {
+		return nil
+	}
+	out := new(SonarProjectDiscovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PerfDataSourceSonarStatus) DeepCopyInto(out *PerfDataSourceSonarStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]DataSourceCondition, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnknownProjectKeys != nil {
+		in, out := &in.UnknownProjectKeys, &out.UnknownProjectKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DiscoveredProjectKeys != nil {
+		in, out := &in.DiscoveredProjectKeys, &out.DiscoveredProjectKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -292,7 +568,7 @@ func (in *PerfDataSourceGitLab) DeepCopyInto(out *PerfDataSourceGitLab) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
@@ -351,6 +627,12 @@ func (in *PerfDataSourceGitLabList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PerfDataSourceGitLabSpec) DeepCopyInto(out *PerfDataSourceGitLabSpec) {
 	*out = *in
+	in.Config.DeepCopyInto(&out.Config)
+	if in.Discovery != nil {
+		in, out := &in.Discovery, &out.Discovery
+		*out = new(GitLabRepositoryDiscovery)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -364,9 +646,101 @@ func (in *PerfDataSourceGitLabSpec) DeepCopy() *PerfDataSourceGitLabSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataSourceGitLabConfig) DeepCopyInto(out *DataSourceGitLabConfig) {
+	*out = *in
+	if in.Repositories != nil {
+		in, out := &in.Repositories, &out.Repositories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.WithMembership != nil {
+		in, out := &in.WithMembership, &out.WithMembership
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AllPublic != nil {
+		in, out := &in.AllPublic, &out.AllPublic
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AllBranches != nil {
+		in, out := &in.AllBranches, &out.AllBranches
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataSourceGitLabConfig.
+func (in *DataSourceGitLabConfig) DeepCopy() *DataSourceGitLabConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DataSourceGitLabConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitLabRepositoryDiscovery) DeepCopyInto(out *GitLabRepositoryDiscovery) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitLabRepositoryDiscovery.
+func (in *GitLabRepositoryDiscovery) DeepCopy() *GitLabRepositoryDiscovery {
+	if in == nil {
+		return nil
+	}
+	out := new(GitLabRepositoryDiscovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PerfDataSourceGitLabStatus) DeepCopyInto(out *PerfDataSourceGitLabStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]DataSourceCondition, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnknownRepositories != nil {
+		in, out := &in.UnknownRepositories, &out.UnknownRepositories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnknownBranches != nil {
+		in, out := &in.UnknownBranches, &out.UnknownBranches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DiscoveredRepositories != nil {
+		in, out := &in.DiscoveredRepositories, &out.DiscoveredRepositories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -379,3 +753,520 @@ func (in *PerfDataSourceGitLabStatus) DeepCopy() *PerfDataSourceGitLabStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerfDataSourceBitbucket) DeepCopyInto(out *PerfDataSourceBitbucket) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerfDataSourceBitbucket.
+func (in *PerfDataSourceBitbucket) DeepCopy() *PerfDataSourceBitbucket {
+	if in == nil {
+		return nil
+	}
+	out := new(PerfDataSourceBitbucket)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PerfDataSourceBitbucket) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerfDataSourceBitbucketList) DeepCopyInto(out *PerfDataSourceBitbucketList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PerfDataSourceBitbucket, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerfDataSourceBitbucketList.
+func (in *PerfDataSourceBitbucketList) DeepCopy() *PerfDataSourceBitbucketList {
+	if in == nil {
+		return nil
+	}
+	out := new(PerfDataSourceBitbucketList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PerfDataSourceBitbucketList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerfDataSourceBitbucketSpec) DeepCopyInto(out *PerfDataSourceBitbucketSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerfDataSourceBitbucketSpec.
+func (in *PerfDataSourceBitbucketSpec) DeepCopy() *PerfDataSourceBitbucketSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PerfDataSourceBitbucketSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerfDataSourceBitbucketStatus) DeepCopyInto(out *PerfDataSourceBitbucketStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerfDataSourceBitbucketStatus.
+func (in *PerfDataSourceBitbucketStatus) DeepCopy() *PerfDataSourceBitbucketStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PerfDataSourceBitbucketStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerfDataSourceAzureDevOps) DeepCopyInto(out *PerfDataSourceAzureDevOps) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerfDataSourceAzureDevOps.
+func (in *PerfDataSourceAzureDevOps) DeepCopy() *PerfDataSourceAzureDevOps {
+	if in == nil {
+		return nil
+	}
+	out := new(PerfDataSourceAzureDevOps)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PerfDataSourceAzureDevOps) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerfDataSourceAzureDevOpsList) DeepCopyInto(out *PerfDataSourceAzureDevOpsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PerfDataSourceAzureDevOps, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerfDataSourceAzureDevOpsList.
+func (in *PerfDataSourceAzureDevOpsList) DeepCopy() *PerfDataSourceAzureDevOpsList {
+	if in == nil {
+		return nil
+	}
+	out := new(PerfDataSourceAzureDevOpsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PerfDataSourceAzureDevOpsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerfDataSourceAzureDevOpsSpec) DeepCopyInto(out *PerfDataSourceAzureDevOpsSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerfDataSourceAzureDevOpsSpec.
+func (in *PerfDataSourceAzureDevOpsSpec) DeepCopy() *PerfDataSourceAzureDevOpsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PerfDataSourceAzureDevOpsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerfDataSourceAzureDevOpsStatus) DeepCopyInto(out *PerfDataSourceAzureDevOpsStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerfDataSourceAzureDevOpsStatus.
+func (in *PerfDataSourceAzureDevOpsStatus) DeepCopy() *PerfDataSourceAzureDevOpsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PerfDataSourceAzureDevOpsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerfDataSourceTekton) DeepCopyInto(out *PerfDataSourceTekton) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerfDataSourceTekton.
+func (in *PerfDataSourceTekton) DeepCopy() *PerfDataSourceTekton {
+	if in == nil {
+		return nil
+	}
+	out := new(PerfDataSourceTekton)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PerfDataSourceTekton) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerfDataSourceTektonList) DeepCopyInto(out *PerfDataSourceTektonList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PerfDataSourceTekton, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerfDataSourceTektonList.
+func (in *PerfDataSourceTektonList) DeepCopy() *PerfDataSourceTektonList {
+	if in == nil {
+		return nil
+	}
+	out := new(PerfDataSourceTektonList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PerfDataSourceTektonList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerfDataSourceTektonSpec) DeepCopyInto(out *PerfDataSourceTektonSpec) {
+	*out = *in
+	in.Config.DeepCopyInto(&out.Config)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerfDataSourceTektonSpec.
+func (in *PerfDataSourceTektonSpec) DeepCopy() *PerfDataSourceTektonSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PerfDataSourceTektonSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataSourceTektonConfig) DeepCopyInto(out *DataSourceTektonConfig) {
+	*out = *in
+	if in.CodebaseSelector != nil {
+		in, out := &in.CodebaseSelector, &out.CodebaseSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PipelineRunSelector != nil {
+		in, out := &in.PipelineRunSelector, &out.PipelineRunSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataSourceTektonConfig.
+func (in *DataSourceTektonConfig) DeepCopy() *DataSourceTektonConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DataSourceTektonConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerfDataSourceTektonStatus) DeepCopyInto(out *PerfDataSourceTektonStatus) {
+	*out = *in
+	if in.Codebases != nil {
+		in, out := &in.Codebases, &out.Codebases
+		*out = make([]PipelineRunMetrics, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerfDataSourceTektonStatus.
+func (in *PerfDataSourceTektonStatus) DeepCopy() *PerfDataSourceTektonStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PerfDataSourceTektonStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerfDoraMetrics) DeepCopyInto(out *PerfDoraMetrics) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerfDoraMetrics.
+func (in *PerfDoraMetrics) DeepCopy() *PerfDoraMetrics {
+	if in == nil {
+		return nil
+	}
+	out := new(PerfDoraMetrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PerfDoraMetrics) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerfDoraMetricsList) DeepCopyInto(out *PerfDoraMetricsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PerfDoraMetrics, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerfDoraMetricsList.
+func (in *PerfDoraMetricsList) DeepCopy() *PerfDoraMetricsList {
+	if in == nil {
+		return nil
+	}
+	out := new(PerfDoraMetricsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PerfDoraMetricsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerfDoraMetricsSpec) DeepCopyInto(out *PerfDoraMetricsSpec) {
+	*out = *in
+	if in.Stages != nil {
+		in, out := &in.Stages, &out.Stages
+		*out = make([]DoraStage, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerfDoraMetricsSpec.
+func (in *PerfDoraMetricsSpec) DeepCopy() *PerfDoraMetricsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PerfDoraMetricsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerfDoraMetricsStatus) DeepCopyInto(out *PerfDoraMetricsStatus) {
+	*out = *in
+	if in.Stages != nil {
+		in, out := &in.Stages, &out.Stages
+		*out = make([]DoraStageMetrics, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerfDoraMetricsStatus.
+func (in *PerfDoraMetricsStatus) DeepCopy() *PerfDoraMetricsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PerfDoraMetricsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerfReport) DeepCopyInto(out *PerfReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerfReport.
+func (in *PerfReport) DeepCopy() *PerfReport {
+	if in == nil {
+		return nil
+	}
+	out := new(PerfReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PerfReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerfReportList) DeepCopyInto(out *PerfReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PerfReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerfReportList.
+func (in *PerfReportList) DeepCopy() *PerfReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(PerfReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PerfReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerfReportSpec) DeepCopyInto(out *PerfReportSpec) {
+	*out = *in
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerfReportSpec.
+func (in *PerfReportSpec) DeepCopy() *PerfReportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PerfReportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerfReportStatus) DeepCopyInto(out *PerfReportStatus) {
+	*out = *in
+	if in.Kpis != nil {
+		in, out := &in.Kpis, &out.Kpis
+		*out = make([]PerfKpi, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerfReportStatus.
+func (in *PerfReportStatus) DeepCopy() *PerfReportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PerfReportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -1,3 +1,4 @@
+//go:build !ignore_autogenerated
 // +build !ignore_autogenerated
 
 // This file was autogenerated by openapi-gen. Do not edit it manually!
@@ -11,18 +12,35 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
-		"./pkg/apis/edp/v1alpha1.PerfServer":                  schema_pkg_apis_edp_v1alpha1_PerfServer(ref),
-		"./pkg/apis/edp/v1alpha1.PerfServerSpec":              schema_pkg_apis_edp_v1alpha1_PerfServerSpec(ref),
-		"./pkg/apis/edp/v1alpha1.PerfServerStatus":            schema_pkg_apis_edp_v1alpha1_PerfStatus(ref),
-		"./pkg/apis/edp/v1alpha1.PerfDataSourceJenkins":       schema_pkg_apis_edp_v1alpha1_PerfDataSourceJenkins(ref),
-		"./pkg/apis/edp/v1alpha1.PerfDataSourceJenkinsSpec":   schema_pkg_apis_edp_v1alpha1_PerfDataSourceJenkinsSpec(ref),
-		"./pkg/apis/edp/v1alpha1.PerfDataSourceJenkinsStatus": schema_pkg_apis_edp_v1alpha1_PerfDataSourceJenkinsStatus(ref),
-		"./pkg/apis/edp/v1alpha1.PerfDataSourceSonar":         schema_pkg_apis_edp_v1alpha1_PerfDataSourceSonar(ref),
-		"./pkg/apis/edp/v1alpha1.PerfDataSourceSonarSpec":     schema_pkg_apis_edp_v1alpha1_PerfDataSourceSonarSpec(ref),
-		"./pkg/apis/edp/v1alpha1.PerfDataSourceSonarStatus":   schema_pkg_apis_edp_v1alpha1_PerfDataSourceSonarStatus(ref),
-		"./pkg/apis/edp/v1alpha1.PerfDataSourceGitLab":        schema_pkg_apis_edp_v1alpha1_PerfDataSourceGitLab(ref),
-		"./pkg/apis/edp/v1alpha1.PerfDataSourceGitLabSpec":    schema_pkg_apis_edp_v1alpha1_PerfDataSourceGitLabSpec(ref),
-		"./pkg/apis/edp/v1alpha1.PerfDataSourceGitLabStatus":  schema_pkg_apis_edp_v1alpha1_PerfDataSourceGitLabStatus(ref),
+		"./pkg/apis/edp/v1alpha1.PerfServer":                      schema_pkg_apis_edp_v1alpha1_PerfServer(ref),
+		"./pkg/apis/edp/v1alpha1.PerfServerSpec":                  schema_pkg_apis_edp_v1alpha1_PerfServerSpec(ref),
+		"./pkg/apis/edp/v1alpha1.PerfServerStatus":                schema_pkg_apis_edp_v1alpha1_PerfStatus(ref),
+		"./pkg/apis/edp/v1alpha1.ClusterPerfServer":               schema_pkg_apis_edp_v1alpha1_ClusterPerfServer(ref),
+		"./pkg/apis/edp/v1alpha1.ClusterPerfServerSpec":           schema_pkg_apis_edp_v1alpha1_ClusterPerfServerSpec(ref),
+		"./pkg/apis/edp/v1alpha1.PerfDataSourceJenkins":           schema_pkg_apis_edp_v1alpha1_PerfDataSourceJenkins(ref),
+		"./pkg/apis/edp/v1alpha1.PerfDataSourceJenkinsSpec":       schema_pkg_apis_edp_v1alpha1_PerfDataSourceJenkinsSpec(ref),
+		"./pkg/apis/edp/v1alpha1.PerfDataSourceJenkinsStatus":     schema_pkg_apis_edp_v1alpha1_PerfDataSourceJenkinsStatus(ref),
+		"./pkg/apis/edp/v1alpha1.PerfDataSourceSonar":             schema_pkg_apis_edp_v1alpha1_PerfDataSourceSonar(ref),
+		"./pkg/apis/edp/v1alpha1.PerfDataSourceSonarSpec":         schema_pkg_apis_edp_v1alpha1_PerfDataSourceSonarSpec(ref),
+		"./pkg/apis/edp/v1alpha1.PerfDataSourceSonarStatus":       schema_pkg_apis_edp_v1alpha1_PerfDataSourceSonarStatus(ref),
+		"./pkg/apis/edp/v1alpha1.PerfDataSourceGitLab":            schema_pkg_apis_edp_v1alpha1_PerfDataSourceGitLab(ref),
+		"./pkg/apis/edp/v1alpha1.PerfDataSourceGitLabSpec":        schema_pkg_apis_edp_v1alpha1_PerfDataSourceGitLabSpec(ref),
+		"./pkg/apis/edp/v1alpha1.PerfDataSourceGitLabStatus":      schema_pkg_apis_edp_v1alpha1_PerfDataSourceGitLabStatus(ref),
+		"./pkg/apis/edp/v1alpha1.PerfDataSourceBitbucket":         schema_pkg_apis_edp_v1alpha1_PerfDataSourceBitbucket(ref),
+		"./pkg/apis/edp/v1alpha1.PerfDataSourceBitbucketSpec":     schema_pkg_apis_edp_v1alpha1_PerfDataSourceBitbucketSpec(ref),
+		"./pkg/apis/edp/v1alpha1.PerfDataSourceBitbucketStatus":   schema_pkg_apis_edp_v1alpha1_PerfDataSourceBitbucketStatus(ref),
+		"./pkg/apis/edp/v1alpha1.PerfDataSourceAzureDevOps":       schema_pkg_apis_edp_v1alpha1_PerfDataSourceAzureDevOps(ref),
+		"./pkg/apis/edp/v1alpha1.PerfDataSourceAzureDevOpsSpec":   schema_pkg_apis_edp_v1alpha1_PerfDataSourceAzureDevOpsSpec(ref),
+		"./pkg/apis/edp/v1alpha1.PerfDataSourceAzureDevOpsStatus": schema_pkg_apis_edp_v1alpha1_PerfDataSourceAzureDevOpsStatus(ref),
+		"./pkg/apis/edp/v1alpha1.PerfDataSourceTekton":            schema_pkg_apis_edp_v1alpha1_PerfDataSourceTekton(ref),
+		"./pkg/apis/edp/v1alpha1.PerfDataSourceTektonSpec":        schema_pkg_apis_edp_v1alpha1_PerfDataSourceTektonSpec(ref),
+		"./pkg/apis/edp/v1alpha1.PerfDataSourceTektonStatus":      schema_pkg_apis_edp_v1alpha1_PerfDataSourceTektonStatus(ref),
+		"./pkg/apis/edp/v1alpha1.PerfDoraMetrics":                 schema_pkg_apis_edp_v1alpha1_PerfDoraMetrics(ref),
+		"./pkg/apis/edp/v1alpha1.PerfDoraMetricsSpec":             schema_pkg_apis_edp_v1alpha1_PerfDoraMetricsSpec(ref),
+		"./pkg/apis/edp/v1alpha1.PerfDoraMetricsStatus":           schema_pkg_apis_edp_v1alpha1_PerfDoraMetricsStatus(ref),
+		"./pkg/apis/edp/v1alpha1.PerfReport":                      schema_pkg_apis_edp_v1alpha1_PerfReport(ref),
+		"./pkg/apis/edp/v1alpha1.PerfReportSpec":                  schema_pkg_apis_edp_v1alpha1_PerfReportSpec(ref),
+		"./pkg/apis/edp/v1alpha1.PerfReportStatus":                schema_pkg_apis_edp_v1alpha1_PerfReportStatus(ref),
 	}
 }
 
@@ -98,14 +116,62 @@ func schema_pkg_apis_edp_v1alpha1_PerfServerSpec(ref common.ReferenceCallback) c
 					},
 					"projectName": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "ProjectName is the name of the PERF project node, it has to be unique in the whole node tree.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"projectPath": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ProjectPath is the slash-separated path of the project node from the root one, e.g. EPAM/Delivery/Backend. It's used instead of ProjectName if set.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"projectId": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ProjectId pins the project node by its PERF id, so it's never re-resolved by name or path.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"exporterNodes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ExporterNodes lists child nodes of the project whose KPIs are exported to Prometheus along with the project ones.",
+							Type:        []string{"array"},
+							Format:      "",
+						},
+					},
+					"auth": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Auth selects how the operator authenticates in PERF. Luminate is used if omitted.",
+							Ref:         ref("./pkg/apis/edp/v1alpha1.PerfServerAuth"),
+						},
+					},
+					"luminate": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Luminate defines the Luminate tunnel of the server. The namespace-wide luminatesec-conf ConfigMap is used if omitted.",
+							Ref:         ref("./pkg/apis/edp/v1alpha1.PerfServerLuminate"),
+						},
+					},
+					"transport": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Transport configures TLS and proxy of both PERF and Luminate connections.",
+							Ref:         ref("./pkg/apis/edp/v1alpha1.PerfServerTransport"),
+						},
+					},
+					"edpComponent": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EdpComponent configures the EDPComponent that represents the server in the admin console.",
+							Ref:         ref("./pkg/apis/edp/v1alpha1.PerfServerEdpComponent"),
 						},
 					},
 				},
-				Required: []string{"apiUrl", "rootUrl", "credentialName", "projectName"},
+				Required: []string{"apiUrl", "rootUrl", "credentialName"},
 			},
 		},
+		Dependencies: []string{
+			"./pkg/apis/edp/v1alpha1.PerfServerAuth", "./pkg/apis/edp/v1alpha1.PerfServerEdpComponent", "./pkg/apis/edp/v1alpha1.PerfServerLuminate", "./pkg/apis/edp/v1alpha1.PerfServerTransport"},
 	}
 }
 
@@ -120,6 +186,143 @@ func schema_pkg_apis_edp_v1alpha1_PerfStatus(ref common.ReferenceCallback) commo
 	}
 }
 
+func schema_pkg_apis_edp_v1alpha1_ClusterPerfServer(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ClusterPerfServer is the Schema for the clusterperfservers API",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("./pkg/apis/edp/v1alpha1.ClusterPerfServerSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("./pkg/apis/edp/v1alpha1.PerfServerStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"./pkg/apis/edp/v1alpha1.ClusterPerfServerSpec", "./pkg/apis/edp/v1alpha1.PerfServerStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_edp_v1alpha1_ClusterPerfServerSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ClusterPerfServerSpec defines the desired state of ClusterPerfServer",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"apiUrl": {
+						SchemaProps: spec.SchemaProps{
+							Description: "INSERT ADDITIONAL SPEC FIELDS - desired state of cluster Important: Run \"operator-sdk generate k8s\" to regenerate code after modifying this file Add custom validation using kubebuilder tags: https://book.kubebuilder.io/beyond_basics/generating_crd.html",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"rootUrl": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"credentialName": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"projectName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ProjectName is the name of the PERF project node, it has to be unique in the whole node tree.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"projectPath": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ProjectPath is the slash-separated path of the project node from the root one, e.g. EPAM/Delivery/Backend. It's used instead of ProjectName if set.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"projectId": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ProjectId pins the project node by its PERF id, so it's never re-resolved by name or path.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"exporterNodes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ExporterNodes lists child nodes of the project whose KPIs are exported to Prometheus along with the project ones.",
+							Type:        []string{"array"},
+							Format:      "",
+						},
+					},
+					"auth": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Auth selects how the operator authenticates in PERF. Luminate is used if omitted.",
+							Ref:         ref("./pkg/apis/edp/v1alpha1.PerfServerAuth"),
+						},
+					},
+					"luminate": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Luminate defines the Luminate tunnel of the server. The namespace-wide luminatesec-conf ConfigMap is used if omitted.",
+							Ref:         ref("./pkg/apis/edp/v1alpha1.PerfServerLuminate"),
+						},
+					},
+					"transport": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Transport configures TLS and proxy of both PERF and Luminate connections.",
+							Ref:         ref("./pkg/apis/edp/v1alpha1.PerfServerTransport"),
+						},
+					},
+					"edpComponent": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EdpComponent configures the EDPComponent that represents the server in the admin console.",
+							Ref:         ref("./pkg/apis/edp/v1alpha1.PerfServerEdpComponent"),
+						},
+					},
+					"allowedNamespaces": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AllowedNamespaces selects the namespaces by labels whose CRs can refer to the server. An empty selector allows all namespaces.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+				},
+				Required: []string{"apiUrl", "rootUrl", "credentialName", "allowedNamespaces"},
+			},
+		},
+		Dependencies: []string{
+			"./pkg/apis/edp/v1alpha1.PerfServerAuth", "./pkg/apis/edp/v1alpha1.PerfServerEdpComponent", "./pkg/apis/edp/v1alpha1.PerfServerLuminate", "./pkg/apis/edp/v1alpha1.PerfServerTransport", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+	}
+}
+
 func schema_pkg_apis_edp_v1alpha1_PerfDataSourceJenkins(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -178,6 +381,13 @@ func schema_pkg_apis_edp_v1alpha1_PerfDataSourceJenkinsSpec(ref common.Reference
 							Format:      "",
 						},
 					},
+					"perfServerKind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PerfServerKind is the kind of the PERF server, PerfServer or ClusterPerfServer. PerfServer is used if empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"type": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"string"},
@@ -196,6 +406,48 @@ func schema_pkg_apis_edp_v1alpha1_PerfDataSourceJenkinsSpec(ref common.Reference
 							Format: "",
 						},
 					},
+					"perfNode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PerfNode routes the data source to a child node of the PerfServer project, given by its name or slash-separated path relative to the project node. The project node itself is used if empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"createPerfNode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CreatePerfNode allows creating the missing nodes of PerfNode.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"validation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Validation enables checking JobNames against Jenkins before they're sent to PERF. It's one of skip, block or warn. The job names aren't checked if empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"discovery": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Discovery resolves more job names from Jenkins periodically. They're sent to PERF along with JobNames.",
+							Type:        []string{"object"},
+							Format:      "",
+						},
+					},
+					"edpComponent": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EdpComponent names the EDPComponent whose url is used if Config.Url is empty. The credentials are taken from its Secret, if it exists, when Config.CredentialName is empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"jenkinsName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "JenkinsName names the Jenkins CR of the EDP Jenkins operator whose url and credentials are used if they aren't set in Config. It takes precedence over EdpComponent.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"perfServerName", "type", "name", "config"},
 			},
@@ -272,6 +524,13 @@ func schema_pkg_apis_edp_v1alpha1_PerfDataSourceSonarSpec(ref common.ReferenceCa
 							Format:      "",
 						},
 					},
+					"perfServerKind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PerfServerKind is the kind of the PERF server, PerfServer or ClusterPerfServer. PerfServer is used if empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"type": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"string"},
@@ -290,6 +549,48 @@ func schema_pkg_apis_edp_v1alpha1_PerfDataSourceSonarSpec(ref common.ReferenceCa
 							Format: "",
 						},
 					},
+					"perfNode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PerfNode routes the data source to a child node of the PerfServer project, given by its name or slash-separated path relative to the project node. The project node itself is used if empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"createPerfNode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CreatePerfNode allows creating the missing nodes of PerfNode.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"validation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Validation enables checking the project keys against SonarQube before they're sent to PERF. It's one of skip, block or warn. The entries aren't checked if empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"discovery": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Discovery resolves more project keys from SonarQube periodically. They're sent to PERF along with ProjectKeys.",
+							Type:        []string{"object"},
+							Format:      "",
+						},
+					},
+					"edpComponent": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EdpComponent names the EDPComponent whose url is used if Config.Url is empty. The credentials are taken from its Secret, if it exists, when Config.CredentialName is empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"sonarName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SonarName names the Sonar CR of the EDP Sonar operator whose url and credentials are used if they aren't set in Config. It takes precedence over EdpComponent.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"perfServerName", "type", "name", "config"},
 			},
@@ -366,6 +667,13 @@ func schema_pkg_apis_edp_v1alpha1_PerfDataSourceGitLabSpec(ref common.ReferenceC
 							Format:      "",
 						},
 					},
+					"perfServerKind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PerfServerKind is the kind of the PERF server, PerfServer or ClusterPerfServer. PerfServer is used if empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"type": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"string"},
@@ -384,6 +692,41 @@ func schema_pkg_apis_edp_v1alpha1_PerfDataSourceGitLabSpec(ref common.ReferenceC
 							Format: "",
 						},
 					},
+					"perfNode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PerfNode routes the data source to a child node of the PerfServer project, given by its name or slash-separated path relative to the project node. The project node itself is used if empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"createPerfNode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CreatePerfNode allows creating the missing nodes of PerfNode.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"validation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Validation enables checking the repositories and branches against GitLab before they're sent to PERF. It's one of skip, block or warn. The entries aren't checked if empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"discovery": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Discovery resolves more repositories from GitLab periodically. They're sent to PERF along with Repositories.",
+							Type:        []string{"object"},
+							Format:      "",
+						},
+					},
+					"edpComponent": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EdpComponent names the EDPComponent whose url is used if Config.Url is empty. The credentials are taken from its Secret, if it exists, when Config.CredentialName is empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"perfServerName", "type", "name", "config"},
 			},
@@ -401,3 +744,562 @@ func schema_pkg_apis_edp_v1alpha1_PerfDataSourceGitLabStatus(ref common.Referenc
 		},
 	}
 }
+
+func schema_pkg_apis_edp_v1alpha1_PerfDataSourceBitbucket(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PerfDataSourceBitbucket is the Schema for the perfdatasourcebitbuckets API",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("./pkg/apis/edp/v1alpha1.PerfDataSourceBitbucketSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("./pkg/apis/edp/v1alpha1.PerfDataSourceBitbucketStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"./pkg/apis/edp/v1alpha1.PerfDataSourceBitbucketSpec", "./pkg/apis/edp/v1alpha1.PerfDataSourceBitbucketStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_edp_v1alpha1_PerfDataSourceBitbucketSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PerfDataSourceBitbucketSpec defines the desired state of PerfDataSourceBitbucket",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"perfServerName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "INSERT ADDITIONAL SPEC FIELDS - desired state of cluster Important: Run \"operator-sdk generate k8s\" to regenerate code after modifying this file Add custom validation using kubebuilder tags: https://book.kubebuilder.io/beyond_basics/generating_crd.html",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"perfServerKind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PerfServerKind is the kind of the PERF server, PerfServer or ClusterPerfServer. PerfServer is used if empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"config": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"object"},
+							Format: "",
+						},
+					},
+					"perfNode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PerfNode routes the data source to a child node of the PerfServer project, given by its name or slash-separated path relative to the project node. The project node itself is used if empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"createPerfNode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CreatePerfNode allows creating the missing nodes of PerfNode.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"perfServerName", "type", "name", "config"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_edp_v1alpha1_PerfDataSourceBitbucketStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PerfDataSourceBitbucketStatus defines the observed state of PerfDataSourceBitbucketStream",
+				Type:        []string{"object"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_edp_v1alpha1_PerfDataSourceAzureDevOps(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PerfDataSourceAzureDevOps is the Schema for the perfdatasourceazuredevopses API",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("./pkg/apis/edp/v1alpha1.PerfDataSourceAzureDevOpsSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("./pkg/apis/edp/v1alpha1.PerfDataSourceAzureDevOpsStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"./pkg/apis/edp/v1alpha1.PerfDataSourceAzureDevOpsSpec", "./pkg/apis/edp/v1alpha1.PerfDataSourceAzureDevOpsStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_edp_v1alpha1_PerfDataSourceAzureDevOpsSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PerfDataSourceAzureDevOpsSpec defines the desired state of PerfDataSourceAzureDevOps",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"perfServerName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "INSERT ADDITIONAL SPEC FIELDS - desired state of cluster Important: Run \"operator-sdk generate k8s\" to regenerate code after modifying this file Add custom validation using kubebuilder tags: https://book.kubebuilder.io/beyond_basics/generating_crd.html",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"perfServerKind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PerfServerKind is the kind of the PERF server, PerfServer or ClusterPerfServer. PerfServer is used if empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"config": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"object"},
+							Format: "",
+						},
+					},
+					"perfNode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PerfNode routes the data source to a child node of the PerfServer project, given by its name or slash-separated path relative to the project node. The project node itself is used if empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"createPerfNode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CreatePerfNode allows creating the missing nodes of PerfNode.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"perfServerName", "type", "name", "config"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_edp_v1alpha1_PerfDataSourceAzureDevOpsStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PerfDataSourceAzureDevOpsStatus defines the observed state of PerfDataSourceAzureDevOpsStream",
+				Type:        []string{"object"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_edp_v1alpha1_PerfDataSourceTekton(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PerfDataSourceTekton is the Schema for the perfdatasourcetektons API",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("./pkg/apis/edp/v1alpha1.PerfDataSourceTektonSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("./pkg/apis/edp/v1alpha1.PerfDataSourceTektonStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"./pkg/apis/edp/v1alpha1.PerfDataSourceTektonSpec", "./pkg/apis/edp/v1alpha1.PerfDataSourceTektonStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_edp_v1alpha1_PerfDataSourceTektonSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PerfDataSourceTektonSpec defines the desired state of PerfDataSourceTekton",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"perfServerName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "INSERT ADDITIONAL SPEC FIELDS - desired state of cluster Important: Run \"operator-sdk generate k8s\" to regenerate code after modifying this file Add custom validation using kubebuilder tags: https://book.kubebuilder.io/beyond_basics/generating_crd.html",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"perfServerKind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PerfServerKind is the kind of the PERF server, PerfServer or ClusterPerfServer. PerfServer is used if empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"config": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"object"},
+							Format: "",
+						},
+					},
+					"perfNode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PerfNode routes the data source to a child node of the PerfServer project, given by its name or slash-separated path relative to the project node. The project node itself is used if empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"createPerfNode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CreatePerfNode allows creating the missing nodes of PerfNode.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"perfServerName", "type", "name", "config"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_edp_v1alpha1_PerfDataSourceTektonStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PerfDataSourceTektonStatus defines the observed state of PerfDataSourceTektonStream",
+				Type:        []string{"object"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_edp_v1alpha1_PerfDoraMetrics(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PerfDoraMetrics is the Schema for the perfdorametrics API",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("./pkg/apis/edp/v1alpha1.PerfDoraMetricsSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("./pkg/apis/edp/v1alpha1.PerfDoraMetricsStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"./pkg/apis/edp/v1alpha1.PerfDoraMetricsSpec", "./pkg/apis/edp/v1alpha1.PerfDoraMetricsStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_edp_v1alpha1_PerfDoraMetricsSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PerfDoraMetricsSpec defines the desired state of PerfDoraMetrics",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"perfServerName": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"perfServerKind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PerfServerKind is the kind of the PERF server, PerfServer or ClusterPerfServer. PerfServer is used if empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"stages": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"array"},
+							Format: "",
+						},
+					},
+					"perfNode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PerfNode routes the data source to a child node of the PerfServer project, given by its name or slash-separated path relative to the project node. The project node itself is used if empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"createPerfNode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CreatePerfNode allows creating the missing nodes of PerfNode.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"perfServerName", "type", "name", "stages"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_edp_v1alpha1_PerfDoraMetricsStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PerfDoraMetricsStatus defines the observed state of PerfDoraMetricsStream",
+				Type:        []string{"object"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_edp_v1alpha1_PerfReport(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PerfReport is the Schema for the perfreports API",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("./pkg/apis/edp/v1alpha1.PerfReportSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("./pkg/apis/edp/v1alpha1.PerfReportStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"./pkg/apis/edp/v1alpha1.PerfReportSpec", "./pkg/apis/edp/v1alpha1.PerfReportStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_edp_v1alpha1_PerfReportSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PerfReportSpec defines the desired state of PerfReport",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"perfServerName": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"perfServerKind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PerfServerKind is the kind of the PERF server, PerfServer or ClusterPerfServer. PerfServer is used if empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"nodeName": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"metrics": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"array"},
+							Format: "",
+						},
+					},
+					"interval": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+				},
+				Required: []string{"perfServerName"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_edp_v1alpha1_PerfReportStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PerfReportStatus defines the observed state of PerfReportStream",
+				Type:        []string{"object"},
+			},
+		},
+	}
+}
@@ -0,0 +1,93 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"gopkg.in/resty.v1"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type GitLabClient interface {
+	ProjectExists(path string) (bool, error)
+	BranchExists(path, branch string) (bool, error)
+	GetGroupProjects(group string) ([]string, error)
+}
+
+type GitLabClientAdapter struct {
+	client resty.Client
+}
+
+// the max page size of GitLab API
+const maxPerPage = 100
+
+func NewGitLabRestClient(apiUrl, token string) GitLabClientAdapter {
+	cl := resty.New().
+		SetHostURL(strings.TrimRight(apiUrl, "/")+"/api/v4").
+		SetHeader("PRIVATE-TOKEN", token)
+	return GitLabClientAdapter{client: *cl}
+}
+
+// ProjectExists checks the project by its path with namespace, e.g. group/project.
+func (c GitLabClientAdapter) ProjectExists(path string) (bool, error) {
+	return c.exists("/projects/"+escapePath(path), path)
+}
+
+func (c GitLabClientAdapter) BranchExists(path, branch string) (bool, error) {
+	return c.exists("/projects/"+escapePath(path)+"/repository/branches/"+url.PathEscape(branch), path+"@"+branch)
+}
+
+// GetGroupProjects returns the paths with namespace of the group projects, including the ones of its subgroups.
+// The archived projects are left out.
+func (c GitLabClientAdapter) GetGroupProjects(group string) ([]string, error) {
+	var res []string
+	for page := "1"; page != ""; {
+		resp, err := c.client.R().
+			SetQueryParams(map[string]string{
+				"include_subgroups": "true",
+				"archived":          "false",
+				"simple":            "true",
+				"per_page":          strconv.Itoa(maxPerPage),
+				"page":              page,
+			}).
+			Get("/groups/" + escapePath(group) + "/projects")
+		if err != nil {
+			return nil, errors.Wrapf(err, "couldn't get projects of %v group from GitLab", group)
+		}
+		if resp.IsError() {
+			return nil, errors.Errorf("couldn't get projects of %v group from GitLab. Status - %v", group, resp.StatusCode())
+		}
+
+		var projects []struct {
+			PathWithNamespace string `json:"path_with_namespace"`
+		}
+		if err := json.Unmarshal(resp.Body(), &projects); err != nil {
+			return nil, errors.Wrapf(err, "couldn't parse projects of %v group", group)
+		}
+		for _, p := range projects {
+			res = append(res, p.PathWithNamespace)
+		}
+		page = resp.Header().Get("X-Next-Page")
+	}
+	return res, nil
+}
+
+func (c GitLabClientAdapter) exists(resource, name string) (bool, error) {
+	resp, err := c.client.R().Get(resource)
+	if err != nil {
+		return false, errors.Wrapf(err, "couldn't get %v from GitLab", name)
+	}
+	if resp.StatusCode() == http.StatusNotFound {
+		return false, nil
+	}
+	if resp.IsError() {
+		return false, errors.Errorf("couldn't get %v from GitLab. Status - %v", name, resp.StatusCode())
+	}
+	return true, nil
+}
+
+func escapePath(path string) string {
+	return url.PathEscape(strings.Trim(path, "/"))
+}
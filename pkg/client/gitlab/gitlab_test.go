@@ -0,0 +1,74 @@
+package gitlab
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGitLabClientAdapter(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token", r.Header.Get("PRIVATE-TOKEN"))
+		switch r.URL.EscapedPath() {
+		case "/api/v4/projects/group%2Fapp", "/api/v4/projects/group%2Fapp/repository/branches/feature%2Fx":
+			_, _ = w.Write([]byte(`{}`))
+		case "/api/v4/projects/group%2Fbroken":
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewGitLabRestClient(srv.URL+"/", "token")
+
+	exists, err := c.ProjectExists("/group/app")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = c.ProjectExists("group/missing")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	exists, err = c.BranchExists("group/app", "feature/x")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = c.BranchExists("group/app", "master")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	_, err = c.ProjectExists("group/broken")
+	assert.Error(t, err)
+}
+
+func TestGitLabClientAdapter_GetGroupProjects(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/api/v4/groups/edp%2Fbackend/projects" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, "true", r.URL.Query().Get("include_subgroups"))
+		assert.Equal(t, "false", r.URL.Query().Get("archived"))
+		switch r.URL.Query().Get("page") {
+		case "1":
+			w.Header().Set("X-Next-Page", "2")
+			_, _ = w.Write([]byte(`[{"path_with_namespace":"edp/backend/app"}]`))
+		case "2":
+			_, _ = w.Write([]byte(`[{"path_with_namespace":"edp/backend/libs/api"}]`))
+		default:
+			t.Errorf("unexpected page %v", r.URL.Query().Get("page"))
+		}
+	}))
+	defer srv.Close()
+
+	c := NewGitLabRestClient(srv.URL, "token")
+
+	projects, err := c.GetGroupProjects("/edp/backend/")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"edp/backend/app", "edp/backend/libs/api"}, projects)
+
+	_, err = c.GetGroupProjects("missing")
+	assert.Error(t, err)
+}
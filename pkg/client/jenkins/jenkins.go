@@ -0,0 +1,114 @@
+package jenkins
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"gopkg.in/resty.v1"
+	"net/http"
+	"net/url"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"strings"
+)
+
+type JenkinsClient interface {
+	JobExists(name string) (bool, error)
+	GetJobs(folder string) ([]string, error)
+}
+
+type JenkinsClientAdapter struct {
+	client resty.Client
+}
+
+var log = logf.Log.WithName("jenkins_client")
+
+// the max depth of the nested folders to be searched for jobs
+const maxFolderDepth = 10
+
+func NewJenkinsRestClient(apiUrl, username, password string) JenkinsClientAdapter {
+	cl := resty.New().
+		SetHostURL(apiUrl).
+		SetBasicAuth(username, password)
+	return JenkinsClientAdapter{client: *cl}
+}
+
+// JobExists checks the job by its full name, the folders are separated by slashes, e.g. /folder/job.
+func (c JenkinsClientAdapter) JobExists(name string) (bool, error) {
+	path := GetJobPath(name)
+	if path == "" {
+		return false, nil
+	}
+
+	resp, err := c.client.R().
+		SetQueryParam("tree", "name").
+		Get(path + "/api/json")
+	if err != nil {
+		return false, errors.Wrapf(err, "couldn't get %v Jenkins job", name)
+	}
+	if resp.StatusCode() == http.StatusNotFound {
+		log.Info("Jenkins job hasn't been found", "name", name)
+		return false, nil
+	}
+	if resp.IsError() {
+		return false, errors.Errorf("couldn't get %v Jenkins job. Status - %v", name, resp.StatusCode())
+	}
+	return true, nil
+}
+
+// GetJobs returns the full names of the jobs in the folder and its subfolders, e.g. /folder/job.
+// The jobs of the whole Jenkins are returned if the folder is empty.
+func (c JenkinsClientAdapter) GetJobs(folder string) ([]string, error) {
+	return c.getJobs(strings.Trim(folder, "/"), 0)
+}
+
+func (c JenkinsClientAdapter) getJobs(folder string, depth int) ([]string, error) {
+	resp, err := c.client.R().
+		SetQueryParam("tree", "jobs[name,jobs[name]]").
+		Get(GetJobPath(folder) + "/api/json")
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't get jobs of %v Jenkins folder", folder)
+	}
+	if resp.IsError() {
+		return nil, errors.Errorf("couldn't get jobs of %v Jenkins folder. Status - %v", folder, resp.StatusCode())
+	}
+
+	jr := struct {
+		Jobs []struct {
+			Name string `json:"name"`
+			// Jobs is set for the folders only
+			Jobs *[]interface{} `json:"jobs"`
+		} `json:"jobs"`
+	}{}
+	if err := json.Unmarshal(resp.Body(), &jr); err != nil {
+		return nil, errors.Wrapf(err, "couldn't parse jobs of %v Jenkins folder", folder)
+	}
+
+	var res []string
+	for _, j := range jr.Jobs {
+		name := strings.TrimLeft(folder+"/"+j.Name, "/")
+		if j.Jobs == nil {
+			res = append(res, "/"+name)
+			continue
+		}
+		if depth >= maxFolderDepth {
+			log.Info("Jenkins folder is too deep to be searched for jobs", "name", name)
+			continue
+		}
+		nested, err := c.getJobs(name, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, nested...)
+	}
+	return res, nil
+}
+
+// GetJobPath converts the full job name to its URL path, e.g. /folder/job to /job/folder/job/job.
+func GetJobPath(name string) string {
+	var path string
+	for _, n := range strings.Split(name, "/") {
+		if n = strings.TrimSpace(n); n != "" {
+			path += "/job/" + url.PathEscape(n)
+		}
+	}
+	return path
+}
@@ -0,0 +1,79 @@
+package jenkins
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestJenkinsClientAdapter_JobExists(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u, p, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "user", u)
+		assert.Equal(t, "token", p)
+
+		switch r.URL.Path {
+		case "/job/folder/job/build/api/json":
+			_, _ = w.Write([]byte(`{"name":"build"}`))
+		case "/job/forbidden/api/json":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewJenkinsRestClient(srv.URL, "user", "token")
+
+	exists, err := c.JobExists("/folder/build")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = c.JobExists("/folder/missing")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	_, err = c.JobExists("forbidden")
+	assert.Error(t, err)
+}
+
+func TestGetJobPath(t *testing.T) {
+	assert.Equal(t, "/job/folder/job/build", GetJobPath("/folder/build"))
+	assert.Equal(t, "/job/build", GetJobPath("build/"))
+	assert.Equal(t, "/job/my%20folder/job/build", GetJobPath("my folder/build"))
+	assert.Equal(t, "", GetJobPath("/"))
+}
+
+func TestJenkinsClientAdapter_GetJobs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "jobs[name,jobs[name]]", r.URL.Query().Get("tree"))
+		switch r.URL.Path {
+		case "/api/json":
+			_, _ = w.Write([]byte(`{"jobs":[{"name":"seed"},{"name":"folder","jobs":[{"name":"build"}]},{"name":"empty","jobs":[]}]}`))
+		case "/job/folder/api/json":
+			_, _ = w.Write([]byte(`{"jobs":[{"name":"build"},{"name":"nested","jobs":[{"name":"deploy"}]}]}`))
+		case "/job/folder/job/nested/api/json":
+			_, _ = w.Write([]byte(`{"jobs":[{"name":"deploy"}]}`))
+		case "/job/empty/api/json":
+			_, _ = w.Write([]byte(`{"jobs":[]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewJenkinsRestClient(srv.URL, "user", "token")
+
+	jobs, err := c.GetJobs("")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/seed", "/folder/build", "/folder/nested/deploy"}, jobs)
+
+	jobs, err = c.GetJobs("/folder/nested/")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/folder/nested/deploy"}, jobs)
+
+	_, err = c.GetJobs("missing")
+	assert.Error(t, err)
+}
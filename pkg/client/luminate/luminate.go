@@ -2,46 +2,76 @@ package luminate
 
 import (
 	"encoding/json"
-	"github.com/epmd-edp/perf-operator/v2/pkg/util/common"
 	"github.com/pkg/errors"
 	"gopkg.in/resty.v1"
+	"net/http"
 	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"time"
 )
 
 type LuminateClient interface {
-	GetApiToken(clientId, secret string) (*string, error)
+	GetApiToken(clientId, secret string) (*Token, error)
 }
 
 type LuminateClientAdapter struct {
 	client resty.Client
+	now    func() time.Time
+}
+
+// Token is a Luminate API token. Expiry is zero if Luminate didn't report expires_in.
+type Token struct {
+	AccessToken string
+	TokenType   string
+	ExpiresIn   int64
+	Expiry      time.Time
 }
 
 var log = logf.Log.WithName("luminate_client")
 
-func NewLuminateRestClient(url string) LuminateClientAdapter {
+func NewLuminateRestClient(url string, transport http.RoundTripper) LuminateClientAdapter {
 	cl := resty.New().
 		SetHostURL(url)
-	return LuminateClientAdapter{client: *cl}
+	if transport != nil {
+		cl.SetTransport(transport)
+	}
+	return LuminateClientAdapter{client: *cl, now: time.Now}
 }
 
-func (c LuminateClientAdapter) GetApiToken(clientId, secret string) (*string, error) {
+func (c LuminateClientAdapter) GetApiToken(clientId, secret string) (*Token, error) {
 	rl := log.WithValues("clientId", clientId)
 	rl.Info("getting Luminate API token")
 
 	resp, err := c.client.R().
 		SetBasicAuth(clientId, secret).
 		Post("/v1/oauth/token")
-	if err != nil || resp.IsError() {
-		return nil, errors.Wrapf(err, "Couldn't get Luminate API token for %v client.", clientId)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't get Luminate API token for %v client", clientId)
+	}
+	if resp.IsError() {
+		return nil, errors.Errorf("couldn't get Luminate API token for %v client. Status - %v, body - %v",
+			clientId, resp.StatusCode(), resp.String())
 	}
 
 	at := &struct {
 		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresIn   int64  `json:"expires_in"`
 	}{}
+	if err = json.Unmarshal(resp.Body(), at); err != nil {
+		return nil, errors.Wrapf(err, "couldn't parse Luminate API token for %v client", clientId)
+	}
+	if at.AccessToken == "" {
+		return nil, errors.Errorf("Luminate returned no API token for %v client. Body - %v", clientId, resp.String())
+	}
 
-	if err = json.Unmarshal([]byte(resp.String()), at); err != nil {
-		return nil, errors.Wrapf(err, "Couldn't parse Luminate API token for %v client.", clientId)
+	t := &Token{
+		AccessToken: at.AccessToken,
+		TokenType:   at.TokenType,
+		ExpiresIn:   at.ExpiresIn,
+	}
+	if at.ExpiresIn > 0 {
+		t.Expiry = c.now().Add(time.Duration(at.ExpiresIn) * time.Second)
 	}
-	rl.Info("Luminate API token has been received.")
-	return common.GetStringP(at.AccessToken), nil
+	rl.Info("Luminate API token has been received.", "expiresIn", at.ExpiresIn)
+	return t, nil
 }
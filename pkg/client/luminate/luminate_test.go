@@ -0,0 +1,68 @@
+package luminate
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestLuminateClientAdapter_GetApiToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/oauth/token", r.URL.Path)
+		u, p, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "client", u)
+		assert.Equal(t, "secret", p)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"lum-token","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer srv.Close()
+
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewLuminateRestClient(srv.URL, nil)
+	c.now = func() time.Time { return now }
+
+	token, err := c.GetApiToken("client", "secret")
+	assert.NoError(t, err)
+	assert.Equal(t, "lum-token", token.AccessToken)
+	assert.Equal(t, "Bearer", token.TokenType)
+	assert.Equal(t, int64(3600), token.ExpiresIn)
+	assert.Equal(t, now.Add(time.Hour), token.Expiry)
+}
+
+func TestLuminateClientAdapter_GetApiTokenWithoutExpiry(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"access_token":"lum-token"}`))
+	}))
+	defer srv.Close()
+
+	token, err := NewLuminateRestClient(srv.URL, nil).GetApiToken("client", "secret")
+	assert.NoError(t, err)
+	assert.Equal(t, "lum-token", token.AccessToken)
+	assert.True(t, token.Expiry.IsZero())
+}
+
+func TestLuminateClientAdapter_GetApiTokenErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":"invalid_client"}`))
+	}))
+	defer srv.Close()
+
+	_, err := NewLuminateRestClient(srv.URL, nil).GetApiToken("client", "wrong")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "401")
+	assert.Contains(t, err.Error(), "invalid_client")
+}
+
+func TestLuminateClientAdapter_GetApiTokenInvalidBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<html>maintenance</html>`))
+	}))
+	defer srv.Close()
+
+	_, err := NewLuminateRestClient(srv.URL, nil).GetApiToken("client", "secret")
+	assert.Error(t, err)
+}
@@ -0,0 +1,122 @@
+package luminate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// maxRefreshMargin is how long before the expiry a token is refreshed. Short-lived tokens
+// are refreshed when half of their lifetime is gone.
+const maxRefreshMargin = time.Minute
+
+// TokenSource caches the Luminate API token of a client and gets a new one before the cached one expires.
+type TokenSource struct {
+	mu       sync.Mutex
+	client   LuminateClient
+	clientId string
+	secret   string
+	token    *Token
+	now      func() time.Time
+}
+
+// cachedTokenSource is the token source of a PerfServer along with the fingerprint of the Luminate url
+// and credentials it was created for.
+type cachedTokenSource struct {
+	fingerprint string
+	source      *TokenSource
+}
+
+var (
+	sourcesMu sync.Mutex
+	sources   = make(map[string]cachedTokenSource)
+)
+
+func NewTokenSource(client LuminateClient, clientId, secret string) *TokenSource {
+	return &TokenSource{
+		client:   client,
+		clientId: clientId,
+		secret:   secret,
+		now:      time.Now,
+	}
+}
+
+// GetTokenSource returns the token source shared by all PERF clients of the PerfServer, so every reconciliation
+// doesn't request a new token. The source is replaced once the Luminate url or credentials change, and it switches
+// to the given transport for the next token requests, as it may be rebuilt with another CA bundle or proxy.
+func GetTokenSource(namespace, name, url, clientId, secret string, transport http.RoundTripper) *TokenSource {
+	sourcesMu.Lock()
+	defer sourcesMu.Unlock()
+
+	key := getSourceKey(namespace, name)
+	fingerprint := getFingerprint(url, clientId, secret)
+	cached, ok := sources[key]
+	if !ok || cached.fingerprint != fingerprint {
+		cached = cachedTokenSource{
+			fingerprint: fingerprint,
+			source:      NewTokenSource(nil, clientId, secret),
+		}
+		sources[key] = cached
+	}
+	cached.source.setClient(NewLuminateRestClient(url, transport))
+	return cached.source
+}
+
+// EvictTokenSource removes the token source of the PerfServer from the cache.
+func EvictTokenSource(namespace, name string) {
+	sourcesMu.Lock()
+	defer sourcesMu.Unlock()
+
+	delete(sources, getSourceKey(namespace, name))
+}
+
+func getSourceKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+func getFingerprint(url, clientId, secret string) string {
+	h := sha256.New()
+	for _, v := range []string{url, clientId, secret} {
+		h.Write([]byte(v))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (ts *TokenSource) setClient(client LuminateClient) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.client = client
+}
+
+// Token returns the cached token while it's valid and requests a new one otherwise.
+// Tokens without expiry are never cached.
+func (ts *TokenSource) Token() (*Token, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.valid() {
+		return ts.token, nil
+	}
+
+	t, err := ts.client.GetApiToken(ts.clientId, ts.secret)
+	if err != nil {
+		return nil, err
+	}
+	ts.token = t
+	return t, nil
+}
+
+func (ts *TokenSource) valid() bool {
+	if ts.token == nil || ts.token.Expiry.IsZero() {
+		return false
+	}
+
+	margin := time.Duration(ts.token.ExpiresIn) * time.Second / 2
+	if margin > maxRefreshMargin {
+		margin = maxRefreshMargin
+	}
+	return ts.now().Before(ts.token.Expiry.Add(-margin))
+}
@@ -0,0 +1,136 @@
+package luminate
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTokenServer(expiresIn int, calls *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(calls, 1)
+		_, _ = fmt.Fprintf(w, `{"access_token":"token-%v","token_type":"Bearer","expires_in":%v}`, n, expiresIn)
+	}))
+}
+
+func TestTokenSource_Token(t *testing.T) {
+	var calls int32
+	srv := newTokenServer(3600, &calls)
+	defer srv.Close()
+
+	now := time.Now()
+	ts := NewTokenSource(NewLuminateRestClient(srv.URL, nil), "client", "secret")
+	ts.now = func() time.Time { return now }
+
+	token, err := ts.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, "token-1", token.AccessToken)
+
+	now = now.Add(30 * time.Minute)
+	token, err = ts.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, "token-1", token.AccessToken)
+
+	// refreshed a minute before the expiry
+	now = now.Add(29*time.Minute + 30*time.Second)
+	token, err = ts.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, "token-2", token.AccessToken)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestTokenSource_TokenShortLived(t *testing.T) {
+	var calls int32
+	srv := newTokenServer(60, &calls)
+	defer srv.Close()
+
+	now := time.Now()
+	ts := NewTokenSource(NewLuminateRestClient(srv.URL, nil), "client", "secret")
+	ts.now = func() time.Time { return now }
+
+	_, err := ts.Token()
+	assert.NoError(t, err)
+
+	now = now.Add(20 * time.Second)
+	token, err := ts.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, "token-1", token.AccessToken)
+
+	now = now.Add(15 * time.Second)
+	token, err = ts.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, "token-2", token.AccessToken)
+}
+
+func TestTokenSource_TokenWithoutExpiry(t *testing.T) {
+	var calls int32
+	srv := newTokenServer(0, &calls)
+	defer srv.Close()
+
+	ts := NewTokenSource(NewLuminateRestClient(srv.URL, nil), "client", "secret")
+	_, err := ts.Token()
+	assert.NoError(t, err)
+	_, err = ts.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestTokenSource_TokenError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	_, err := NewTokenSource(NewLuminateRestClient(srv.URL, nil), "client", "secret").Token()
+	assert.Error(t, err)
+}
+
+func TestGetTokenSource_Shared(t *testing.T) {
+	var calls int32
+	srv := newTokenServer(3600, &calls)
+	defer srv.Close()
+	defer EvictTokenSource("ns", "perf")
+
+	ts := GetTokenSource("ns", "perf", srv.URL, "shared", "secret", nil)
+	assert.Same(t, ts, GetTokenSource("ns", "perf", srv.URL, "shared", "secret", nil))
+	assert.True(t, ts != GetTokenSource("other-ns", "perf", srv.URL, "shared", "secret", nil))
+	EvictTokenSource("other-ns", "perf")
+
+	_, err := ts.Token()
+	assert.NoError(t, err)
+	_, err = GetTokenSource("ns", "perf", srv.URL, "shared", "secret", nil).Token()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestGetTokenSource_ShouldBeReplacedOnCredentialChange(t *testing.T) {
+	var calls int32
+	srv := newTokenServer(3600, &calls)
+	defer srv.Close()
+	defer EvictTokenSource("ns", "perf")
+
+	ts := GetTokenSource("ns", "perf", srv.URL, "shared", "secret", nil)
+	rotated := GetTokenSource("ns", "perf", srv.URL, "shared", "rotated", nil)
+	assert.True(t, ts != rotated)
+	assert.Same(t, rotated, GetTokenSource("ns", "perf", srv.URL, "shared", "rotated", nil))
+	assert.Len(t, sources, 1)
+}
+
+func TestEvictTokenSource(t *testing.T) {
+	var calls int32
+	srv := newTokenServer(3600, &calls)
+	defer srv.Close()
+
+	ts := GetTokenSource("ns", "perf", srv.URL, "shared", "secret", nil)
+	EvictTokenSource("ns", "perf")
+	assert.NotContains(t, sources, "ns/perf")
+	assert.True(t, ts != GetTokenSource("ns", "perf", srv.URL, "shared", "secret", nil))
+
+	EvictTokenSource("ns", "perf")
+	EvictTokenSource("ns", "perf")
+	assert.Empty(t, sources)
+}
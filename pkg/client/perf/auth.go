@@ -0,0 +1,267 @@
+package perf
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/luminate"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/cluster"
+	"github.com/pkg/errors"
+	"net/http"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"strings"
+)
+
+const (
+	SsoAuthType      = "sso"
+	LuminateAuthType = "luminate"
+	BearerAuthType   = "bearer"
+	OAuth2AuthType   = "oauth2"
+
+	LuminatesecConfigMapName = "luminatesec-conf"
+	lumApiTokenHeader        = "lum-api-token"
+)
+
+// AuthProvider supplies the headers PERF API requests are authenticated with.
+type AuthProvider interface {
+	GetHeaders() (map[string]string, error)
+}
+
+// SsoAuthProvider exchanges PERF user credentials for a token on the PERF SSO endpoint.
+type SsoAuthProvider struct {
+	transport   http.RoundTripper
+	url         string
+	username    string
+	password    string
+	lumApiToken string
+}
+
+// LuminateAuthProvider gets a Luminate API token first, as PERF behind Luminate
+// requires it on every request including the SSO one.
+type LuminateAuthProvider struct {
+	transport http.RoundTripper
+	tokens    *luminate.TokenSource
+	url       string
+	username  string
+	password  string
+}
+
+// BearerAuthProvider uses a static token.
+type BearerAuthProvider struct {
+	token string
+}
+
+// OAuth2AuthProvider gets a token with the client credentials grant.
+type OAuth2AuthProvider struct {
+	transport    http.RoundTripper
+	tokenUrl     string
+	clientId     string
+	clientSecret string
+	scopes       []string
+}
+
+func NewSsoAuthProvider(transport http.RoundTripper, url, username, password string) SsoAuthProvider {
+	return SsoAuthProvider{transport: transport, url: url, username: username, password: password}
+}
+
+func NewLuminateAuthProvider(transport http.RoundTripper, tokens *luminate.TokenSource, url, username,
+	password string) LuminateAuthProvider {
+	return LuminateAuthProvider{
+		transport: transport,
+		tokens:    tokens,
+		url:       url,
+		username:  username,
+		password:  password,
+	}
+}
+
+func NewBearerAuthProvider(token string) BearerAuthProvider {
+	return BearerAuthProvider{token: token}
+}
+
+func NewOAuth2AuthProvider(transport http.RoundTripper, tokenUrl, clientId, clientSecret string, scopes []string) OAuth2AuthProvider {
+	return OAuth2AuthProvider{
+		transport:    transport,
+		tokenUrl:     tokenUrl,
+		clientId:     clientId,
+		clientSecret: clientSecret,
+		scopes:       scopes,
+	}
+}
+
+// GetAuthProvider creates the provider selected in the PerfServer spec, reading the required Secrets.
+// Token requests are sent over the given transport, nil means Go's default one.
+func GetAuthProvider(client client.Client, ps *v1alpha1.PerfServer, transport http.RoundTripper) (AuthProvider, error) {
+	authType := LuminateAuthType
+	if ps.Spec.Auth != nil && ps.Spec.Auth.Type != "" {
+		authType = ps.Spec.Auth.Type
+	}
+
+	if authType != LuminateAuthType {
+		luminate.EvictTokenSource(ps.Namespace, ps.Name)
+	}
+
+	switch authType {
+	case SsoAuthType:
+		s, err := cluster.GetSecret(client, ps.Spec.CredentialName, ps.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		return NewSsoAuthProvider(transport, ps.Spec.ApiUrl, string(s.Data["username"]), string(s.Data["password"])), nil
+	case LuminateAuthType:
+		return getLuminateAuthProvider(client, ps, transport)
+	case BearerAuthType:
+		s, err := cluster.GetSecret(client, ps.Spec.Auth.SecretName, ps.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		return NewBearerAuthProvider(string(s.Data["token"])), nil
+	case OAuth2AuthType:
+		s, err := cluster.GetSecret(client, ps.Spec.Auth.SecretName, ps.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		return NewOAuth2AuthProvider(transport, ps.Spec.Auth.TokenUrl, string(s.Data["clientId"]), string(s.Data["clientSecret"]),
+			ps.Spec.Auth.Scopes), nil
+	}
+	return nil, errors.Errorf("unsupported %v PERF auth type", authType)
+}
+
+func getLuminateAuthProvider(client client.Client, ps *v1alpha1.PerfServer, transport http.RoundTripper) (AuthProvider, error) {
+	apiUrl, credentialName, err := GetLuminateConfig(client, ps)
+	if err != nil {
+		return nil, err
+	}
+
+	lumSecret, err := cluster.GetSecret(client, credentialName, ps.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := cluster.GetSecret(client, ps.Spec.CredentialName, ps.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := luminate.GetTokenSource(ps.Namespace, ps.Name, apiUrl, string(lumSecret.Data["username"]),
+		string(lumSecret.Data["password"]), transport)
+	return NewLuminateAuthProvider(transport, tokens, ps.Spec.ApiUrl, string(s.Data["username"]), string(s.Data["password"])), nil
+}
+
+// GetLuminateConfig returns the Luminate API URL and credentials Secret name of the PerfServer,
+// falling back to the luminatesec-conf ConfigMap if the spec doesn't define them.
+func GetLuminateConfig(client client.Client, ps *v1alpha1.PerfServer) (string, string, error) {
+	if ps.Spec.Luminate != nil {
+		return ps.Spec.Luminate.ApiUrl, ps.Spec.Luminate.CredentialName, nil
+	}
+
+	cm, err := cluster.GetConfigMap(client, LuminatesecConfigMapName, ps.Namespace)
+	if err != nil {
+		return "", "", err
+	}
+	return cm.Data["apiUrl"], cm.Data["credentialName"], nil
+}
+
+// AuthDependencies are the ConfigMaps and Secrets the PerfServer authentication and transport are built from.
+type AuthDependencies struct {
+	ConfigMaps []string
+	Secrets    []string
+}
+
+func GetAuthDependencies(client client.Client, ps *v1alpha1.PerfServer) AuthDependencies {
+	d := AuthDependencies{
+		Secrets: []string{ps.Spec.CredentialName},
+	}
+
+	authType := LuminateAuthType
+	if ps.Spec.Auth != nil && ps.Spec.Auth.Type != "" {
+		authType = ps.Spec.Auth.Type
+	}
+
+	if t := ps.Spec.Transport; t != nil {
+		if t.CaBundleName != "" {
+			d.ConfigMaps = append(d.ConfigMaps, t.CaBundleName)
+		}
+		if t.ClientCertSecretName != "" {
+			d.Secrets = append(d.Secrets, t.ClientCertSecretName)
+		}
+	}
+
+	switch authType {
+	case BearerAuthType, OAuth2AuthType:
+		d.Secrets = append(d.Secrets, ps.Spec.Auth.SecretName)
+	case LuminateAuthType:
+		if ps.Spec.Luminate == nil {
+			d.ConfigMaps = append(d.ConfigMaps, LuminatesecConfigMapName)
+		}
+		if _, credentialName, err := GetLuminateConfig(client, ps); err == nil {
+			d.Secrets = append(d.Secrets, credentialName)
+		}
+	}
+	return d
+}
+
+func (p SsoAuthProvider) GetHeaders() (map[string]string, error) {
+	token, err := getAuthorizationToken(p.transport, p.url, p.username, p.password, p.lumApiToken)
+	if err != nil {
+		return nil, err
+	}
+	h := map[string]string{
+		"Authorization": "Bearer " + token,
+	}
+	if p.lumApiToken != "" {
+		h[lumApiTokenHeader] = p.lumApiToken
+	}
+	return h, nil
+}
+
+func (p LuminateAuthProvider) GetHeaders() (map[string]string, error) {
+	lumToken, err := p.tokens.Token()
+	if err != nil {
+		return nil, err
+	}
+	return SsoAuthProvider{
+		transport:   p.transport,
+		url:         p.url,
+		username:    p.username,
+		password:    p.password,
+		lumApiToken: lumToken.AccessToken,
+	}.GetHeaders()
+}
+
+func (p BearerAuthProvider) GetHeaders() (map[string]string, error) {
+	if p.token == "" {
+		return nil, errors.New("PERF bearer token is empty")
+	}
+	return map[string]string{
+		"Authorization": "Bearer " + p.token,
+	}, nil
+}
+
+func (p OAuth2AuthProvider) GetHeaders() (map[string]string, error) {
+	form := map[string]string{
+		"grant_type": "client_credentials",
+	}
+	if len(p.scopes) > 0 {
+		form["scope"] = strings.Join(p.scopes, " ")
+	}
+
+	at := &struct {
+		AccessToken string `json:"access_token"`
+	}{}
+	resp, err := newRestyClient(p.transport).R().
+		SetBasicAuth(p.clientId, p.clientSecret).
+		SetFormData(form).
+		SetResult(at).
+		Post(p.tokenUrl)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't get OAuth2 token for %v client", p.clientId)
+	}
+	if resp.IsError() {
+		return nil, errors.Errorf("couldn't get OAuth2 token for %v client. Status - %v", p.clientId, resp.StatusCode())
+	}
+	if at.AccessToken == "" {
+		return nil, errors.Errorf("OAuth2 token endpoint returned no token for %v client", p.clientId)
+	}
+	return map[string]string{
+		"Authorization": "Bearer " + at.AccessToken,
+	}, nil
+}
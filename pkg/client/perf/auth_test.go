@@ -0,0 +1,142 @@
+package perf
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"net/http"
+	"net/http/httptest"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+const (
+	fakeName      = "fake-name"
+	fakeNamespace = "fake-namespace"
+)
+
+func createPerfServer(auth *v1alpha1.PerfServerAuth) *v1alpha1.PerfServer {
+	return &v1alpha1.PerfServer{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Spec: v1alpha1.PerfServerSpec{
+			CredentialName: fakeName,
+			Auth:           auth,
+		},
+	}
+}
+
+func createSecret(data map[string][]byte) *coreV1.Secret {
+	return &coreV1.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Data: data,
+	}
+}
+
+func TestGetAuthProvider_ShouldCreateBearerProvider(t *testing.T) {
+	ps := createPerfServer(&v1alpha1.PerfServerAuth{Type: BearerAuthType, SecretName: fakeName})
+	s := createSecret(map[string][]byte{"token": []byte("secret-token")})
+
+	p, err := GetAuthProvider(fake.NewFakeClient([]runtime.Object{s}...), ps, nil)
+	assert.NoError(t, err)
+
+	h, err := p.GetHeaders()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"Authorization": "Bearer secret-token"}, h)
+}
+
+func TestGetAuthProvider_ShouldCreateSsoProvider(t *testing.T) {
+	ps := createPerfServer(&v1alpha1.PerfServerAuth{Type: SsoAuthType})
+	s := createSecret(map[string][]byte{"username": []byte("user"), "password": []byte("pwd")})
+
+	p, err := GetAuthProvider(fake.NewFakeClient([]runtime.Object{s}...), ps, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, SsoAuthProvider{username: "user", password: "pwd"}, p)
+}
+
+func TestGetAuthProvider_ShouldRequireLuminateConfigByDefault(t *testing.T) {
+	_, err := GetAuthProvider(fake.NewFakeClient(), createPerfServer(nil), nil)
+	assert.Error(t, err)
+}
+
+func TestGetAuthProvider_ShouldFailOnUnknownType(t *testing.T) {
+	_, err := GetAuthProvider(fake.NewFakeClient(), createPerfServer(&v1alpha1.PerfServerAuth{Type: "kerberos"}), nil)
+	assert.Error(t, err)
+}
+
+func TestOAuth2AuthProvider_ShouldUseClientCredentials(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, secret, _ := r.BasicAuth()
+		if id != "client" || secret != "secret" || r.FormValue("grant_type") != "client_credentials" ||
+			r.FormValue("scope") != "perf.read perf.write" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"oauth-token","token_type":"Bearer"}`))
+	}))
+	defer srv.Close()
+
+	p := NewOAuth2AuthProvider(nil, srv.URL, "client", "secret", []string{"perf.read", "perf.write"})
+	h, err := p.GetHeaders()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"Authorization": "Bearer oauth-token"}, h)
+
+	p = NewOAuth2AuthProvider(nil, srv.URL, "client", "wrong", nil)
+	_, err = p.GetHeaders()
+	assert.Error(t, err)
+}
+
+func TestSsoAuthProvider_ShouldGetPerfToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/sso/token" || r.FormValue("username") != "user" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte("perf-token"))
+	}))
+	defer srv.Close()
+
+	h, err := NewSsoAuthProvider(nil, srv.URL, "user", "pwd").GetHeaders()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"Authorization": "Bearer perf-token"}, h)
+}
+
+func TestGetLuminateConfig_ShouldPreferSpecOverConfigMap(t *testing.T) {
+	cm := &coreV1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      LuminatesecConfigMapName,
+			Namespace: fakeNamespace,
+		},
+		Data: map[string]string{
+			"apiUrl":         "https://cm.luminate",
+			"credentialName": "cm-secret",
+		},
+	}
+	c := fake.NewFakeClient([]runtime.Object{cm}...)
+
+	url, name, err := GetLuminateConfig(c, createPerfServer(nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "https://cm.luminate", url)
+	assert.Equal(t, "cm-secret", name)
+
+	ps := createPerfServer(nil)
+	ps.Spec.Luminate = &v1alpha1.PerfServerLuminate{ApiUrl: "https://spec.luminate", CredentialName: "spec-secret"}
+	url, name, err = GetLuminateConfig(c, ps)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://spec.luminate", url)
+	assert.Equal(t, "spec-secret", name)
+
+	assert.Equal(t, AuthDependencies{Secrets: []string{fakeName, "spec-secret"}}, GetAuthDependencies(c, ps))
+	assert.Equal(t, AuthDependencies{
+		ConfigMaps: []string{LuminatesecConfigMapName},
+		Secrets:    []string{fakeName, "cm-secret"},
+	}, GetAuthDependencies(c, createPerfServer(nil)))
+}
@@ -0,0 +1,87 @@
+package perf
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/dto"
+	"github.com/pkg/errors"
+	"strings"
+)
+
+// DataSourceKey identifies a data source among the data sources of a PERF node.
+// Name and Url are optional and are used to tell apart several data sources of the same type.
+type DataSourceKey struct {
+	Type string
+	Name string
+	Url  string
+}
+
+// config keys that hold the address of the source system
+var dataSourceUrlKeys = []string{"url", "instanceId"}
+
+// SelectDataSource picks the data source matching the key. A data source of the same type is matched by name first
+// and then by url or instanceId. If neither name nor url is set, the only data source of the type is matched.
+// It returns nil if no data source matches and an error if the key matches several data sources.
+func SelectDataSource(dss []dto.DataSource, key DataSourceKey) (*dto.DataSource, error) {
+	var candidates []dto.DataSource
+	for _, ds := range dss {
+		if strings.EqualFold(ds.Type, key.Type) {
+			candidates = append(candidates, ds)
+		}
+	}
+
+	if key.Name == "" && key.Url == "" {
+		return pickOne(candidates, key)
+	}
+
+	if key.Name != "" {
+		var byName []dto.DataSource
+		for _, ds := range candidates {
+			if strings.EqualFold(ds.Name, key.Name) {
+				byName = append(byName, ds)
+			}
+		}
+		if len(byName) > 0 {
+			if len(byName) > 1 && key.Url != "" {
+				byName = filterByUrl(byName, key.Url)
+			}
+			return pickOne(byName, key)
+		}
+	}
+
+	if key.Url == "" {
+		return nil, nil
+	}
+	return pickOne(filterByUrl(candidates, key.Url), key)
+}
+
+func filterByUrl(dss []dto.DataSource, url string) []dto.DataSource {
+	var res []dto.DataSource
+	for _, ds := range dss {
+		for _, k := range dataSourceUrlKeys {
+			if v, ok := ds.Config[k].(string); ok && EqualUrls(v, url) {
+				res = append(res, ds)
+				break
+			}
+		}
+	}
+	return res
+}
+
+// EqualUrls compares urls regardless of the case and trailing slashes.
+func EqualUrls(a, b string) bool {
+	return strings.EqualFold(strings.TrimRight(a, "/"), strings.TrimRight(b, "/"))
+}
+
+func pickOne(dss []dto.DataSource, key DataSourceKey) (*dto.DataSource, error) {
+	switch len(dss) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &dss[0], nil
+	}
+	ids := make([]int, 0, len(dss))
+	for _, ds := range dss {
+		ids = append(ids, ds.Id)
+	}
+	return nil, errors.Errorf("%v PERF data sources match type %v, name %v and url %v: %v. set a unique name",
+		len(dss), key.Type, key.Name, key.Url, ids)
+}
@@ -0,0 +1,49 @@
+package perf
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/dto"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var sameTypeDataSources = []dto.DataSource{
+	{Id: 1, Name: "sonar", Type: "SONAR"},
+	{Id: 2, Name: "gitlab-a", Type: "GITLAB", Config: map[string]interface{}{"url": "https://gitlab-a.example.com"}},
+	{Id: 3, Name: "gitlab-b", Type: "GITLAB", Config: map[string]interface{}{"instanceId": "https://gitlab-b.example.com"}},
+	{Id: 4, Name: "gitlab-b", Type: "GITLAB", Config: map[string]interface{}{"url": "https://gitlab-c.example.com"}},
+}
+
+func TestSelectDataSource(t *testing.T) {
+	tests := []struct {
+		name string
+		key  DataSourceKey
+		id   int
+	}{
+		{"only data source of the type", DataSourceKey{Type: "sonar"}, 1},
+		{"only data source of the type with another name", DataSourceKey{Type: "sonar", Name: "other"}, 0},
+		{"by name", DataSourceKey{Type: "gitlab", Name: "GitLab-A", Url: "https://gitlab-b.example.com"}, 2},
+		{"by url", DataSourceKey{Type: "gitlab", Name: "renamed", Url: "https://gitlab-a.example.com/"}, 2},
+		{"by instance id", DataSourceKey{Type: "gitlab", Url: "https://gitlab-b.example.com"}, 3},
+		{"same name by url", DataSourceKey{Type: "gitlab", Name: "gitlab-b", Url: "https://gitlab-c.example.com"}, 4},
+		{"not found", DataSourceKey{Type: "jenkins", Name: "jenkins"}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ds, err := SelectDataSource(sameTypeDataSources, tt.key)
+			assert.NoError(t, err)
+			if tt.id == 0 {
+				assert.Nil(t, ds)
+				return
+			}
+			assert.Equal(t, tt.id, ds.Id)
+		})
+	}
+}
+
+func TestSelectDataSource_Ambiguous(t *testing.T) {
+	_, err := SelectDataSource(sameTypeDataSources, DataSourceKey{Type: "gitlab"})
+	assert.Error(t, err)
+
+	_, err = SelectDataSource(sameTypeDataSources, DataSourceKey{Type: "gitlab", Name: "gitlab-b"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,399 @@
+// Package fake provides an in-memory PERF server for tests that exercise the real PERF and Luminate clients.
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/dto"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Token is the PERF token the server issues on the SSO endpoint and accepts on the API ones.
+	Token = "fake-perf-token"
+	// LuminateToken is the Luminate API token the server issues and requires on the SSO endpoint
+	// once Luminate credentials are set.
+	LuminateToken = "fake-luminate-token"
+
+	luminateTokenTtl = 3600
+)
+
+// Fault makes the server fail or slow down the matching requests. Empty Method and Path match any request,
+// Path is a prefix of the request path. Times limits how many requests are affected, zero means all of them.
+type Fault struct {
+	Method string
+	Path   string
+	// Status is returned instead of handling the request if it isn't zero.
+	Status int
+	// Latency delays the request before it's handled or failed.
+	Latency time.Duration
+	// RetryAfter sets the Retry-After header, usually with the 429 status.
+	RetryAfter time.Duration
+	Times      int
+}
+
+type node struct {
+	id       int
+	name     string
+	parent   int
+	children []int
+	kpis     []dto.Kpi
+}
+
+type dataSource struct {
+	nodeId  int
+	ds      dto.DataSource
+	metrics []json.RawMessage
+}
+
+// Server is a stateful PERF API: the node tree with child node creation, data sources with their activation and pushed metrics,
+// node KPIs, the SSO token endpoint and the Luminate OAuth endpoint.
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	lastId      int
+	roots       []int
+	nodes       map[int]*node
+	dataSources map[int]*dataSource
+	username    string
+	password    string
+	lumClientId string
+	lumSecret   string
+	faults      []*Fault
+	requests    []string
+}
+
+// NewServer starts the server, it has to be closed by the caller.
+func NewServer() *Server {
+	s := &Server{
+		nodes:       make(map[int]*node),
+		dataSources: make(map[int]*dataSource),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// SetCredentials makes the SSO endpoint accept only the given PERF user. Any user is accepted otherwise.
+func (s *Server) SetCredentials(username, password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.username, s.password = username, password
+}
+
+// SetLuminateCredentials makes the Luminate endpoint accept only the given client and the SSO endpoint
+// require the Luminate token.
+func (s *Server) SetLuminateCredentials(clientId, secret string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lumClientId, s.lumSecret = clientId, secret
+}
+
+// AddNode adds a node under the parent one, zero parent adds a root node. It returns the node id.
+func (s *Server) AddNode(parent int, name string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.nextId()
+	s.nodes[id] = &node{id: id, name: name, parent: parent}
+	if p, ok := s.nodes[parent]; ok {
+		p.children = append(p.children, id)
+	} else {
+		s.roots = append(s.roots, id)
+	}
+	return id
+}
+
+func (s *Server) SetNodeKpis(nodeId int, kpis []dto.Kpi) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if n, ok := s.nodes[nodeId]; ok {
+		n.kpis = kpis
+	}
+}
+
+// AddDataSource stores a data source under the node as if it was created in PERF UI. It returns the data source id.
+func (s *Server) AddDataSource(nodeId int, ds dto.DataSource) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ds.Id = s.nextId()
+	s.dataSources[ds.Id] = &dataSource{nodeId: nodeId, ds: ds}
+	return ds.Id
+}
+
+// DataSources returns the data sources of the node.
+func (s *Server) DataSources(nodeId int) []dto.DataSource {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.getDataSources(nodeId)
+}
+
+// Metrics returns the metrics pushed to the data source, in the order they were received.
+func (s *Server) Metrics(dataSourceId int) []json.RawMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ds, ok := s.dataSources[dataSourceId]; ok {
+		return append([]json.RawMessage(nil), ds.metrics...)
+	}
+	return nil
+}
+
+// Inject adds a fault. Faults are checked in the order they were added.
+func (s *Server) Inject(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Requests returns the received requests as "METHOD /path" strings.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+func (s *Server) nextId() int {
+	s.lastId++
+	return s.lastId
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+	f := s.takeFault(r)
+	s.mu.Unlock()
+
+	if f != nil {
+		if f.Latency > 0 {
+			time.Sleep(f.Latency)
+		}
+		if f.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(f.RetryAfter.Seconds())))
+		}
+		if f.Status != 0 {
+			http.Error(w, http.StatusText(f.Status), f.Status)
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := strings.Trim(r.URL.Path, "/")
+	switch {
+	case r.Method == http.MethodPost && path == "v1/oauth/token":
+		s.luminateToken(w, r)
+	case r.Method == http.MethodPost && path == "api/v2/sso/token":
+		s.ssoToken(w, r)
+	case strings.HasPrefix(path, "api/v2/"):
+		if r.Header.Get("Authorization") != "Bearer "+Token {
+			http.Error(w, "invalid PERF token", http.StatusUnauthorized)
+			return
+		}
+		s.serveApi(w, r, strings.Split(strings.TrimPrefix(path, "api/v2/"), "/"))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) takeFault(r *http.Request) *Fault {
+	for i, f := range s.faults {
+		if (f.Method != "" && f.Method != r.Method) || !strings.HasPrefix(r.URL.Path, f.Path) {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+func (s *Server) luminateToken(w http.ResponseWriter, r *http.Request) {
+	id, secret, ok := r.BasicAuth()
+	if !ok || s.lumClientId != "" && (id != s.lumClientId || secret != s.lumSecret) {
+		writeJson(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+	writeJson(w, http.StatusOK, map[string]interface{}{
+		"access_token": LuminateToken,
+		"token_type":   "Bearer",
+		"expires_in":   luminateTokenTtl,
+	})
+}
+
+func (s *Server) ssoToken(w http.ResponseWriter, r *http.Request) {
+	if s.lumClientId != "" && r.Header.Get("lum-api-token") != LuminateToken {
+		http.Error(w, "invalid Luminate token", http.StatusUnauthorized)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if s.username != "" && (r.PostForm.Get("username") != s.username || r.PostForm.Get("password") != s.password) {
+		http.Error(w, "invalid PERF credentials", http.StatusUnauthorized)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	_, _ = w.Write([]byte(Token))
+}
+
+func (s *Server) serveApi(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case r.Method == http.MethodGet && len(path) == 1 && path[0] == "nodes":
+		writeJson(w, http.StatusOK, s.getProjects(s.roots))
+	case r.Method == http.MethodPost && len(path) == 3 && path[0] == "nodes" && path[2] == "children":
+		if n := s.getNode(w, path[1]); n != nil {
+			s.createNode(w, r, n)
+		}
+	case r.Method == http.MethodGet && len(path) == 5 && path[0] == "nodes" && path[2] == "datasets":
+		if n := s.getNode(w, path[1]); n != nil {
+			writeJson(w, http.StatusOK, s.getDataSources(n.id))
+		}
+	case r.Method == http.MethodGet && len(path) == 3 && path[0] == "nodes" && path[2] == "kpis":
+		if n := s.getNode(w, path[1]); n != nil {
+			writeJson(w, http.StatusOK, n.kpis)
+		}
+	case r.Method == http.MethodPost && len(path) == 3 && path[0] == "datasources" && path[1] == "node":
+		if n := s.getNode(w, path[2]); n != nil {
+			s.createDataSource(w, r, n)
+		}
+	case r.Method == http.MethodPut && len(path) == 2 && path[0] == "datasources":
+		if ds := s.getDataSource(w, path[1]); ds != nil {
+			s.updateDataSource(w, r, ds)
+		}
+	case r.Method == http.MethodPut && len(path) == 3 && path[0] == "datasources" && path[2] == "activation":
+		if ds := s.getDataSource(w, path[1]); ds != nil {
+			ds.ds.Active = true
+			w.WriteHeader(http.StatusOK)
+		}
+	case r.Method == http.MethodPost && len(path) == 3 && path[0] == "datasources" && path[2] == "data":
+		if ds := s.getDataSource(w, path[1]); ds != nil {
+			body, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			ds.metrics = append(ds.metrics, body)
+			w.WriteHeader(http.StatusOK)
+		}
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) getProjects(ids []int) []dto.PerfProject {
+	pp := make([]dto.PerfProject, 0, len(ids))
+	for _, id := range ids {
+		n := s.nodes[id]
+		pp = append(pp, dto.PerfProject{
+			Id:            n.id,
+			Name:          n.name,
+			HasDataSource: len(s.getDataSources(n.id)) > 0,
+			Children:      s.getProjects(n.children),
+		})
+	}
+	return pp
+}
+
+func (s *Server) getDataSources(nodeId int) []dto.DataSource {
+	dss := make([]dto.DataSource, 0)
+	for id := 1; id <= s.lastId; id++ {
+		if ds, ok := s.dataSources[id]; ok && ds.nodeId == nodeId {
+			dss = append(dss, ds.ds)
+		}
+	}
+	return dss
+}
+
+func (s *Server) getNode(w http.ResponseWriter, id string) *node {
+	i, _ := strconv.Atoi(id)
+	n, ok := s.nodes[i]
+	if !ok {
+		http.Error(w, fmt.Sprintf("node %v wasn't found", id), http.StatusNotFound)
+		return nil
+	}
+	return n
+}
+
+func (s *Server) getDataSource(w http.ResponseWriter, id string) *dataSource {
+	i, _ := strconv.Atoi(id)
+	ds, ok := s.dataSources[i]
+	if !ok {
+		http.Error(w, fmt.Sprintf("data source %v wasn't found", id), http.StatusNotFound)
+		return nil
+	}
+	return ds
+}
+
+func (s *Server) createNode(w http.ResponseWriter, r *http.Request, parent *node) {
+	p := dto.PerfProject{}
+	if err := json.NewDecoder(r.Body).Decode(&p); err != nil || p.Name == "" {
+		http.Error(w, "node name is required", http.StatusBadRequest)
+		return
+	}
+	for _, id := range parent.children {
+		if strings.EqualFold(s.nodes[id].name, p.Name) {
+			http.Error(w, fmt.Sprintf("node %v already exists", p.Name), http.StatusConflict)
+			return
+		}
+	}
+
+	id := s.nextId()
+	s.nodes[id] = &node{id: id, name: p.Name, parent: parent.id}
+	parent.children = append(parent.children, id)
+	writeJson(w, http.StatusCreated, s.getProjects([]int{id})[0])
+}
+
+func (s *Server) createDataSource(w http.ResponseWriter, r *http.Request, n *node) {
+	ds := dto.DataSource{}
+	if err := json.NewDecoder(r.Body).Decode(&ds); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if ds.Name == "" || ds.Type == "" {
+		http.Error(w, "data source name and type are required", http.StatusBadRequest)
+		return
+	}
+	ds.Id = s.nextId()
+	ds.Active = false
+	s.dataSources[ds.Id] = &dataSource{nodeId: n.id, ds: ds}
+	writeJson(w, http.StatusCreated, ds)
+}
+
+func (s *Server) updateDataSource(w http.ResponseWriter, r *http.Request, current *dataSource) {
+	ds := dto.DataSource{}
+	if err := json.NewDecoder(r.Body).Decode(&ds); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if ds.Name != "" {
+		current.ds.Name = ds.Name
+	}
+	if ds.Config != nil {
+		current.ds.Config = ds.Config
+	}
+	writeJson(w, http.StatusOK, current.ds)
+}
+
+func writeJson(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
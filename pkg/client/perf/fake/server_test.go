@@ -0,0 +1,261 @@
+package fake_test
+
+import (
+	"encoding/json"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/luminate"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf/fake"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/command"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/dto"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func newPerfClient(t *testing.T, srv *fake.Server) *perf.PerfClientAdapter {
+	pc, err := perf.NewRestClient(srv.URL, perf.NewBearerAuthProvider(fake.Token), nil)
+	assert.NoError(t, err)
+	return pc
+}
+
+func TestServer_DataSourceLifecycle(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+	root := srv.AddNode(0, "EPAM")
+	project := srv.AddNode(root, "Fake-Project")
+
+	pc := newPerfClient(t, srv)
+
+	connected, err := pc.Connected()
+	assert.NoError(t, err)
+	assert.True(t, connected)
+
+	p, err := pc.GetProject("fake-project")
+	assert.NoError(t, err)
+	assert.Equal(t, project, p.Id)
+	assert.False(t, p.HasDataSource)
+
+	err = pc.CreateDataSource(project, command.DataSourceCommand{
+		Name:   "jenkins",
+		Type:   command.Jenkins,
+		Config: command.DataSourceJenkinsConfig{JobNames: []string{"/job-1"}},
+	})
+	assert.NoError(t, err)
+
+	ds, err := pc.GetProjectDataSource(project, perf.DataSourceKey{Type: "jenkins", Name: "jenkins"})
+	assert.NoError(t, err)
+	assert.False(t, ds.Active)
+	assert.Equal(t, []interface{}{"/job-1"}, ds.Config["jobNames"])
+
+	assert.NoError(t, pc.ActivateDataSource(ds.Id))
+	assert.NoError(t, pc.UpdateDataSource(command.DataSourceCommand{
+		Id:     ds.Id,
+		Name:   ds.Name,
+		Type:   command.Jenkins,
+		Config: command.DataSourceJenkinsConfig{JobNames: []string{"/job-1", "/job-2"}},
+	}))
+	assert.NoError(t, pc.PushDataSourceMetrics(command.DataSourceMetricsCommand{
+		DataSourceId: ds.Id,
+		Timestamp:    1,
+		Metrics:      map[string]int{"deployments": 2},
+	}))
+
+	dss := srv.DataSources(project)
+	assert.Len(t, dss, 1)
+	assert.True(t, dss[0].Active)
+	assert.Equal(t, []interface{}{"/job-1", "/job-2"}, dss[0].Config["jobNames"])
+
+	metrics := srv.Metrics(ds.Id)
+	assert.Len(t, metrics, 1)
+	pushed := command.DataSourceMetricsCommand{}
+	assert.NoError(t, json.Unmarshal(metrics[0], &pushed))
+	assert.Equal(t, ds.Id, pushed.DataSourceId)
+}
+
+func TestServer_ProjectAddressing(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+	root := srv.AddNode(0, "EPAM")
+	a := srv.AddNode(srv.AddNode(root, "A"), "Backend")
+	b := srv.AddNode(srv.AddNode(root, "B"), "Backend")
+
+	pc := newPerfClient(t, srv)
+
+	p, err := pc.GetProjectByPath("/epam/b/backend")
+	assert.NoError(t, err)
+	assert.Equal(t, b, p.Id)
+
+	p, err = pc.GetProjectByPath("EPAM/C/Backend")
+	assert.NoError(t, err)
+	assert.Nil(t, p)
+
+	p, path, err := pc.GetProjectById(a)
+	assert.NoError(t, err)
+	assert.Equal(t, "Backend", p.Name)
+	assert.Equal(t, "EPAM/A/Backend", path)
+
+	p, _, err = pc.GetProjectById(100)
+	assert.NoError(t, err)
+	assert.Nil(t, p)
+
+	p, err = perf.GetNode(pc, "a")
+	assert.NoError(t, err)
+	assert.Equal(t, "A", p.Name)
+
+	_, err = perf.GetNode(pc, "backend")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "2 PERF nodes are named backend")
+}
+
+func TestServer_ResolveNode(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+	project := srv.AddNode(0, "Fake-Project")
+	stream := srv.AddNode(project, "Stream-A")
+
+	pc := newPerfClient(t, srv)
+
+	id, err := perf.ResolveNode(pc, project, "", false)
+	assert.NoError(t, err)
+	assert.Equal(t, project, id)
+
+	id, err = perf.ResolveNode(pc, project, "stream-a", false)
+	assert.NoError(t, err)
+	assert.Equal(t, stream, id)
+
+	_, err = perf.ResolveNode(pc, project, "Stream-A/Backend", false)
+	assert.Error(t, err)
+
+	backend, err := perf.ResolveNode(pc, project, "Stream-A/Backend", true)
+	assert.NoError(t, err)
+	_, path, err := pc.GetProjectById(backend)
+	assert.NoError(t, err)
+	assert.Equal(t, "Fake-Project/Stream-A/Backend", path)
+
+	id, err = perf.ResolveNode(pc, project, "Stream-A/Backend", true)
+	assert.NoError(t, err)
+	assert.Equal(t, backend, id)
+}
+
+func TestServer_SameTypeDataSources(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+	project := srv.AddNode(0, "Fake-Project")
+	first := srv.AddDataSource(project, dto.DataSource{
+		Name:   "jenkins-a",
+		Type:   "JENKINS",
+		Config: map[string]interface{}{"url": "https://jenkins-a.example.com"},
+	})
+	second := srv.AddDataSource(project, dto.DataSource{
+		Name:   "jenkins-b",
+		Type:   "JENKINS",
+		Config: map[string]interface{}{"url": "https://jenkins-b.example.com"},
+	})
+
+	pc := newPerfClient(t, srv)
+
+	ds, err := pc.GetProjectDataSource(project, perf.DataSourceKey{Type: "jenkins", Name: "Jenkins-B"})
+	assert.NoError(t, err)
+	assert.Equal(t, second, ds.Id)
+
+	ds, err = pc.GetProjectDataSource(project, perf.DataSourceKey{
+		Type: "jenkins",
+		Name: "jenkins-renamed",
+		Url:  "https://jenkins-a.example.com/",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, first, ds.Id)
+
+	ds, err = pc.GetProjectDataSource(project, perf.DataSourceKey{Type: "jenkins", Name: "jenkins-c"})
+	assert.NoError(t, err)
+	assert.Nil(t, ds)
+
+	_, err = pc.GetProjectDataSource(project, perf.DataSourceKey{Type: "jenkins"})
+	assert.Error(t, err)
+}
+
+func TestServer_NodeKpis(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+	id := srv.AddNode(0, "Fake-Project")
+	srv.SetNodeKpis(id, []dto.Kpi{{Id: 1, Name: "Build Success Rate", Value: 95, Unit: "%", Status: "GREEN"}})
+
+	kpis, err := newPerfClient(t, srv).GetNodeKpis(id)
+	assert.NoError(t, err)
+	assert.Len(t, kpis, 1)
+	assert.Equal(t, 95.0, kpis[0].Value)
+}
+
+func TestServer_SsoAuth(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+	srv.SetCredentials("user", "pwd")
+
+	h, err := perf.NewSsoAuthProvider(nil, srv.URL, "user", "pwd").GetHeaders()
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer "+fake.Token, h["Authorization"])
+
+	_, err = perf.NewSsoAuthProvider(nil, srv.URL, "user", "wrong").GetHeaders()
+	assert.Error(t, err)
+}
+
+func TestServer_LuminateAuth(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+	srv.SetLuminateCredentials("client", "secret")
+	srv.AddNode(0, "Fake-Project")
+
+	tokens := luminate.NewTokenSource(luminate.NewLuminateRestClient(srv.URL, nil), "client", "secret")
+	pc, err := perf.NewRestClient(srv.URL, perf.NewLuminateAuthProvider(nil, tokens, srv.URL, "user", "pwd"), nil)
+	assert.NoError(t, err)
+
+	exists, err := pc.ProjectExists("Fake-Project")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	wrong := luminate.NewTokenSource(luminate.NewLuminateRestClient(srv.URL, nil), "client", "wrong")
+	_, err = perf.NewLuminateAuthProvider(nil, wrong, srv.URL, "user", "pwd").GetHeaders()
+	assert.Error(t, err)
+}
+
+func TestServer_Unauthorized(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	pc, err := perf.NewRestClient(srv.URL, perf.NewBearerAuthProvider("expired"), nil)
+	assert.NoError(t, err)
+
+	_, err = pc.Connected()
+	assert.Error(t, err)
+}
+
+func TestServer_Faults(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+	srv.AddNode(0, "Fake-Project")
+	pc := newPerfClient(t, srv)
+
+	srv.Inject(fake.Fault{Path: "/api/v2/nodes", Status: http.StatusInternalServerError, Times: 1})
+	_, err := pc.Connected()
+	assert.Error(t, err)
+	_, err = pc.Connected()
+	assert.NoError(t, err)
+
+	srv.Inject(fake.Fault{Method: http.MethodGet, Status: http.StatusTooManyRequests, RetryAfter: time.Second})
+	_, err = pc.Connected()
+	assert.Error(t, err)
+	srv.ClearFaults()
+
+	srv.Inject(fake.Fault{Status: http.StatusUnauthorized, Times: 1})
+	_, err = pc.Connected()
+	assert.Error(t, err)
+
+	srv.Inject(fake.Fault{Latency: 50 * time.Millisecond, Times: 1})
+	start := time.Now()
+	_, err = pc.Connected()
+	assert.NoError(t, err)
+	assert.True(t, time.Since(start) >= 50*time.Millisecond)
+
+	assert.Len(t, srv.Requests(), 5)
+}
@@ -1,6 +1,7 @@
 package mock
 
 import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf"
 	"github.com/epmd-edp/perf-operator/v2/pkg/model/command"
 	"github.com/epmd-edp/perf-operator/v2/pkg/model/dto"
 	"github.com/stretchr/testify/mock"
@@ -16,7 +17,35 @@ func (m MockPerfClient) Connected() (bool, error) {
 }
 
 func (m MockPerfClient) GetProject(name string) (ds *dto.PerfProject, err error) {
-	panic("implement me")
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.PerfProject), args.Error(1)
+}
+
+func (m MockPerfClient) GetProjectsByName(name string) ([]dto.PerfProject, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.PerfProject), args.Error(1)
+}
+
+func (m MockPerfClient) GetProjectByPath(path string) (*dto.PerfProject, error) {
+	args := m.Called(path)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.PerfProject), args.Error(1)
+}
+
+func (m MockPerfClient) GetProjectById(id int) (*dto.PerfProject, string, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.String(1), args.Error(2)
+	}
+	return args.Get(0).(*dto.PerfProject), args.String(1), args.Error(2)
 }
 
 func (m MockPerfClient) ProjectExists(name string) (bool, error) {
@@ -24,21 +53,29 @@ func (m MockPerfClient) ProjectExists(name string) (bool, error) {
 	return args.Get(0).(bool), args.Error(1)
 }
 
-func (m MockPerfClient) GetProjectDataSource(projectName, dsType string) (*dto.DataSource, error) {
-	args := m.Called(projectName, dsType)
+func (m MockPerfClient) CreateNode(parentId int, name string) (*dto.PerfProject, error) {
+	args := m.Called(parentId, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.PerfProject), args.Error(1)
+}
+
+func (m MockPerfClient) GetProjectDataSource(projectId int, key perf.DataSourceKey) (*dto.DataSource, error) {
+	args := m.Called(projectId, key)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*dto.DataSource), args.Error(1)
 }
 
-func (m MockPerfClient) CreateDataSource(projectName string, command command.DataSourceCommand) error {
-	args := m.Called(projectName, command)
+func (m MockPerfClient) CreateDataSource(projectId int, command command.DataSourceCommand) error {
+	args := m.Called(projectId, command)
 	return args.Error(0)
 }
 
-func (m MockPerfClient) ActivateDataSource(projectName string, dataSourceId int) error {
-	args := m.Called(projectName, dataSourceId)
+func (m MockPerfClient) ActivateDataSource(dataSourceId int) error {
+	args := m.Called(dataSourceId)
 	return args.Error(0)
 }
 
@@ -46,3 +83,16 @@ func (m MockPerfClient) UpdateDataSource(command command.DataSourceCommand) erro
 	args := m.Called(command)
 	return args.Error(0)
 }
+
+func (m MockPerfClient) PushDataSourceMetrics(command command.DataSourceMetricsCommand) error {
+	args := m.Called(command)
+	return args.Error(0)
+}
+
+func (m MockPerfClient) GetNodeKpis(nodeId int) ([]dto.Kpi, error) {
+	args := m.Called(nodeId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.Kpi), args.Error(1)
+}
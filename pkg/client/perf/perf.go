@@ -1,13 +1,11 @@
 package perf
 
 import (
-	"github.com/epmd-edp/perf-operator/v2/pkg/client/luminate"
 	"github.com/epmd-edp/perf-operator/v2/pkg/model/command"
 	"github.com/epmd-edp/perf-operator/v2/pkg/model/dto"
-	"github.com/epmd-edp/perf-operator/v2/pkg/util/cluster"
 	"github.com/pkg/errors"
 	"gopkg.in/resty.v1"
-	"sigs.k8s.io/controller-runtime/pkg/client"
+	"net/http"
 	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
 	"strconv"
 	"strings"
@@ -16,11 +14,17 @@ import (
 type PerfClient interface {
 	Connected() (bool, error)
 	GetProject(name string) (ds *dto.PerfProject, err error)
+	GetProjectByPath(path string) (*dto.PerfProject, error)
+	GetProjectsByName(name string) ([]dto.PerfProject, error)
+	GetProjectById(id int) (project *dto.PerfProject, path string, err error)
 	ProjectExists(name string) (bool, error)
-	GetProjectDataSource(projectName, dsType string) (*dto.DataSource, error)
-	CreateDataSource(projectName string, command command.DataSourceCommand) error
-	ActivateDataSource(projectName string, dataSourceId int) error
+	CreateNode(parentId int, name string) (*dto.PerfProject, error)
+	GetProjectDataSource(projectId int, key DataSourceKey) (*dto.DataSource, error)
+	CreateDataSource(projectId int, command command.DataSourceCommand) error
+	ActivateDataSource(dataSourceId int) error
 	UpdateDataSource(command command.DataSourceCommand) error
+	PushDataSourceMetrics(command command.DataSourceMetricsCommand) error
+	GetNodeKpis(nodeId int) ([]dto.Kpi, error)
 }
 
 type PerfClientAdapter struct {
@@ -29,59 +33,26 @@ type PerfClientAdapter struct {
 
 var log = logf.Log.WithName("perf_client")
 
-const luminatesecConfigMapName = "luminatesec-conf"
-
-func NewRestClient(url, user, pwd, lumToken string) (*PerfClientAdapter, error) {
-	rl := log.WithValues("url", url, "user", user)
+func NewRestClient(url string, provider AuthProvider, transport http.RoundTripper) (*PerfClientAdapter, error) {
+	rl := log.WithValues("url", url)
 	rl.Info("initializing new Perf REST client.")
 
-	token, err := getAuthorizationToken(url, user, pwd, lumToken)
+	headers, err := provider.GetHeaders()
 	if err != nil {
 		return nil, err
 	}
 
-	cl := resty.New().
+	cl := newRestyClient(transport).
 		SetHostURL(url).
-		SetHeader("lum-api-token", lumToken).
-		SetAuthToken(token)
+		SetHeaders(headers)
 	rl.Info("Perf REST client successfully has been created.")
 	return &PerfClientAdapter{
 		client: *cl,
-	}, err
-}
-
-func GetPerfCredentials(client client.Client, secretName, namespace string) (*dto.PerfCredentials, error) {
-	cm, err := cluster.GetConfigMap(client, luminatesecConfigMapName, namespace)
-	if err != nil {
-		return nil, err
-	}
-
-	lumClient := luminate.NewLuminateRestClient(cm.Data["apiUrl"])
-
-	lumSecret, err := cluster.GetSecret(client, cm.Data["credentialName"], namespace)
-	if err != nil {
-		return nil, err
-	}
-
-	lumToken, err := lumClient.GetApiToken(string(lumSecret.Data["username"]), string(lumSecret.Data["password"]))
-	if err != nil {
-		return nil, err
-	}
-
-	s, err := cluster.GetSecret(client, secretName, namespace)
-	if err != nil {
-		return nil, err
-	}
-
-	return &dto.PerfCredentials{
-		Username:      string(s.Data["username"]),
-		Password:      string(s.Data["password"]),
-		LuminateToken: *lumToken,
 	}, nil
 }
 
-func getAuthorizationToken(url, user, pwd, lumApiToken string) (string, error) {
-	resp, err := resty.R().
+func getAuthorizationToken(transport http.RoundTripper, url, user, pwd, lumApiToken string) (string, error) {
+	resp, err := newRestyClient(transport).R().
 		SetHeaders(map[string]string{
 			"Content-Type":  "application/x-www-form-urlencoded",
 			"accept":        "text/plain",
@@ -135,6 +106,149 @@ func (c PerfClientAdapter) GetProject(name string) (ds *dto.PerfProject, err err
 	return node, nil
 }
 
+// GetProjectByPath finds the node by its slash-separated path from a root node, comparing names case-insensitively.
+func (c PerfClientAdapter) GetProjectByPath(path string) (*dto.PerfProject, error) {
+	projects, err := c.getProjects()
+	if err != nil {
+		return nil, err
+	}
+
+	var node *dto.PerfProject
+	for _, name := range SplitProjectPath(path) {
+		node = findChild(projects, name)
+		if node == nil {
+			return nil, nil
+		}
+		projects = node.Children
+	}
+	return node, nil
+}
+
+// GetProjectsByName finds all the nodes with the name anywhere in the tree, comparing names case-insensitively.
+func (c PerfClientAdapter) GetProjectsByName(name string) ([]dto.PerfProject, error) {
+	projects, err := c.getProjects()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		nodes     []dto.PerfProject
+		findNodes func(projects []dto.PerfProject)
+	)
+	findNodes = func(projects []dto.PerfProject) {
+		for _, p := range projects {
+			if strings.EqualFold(p.Name, name) {
+				nodes = append(nodes, p)
+			}
+			findNodes(p.Children)
+		}
+	}
+	findNodes(projects)
+	return nodes, nil
+}
+
+func findChild(projects []dto.PerfProject, name string) *dto.PerfProject {
+	for i := range projects {
+		if strings.EqualFold(projects[i].Name, name) {
+			return &projects[i]
+		}
+	}
+	return nil
+}
+
+// GetProjectById finds the node by its id and returns it along with its current path.
+func (c PerfClientAdapter) GetProjectById(id int) (*dto.PerfProject, string, error) {
+	projects, err := c.getProjects()
+	if err != nil {
+		return nil, "", err
+	}
+
+	var findNode func(projects []dto.PerfProject, path []string) (*dto.PerfProject, []string)
+	findNode = func(projects []dto.PerfProject, path []string) (*dto.PerfProject, []string) {
+		for i := range projects {
+			p := append(path[:len(path):len(path)], projects[i].Name)
+			if projects[i].Id == id {
+				return &projects[i], p
+			}
+			if node, np := findNode(projects[i].Children, p); node != nil {
+				return node, np
+			}
+		}
+		return nil, nil
+	}
+
+	node, path := findNode(projects, nil)
+	return node, strings.Join(path, "/"), nil
+}
+
+// GetNode finds the node by its path if the name contains slashes or by the name anywhere in the tree otherwise.
+// A name of several nodes is rejected, as any of them could be taken.
+func GetNode(c PerfClient, name string) (*dto.PerfProject, error) {
+	if strings.Contains(name, "/") {
+		return c.GetProjectByPath(name)
+	}
+
+	nodes, err := c.GetProjectsByName(name)
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &nodes[0], nil
+	}
+	return nil, errors.Errorf("%v PERF nodes are named %v, the node has to be set by its path", len(nodes), name)
+}
+
+// ResolveNode returns the id of the node at the slash-separated path relative to the project node,
+// creating the missing nodes if it's allowed. The project id is returned for an empty path.
+func ResolveNode(c PerfClient, projectId int, path string, create bool) (int, error) {
+	names := SplitProjectPath(path)
+	if len(names) == 0 {
+		return projectId, nil
+	}
+
+	project, _, err := c.GetProjectById(projectId)
+	if err != nil {
+		return 0, err
+	}
+	if project == nil {
+		return 0, errors.Errorf("PERF project with %v id wasn't found", projectId)
+	}
+
+	node := project
+	for _, name := range names {
+		child := findChild(node.Children, name)
+		if child == nil {
+			if !create {
+				return 0, errors.Errorf("PERF node %v wasn't found under %v project", path, project.Name)
+			}
+			if child, err = c.CreateNode(node.Id, name); err != nil {
+				return 0, err
+			}
+		}
+		node = child
+	}
+	return node.Id, nil
+}
+
+// SplitProjectPath splits the slash-separated node path ignoring empty segments.
+func SplitProjectPath(path string) []string {
+	var names []string
+	for _, n := range strings.Split(path, "/") {
+		if n = strings.TrimSpace(n); n != "" {
+			names = append(names, n)
+		}
+	}
+	return names
+}
+
+// EqualProjectPaths compares node paths case-insensitively.
+func EqualProjectPaths(a, b string) bool {
+	return strings.EqualFold(strings.Join(SplitProjectPath(a), "/"), strings.Join(SplitProjectPath(b), "/"))
+}
+
 func (c PerfClientAdapter) ProjectExists(name string) (bool, error) {
 	log.Info("start checking project for existence", "name", name)
 	project, err := c.GetProject(name)
@@ -144,6 +258,29 @@ func (c PerfClientAdapter) ProjectExists(name string) (bool, error) {
 	return project != nil, nil
 }
 
+func (c PerfClientAdapter) CreateNode(parentId int, name string) (*dto.PerfProject, error) {
+	log.Info("start creating PERF node", "parent id", parentId, "name", name)
+	node := &dto.PerfProject{}
+	resp, err := c.client.R().
+		SetHeader("Content-Type", "application/json").
+		SetPathParams(map[string]string{
+			"id": strconv.Itoa(parentId),
+		}).
+		SetBody(map[string]string{
+			"nodeName": name,
+		}).
+		SetResult(node).
+		Post("/api/v2/nodes/{id}/children")
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't create %v node under %v node", name, parentId)
+	}
+	if resp.IsError() {
+		return nil, errors.Errorf("couldn't create %v node under %v node. Status - %v", name, parentId, resp.StatusCode())
+	}
+	log.Info("PERF node has been created.", "id", node.Id, "name", name)
+	return node, nil
+}
+
 func (c PerfClientAdapter) getProjects() ([]dto.PerfProject, error) {
 	var pp []dto.PerfProject
 	resp, err := c.client.R().
@@ -159,15 +296,15 @@ func (c PerfClientAdapter) getProjects() ([]dto.PerfProject, error) {
 	return pp, nil
 }
 
-func (c PerfClientAdapter) GetProjectDataSource(projectName, dsType string) (*dto.DataSource, error) {
-	rlog := log.WithValues("projectName", projectName, "dsType", dsType)
+func (c PerfClientAdapter) GetProjectDataSource(projectId int, key DataSourceKey) (*dto.DataSource, error) {
+	rlog := log.WithValues("projectId", projectId, "dsType", key.Type, "dsName", key.Name, "dsUrl", key.Url)
 	rlog.Info("start retrieving PERF datasource")
-	project, err := c.GetProject(projectName)
+	project, _, err := c.GetProjectById(projectId)
 	if err != nil {
 		return nil, err
 	}
 	if project == nil {
-		return nil, errors.Errorf("PERF project %v wasn't found", projectName)
+		return nil, errors.Errorf("PERF project with %v id wasn't found", projectId)
 	}
 
 	if !project.HasDataSource {
@@ -180,14 +317,16 @@ func (c PerfClientAdapter) GetProjectDataSource(projectName, dsType string) (*dt
 		return nil, err
 	}
 
-	for _, ds := range dss {
-		if ds.Type == strings.ToUpper(dsType) {
-			rlog.Info("datasource has been found in PERF.")
-			return &ds, nil
-		}
+	ds, err := SelectDataSource(dss, key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't select datasource in %v project", projectId)
+	}
+	if ds == nil {
+		rlog.Info("datasource has not been found in PERF.")
+		return nil, nil
 	}
-	rlog.Info("datasource has not been found in PERF.")
-	return nil, nil
+	rlog.Info("datasource has been found in PERF.", "id", ds.Id)
+	return ds, nil
 }
 
 func (c PerfClientAdapter) getProjectDataSources(projectId int) ([]dto.DataSource, error) {
@@ -208,38 +347,31 @@ func (c PerfClientAdapter) getProjectDataSources(projectId int) ([]dto.DataSourc
 	return ds, nil
 }
 
-func (c PerfClientAdapter) CreateDataSource(projectName string, command command.DataSourceCommand) error {
-	rlog := log.WithValues("project name", projectName, "datasource name", command.Name)
+func (c PerfClientAdapter) CreateDataSource(projectId int, command command.DataSourceCommand) error {
+	rlog := log.WithValues("project id", projectId, "datasource name", command.Name)
 	rlog.Info("start creating datasource under project")
-	project, err := c.GetProject(projectName)
-	if err != nil {
-		return err
-	}
-	if project == nil {
-		return errors.Errorf("PERF project %v wasn't found", projectName)
-	}
 
 	resp, err := c.client.R().
 		SetHeader("Content-Type", "application/json").
 		SetPathParams(map[string]string{
-			"id": strconv.Itoa(project.Id),
+			"id": strconv.Itoa(projectId),
 		}).
 		SetBody(command).
 		Post("/api/v2/datasources/node/{id}")
 	if err != nil {
-		return errors.Wrapf(err, "couldn't create %v datasource under %v project", command.Name, projectName)
+		return errors.Wrapf(err, "couldn't create %v datasource under %v project", command.Name, projectId)
 	}
 	if resp.IsError() {
 		return errors.Errorf("couldn't create %v datasource under %v project. Status - %v",
-			command.Name, projectName, resp.StatusCode())
+			command.Name, projectId, resp.StatusCode())
 	}
 
 	rlog.Info("datasource has been created.")
 	return nil
 }
 
-func (c PerfClientAdapter) ActivateDataSource(projectName string, dataSourceId int) error {
-	rlog := log.WithValues("project name", projectName, "datasource id", dataSourceId)
+func (c PerfClientAdapter) ActivateDataSource(dataSourceId int) error {
+	rlog := log.WithValues("datasource id", dataSourceId)
 	rlog.Info("try to activate data source")
 
 	resp, err := c.client.R().
@@ -249,11 +381,10 @@ func (c PerfClientAdapter) ActivateDataSource(projectName string, dataSourceId i
 		}).
 		Put("/api/v2/datasources/{id}/activation")
 	if err != nil {
-		return errors.Wrapf(err, "couldn't activate %v datasource under %v project", dataSourceId, projectName)
+		return errors.Wrapf(err, "couldn't activate %v datasource", dataSourceId)
 	}
 	if resp.IsError() {
-		return errors.Errorf("couldn't activate %v datasource under %v project. Status - %v",
-			dataSourceId, projectName, resp.StatusCode())
+		return errors.Errorf("couldn't activate %v datasource. Status - %v", dataSourceId, resp.StatusCode())
 	}
 	rlog.Info("data source has been activated")
 	return nil
@@ -277,3 +408,42 @@ func (c PerfClientAdapter) UpdateDataSource(command command.DataSourceCommand) e
 	log.Info("PERF datasource has been update.", "name", command.Name)
 	return nil
 }
+
+func (c PerfClientAdapter) PushDataSourceMetrics(command command.DataSourceMetricsCommand) error {
+	log.Info("start pushing metrics to PERF datasource", "id", command.DataSourceId)
+	resp, err := c.client.R().
+		SetHeader("Content-Type", "application/json").
+		SetPathParams(map[string]string{
+			"id": strconv.Itoa(command.DataSourceId),
+		}).
+		SetBody(command).
+		Post("/api/v2/datasources/{id}/data")
+	if err != nil {
+		return errors.Wrapf(err, "couldn't push metrics to %v datasource", command.DataSourceId)
+	}
+	if resp.IsError() {
+		return errors.Errorf("couldn't push metrics to %v datasource. Status - %v", command.DataSourceId, resp.StatusCode())
+	}
+	log.Info("metrics have been pushed to PERF datasource.", "id", command.DataSourceId)
+	return nil
+}
+
+func (c PerfClientAdapter) GetNodeKpis(nodeId int) ([]dto.Kpi, error) {
+	log.Info("start retrieving KPIs of PERF node", "id", nodeId)
+	var kpis []dto.Kpi
+	resp, err := c.client.R().
+		SetHeader("Content-Type", "application/json").
+		SetResult(&kpis).
+		SetPathParams(map[string]string{
+			"id": strconv.Itoa(nodeId),
+		}).
+		Get("/api/v2/nodes/{id}/kpis")
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't get KPIs of %v node", nodeId)
+	}
+	if resp.IsError() {
+		return nil, errors.Errorf("couldn't get KPIs of %v node. Status - %v", nodeId, resp.StatusCode())
+	}
+	log.Info("KPIs of PERF node have been retrieved.", "id", nodeId, "count", len(kpis))
+	return kpis, nil
+}
@@ -0,0 +1,202 @@
+package perf
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/cluster"
+	"github.com/pkg/errors"
+	"gopkg.in/resty.v1"
+	coreV1 "k8s.io/api/core/v1"
+	"net/http"
+	"net/url"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const defaultCaBundleKey = "ca.crt"
+
+// cachedTransport is the transport of a PerfServer along with the fingerprint of the spec, CA bundle and client
+// certificate it has been built from.
+type cachedTransport struct {
+	fingerprint string
+	transport   *http.Transport
+}
+
+var (
+	transportsMu sync.Mutex
+	transports   = make(map[string]*cachedTransport)
+)
+
+// GetTransport returns the transport for PERF and Luminate connections of the PerfServer. It's shared by all clients
+// of the PerfServer, so the connections are reused across reconciliations, and rebuilt only if the transport spec,
+// the CA bundle or the client certificate changes. nil is returned if the spec doesn't customize it,
+// so the clients keep Go's defaults.
+func GetTransport(client client.Client, ps *v1alpha1.PerfServer) (http.RoundTripper, error) {
+	t := ps.Spec.Transport
+	if t == nil {
+		EvictTransport(ps.Namespace, ps.Name)
+		return nil, nil
+	}
+
+	var caBundle, cert, key []byte
+	if t.CaBundleName != "" {
+		b, err := getCaBundle(client, t, ps.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		caBundle = b
+	}
+
+	if t.ClientCertSecretName != "" {
+		s, err := cluster.GetSecret(client, t.ClientCertSecretName, ps.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		cert, key = s.Data[coreV1.TLSCertKey], s.Data[coreV1.TLSPrivateKeyKey]
+	}
+
+	transportsMu.Lock()
+	defer transportsMu.Unlock()
+
+	fingerprint := getFingerprint(t, caBundle, cert, key)
+	cached, ok := transports[getTransportKey(ps.Namespace, ps.Name)]
+	if ok && cached.fingerprint == fingerprint {
+		return cached.transport, nil
+	}
+
+	tr, err := newTransport(t, caBundle, cert, key)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		cached.transport.CloseIdleConnections()
+	}
+	transports[getTransportKey(ps.Namespace, ps.Name)] = &cachedTransport{fingerprint: fingerprint, transport: tr}
+	return tr, nil
+}
+
+// EvictTransport closes the idle connections of the PerfServer transport and removes it from the cache.
+func EvictTransport(namespace, name string) {
+	transportsMu.Lock()
+	defer transportsMu.Unlock()
+
+	if cached, ok := transports[getTransportKey(namespace, name)]; ok {
+		cached.transport.CloseIdleConnections()
+		delete(transports, getTransportKey(namespace, name))
+	}
+}
+
+func getTransportKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+func getFingerprint(t *v1alpha1.PerfServerTransport, caBundle, cert, key []byte) string {
+	h := sha256.New()
+	for _, v := range [][]byte{[]byte(strconv.FormatBool(t.Insecure)), caBundle, cert, key, []byte(t.ProxyUrl),
+		[]byte(strings.Join(t.NoProxy, ","))} {
+		h.Write(v)
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// newTransport starts from Go's default transport, so its dial, TLS handshake and idle connection timeouts are kept.
+func newTransport(t *v1alpha1.PerfServerTransport, caBundle, cert, key []byte) (*http.Transport, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: t.Insecure,
+	}
+
+	if t.CaBundleName != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caBundle) {
+			return nil, errors.Errorf("couldn't find CA certificates in %v key of %v config map",
+				getCaBundleKey(t), t.CaBundleName)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if t.ClientCertSecretName != "" {
+		c, err := tls.X509KeyPair(cert, key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "couldn't parse client certificate from %v secret", t.ClientCertSecretName)
+		}
+		tlsConfig.Certificates = []tls.Certificate{c}
+	}
+
+	proxy, err := getProxy(t)
+	if err != nil {
+		return nil, err
+	}
+
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.Proxy = proxy
+	tr.TLSClientConfig = tlsConfig
+	return tr, nil
+}
+
+func getCaBundle(client client.Client, t *v1alpha1.PerfServerTransport, namespace string) ([]byte, error) {
+	cm, err := cluster.GetConfigMap(client, t.CaBundleName, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(cm.Data[getCaBundleKey(t)]), nil
+}
+
+func getCaBundleKey(t *v1alpha1.PerfServerTransport) string {
+	if t.CaBundleKey == "" {
+		return defaultCaBundleKey
+	}
+	return t.CaBundleKey
+}
+
+func getProxy(t *v1alpha1.PerfServerTransport) (func(*http.Request) (*url.URL, error), error) {
+	if t.ProxyUrl == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+
+	proxyUrl, err := url.Parse(t.ProxyUrl)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't parse %v proxy url", t.ProxyUrl)
+	}
+
+	return func(req *http.Request) (*url.URL, error) {
+		if bypassProxy(req.URL.Hostname(), t.NoProxy) {
+			return nil, nil
+		}
+		return proxyUrl, nil
+	}, nil
+}
+
+// bypassProxy matches the host against no-proxy entries: "*", exact hosts and domain suffixes.
+func bypassProxy(host string, noProxy []string) bool {
+	host = strings.ToLower(host)
+	for _, np := range noProxy {
+		np = strings.ToLower(strings.TrimSpace(np))
+		if np == "" {
+			continue
+		}
+		if np == "*" || host == np || host == strings.TrimPrefix(np, ".") {
+			return true
+		}
+		if strings.HasSuffix(host, "."+strings.TrimPrefix(np, ".")) {
+			return true
+		}
+	}
+	return false
+}
+
+func newRestyClient(transport http.RoundTripper) *resty.Client {
+	cl := resty.New()
+	if transport != nil {
+		cl.SetTransport(transport)
+	}
+	return cl
+}
@@ -0,0 +1,166 @@
+package perf
+
+import (
+	"context"
+	"encoding/pem"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+	"net/http/httptest"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func createTransportPerfServer(t *v1alpha1.PerfServerTransport) *v1alpha1.PerfServer {
+	ps := createPerfServer(nil)
+	ps.Spec.Transport = t
+	return ps
+}
+
+func TestGetTransport_NotCustomized(t *testing.T) {
+	tr, err := GetTransport(fake.NewFakeClient(), createPerfServer(nil))
+	assert.NoError(t, err)
+	assert.Nil(t, tr)
+}
+
+func TestGetTransport_CaBundle(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	cm := &coreV1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Data: map[string]string{
+			defaultCaBundleKey: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})),
+		},
+	}
+	ps := createTransportPerfServer(&v1alpha1.PerfServerTransport{CaBundleName: fakeName})
+
+	tr, err := GetTransport(fake.NewFakeClient(cm), ps)
+	assert.NoError(t, err)
+
+	resp, err := newRestyClient(tr).R().Get(srv.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode())
+}
+
+func TestGetTransport_InvalidCaBundle(t *testing.T) {
+	cm := &coreV1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Data: map[string]string{
+			"bundle.pem": "not a certificate",
+		},
+	}
+	ps := createTransportPerfServer(&v1alpha1.PerfServerTransport{CaBundleName: fakeName, CaBundleKey: "bundle.pem"})
+
+	_, err := GetTransport(fake.NewFakeClient(cm), ps)
+	assert.Error(t, err)
+}
+
+func TestGetTransport_MissingClientCert(t *testing.T) {
+	ps := createTransportPerfServer(&v1alpha1.PerfServerTransport{ClientCertSecretName: fakeName})
+
+	_, err := GetTransport(fake.NewFakeClient(), ps)
+	assert.Error(t, err)
+}
+
+func TestGetTransport_Proxy(t *testing.T) {
+	ps := createTransportPerfServer(&v1alpha1.PerfServerTransport{
+		ProxyUrl: "http://proxy.example.com:3128",
+		NoProxy:  []string{".svc", "localhost"},
+	})
+
+	tr, err := GetTransport(fake.NewFakeClient(), ps)
+	assert.NoError(t, err)
+
+	proxy := tr.(*http.Transport).Proxy
+	req, _ := http.NewRequest(http.MethodGet, "https://perf.example.com/api", nil)
+	u, err := proxy(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "proxy.example.com:3128", u.Host)
+
+	req, _ = http.NewRequest(http.MethodGet, "http://perf.edp.svc:8080/api", nil)
+	u, err = proxy(req)
+	assert.NoError(t, err)
+	assert.Nil(t, u)
+}
+
+func TestBypassProxy(t *testing.T) {
+	assert.True(t, bypassProxy("localhost", []string{"localhost"}))
+	assert.True(t, bypassProxy("perf.example.com", []string{"example.com"}))
+	assert.True(t, bypassProxy("perf.example.com", []string{".example.com"}))
+	assert.True(t, bypassProxy("anything", []string{"*"}))
+	assert.False(t, bypassProxy("perf.example.com", []string{"other.com", ""}))
+	assert.False(t, bypassProxy("notexample.com", []string{"example.com"}))
+}
+
+func TestGetTransport_ShouldKeepDefaultTimeouts(t *testing.T) {
+	ps := createTransportPerfServer(&v1alpha1.PerfServerTransport{Insecure: true})
+	defer EvictTransport(ps.Namespace, ps.Name)
+
+	tr, err := GetTransport(fake.NewFakeClient(), ps)
+	assert.NoError(t, err)
+
+	def := http.DefaultTransport.(*http.Transport)
+	assert.Equal(t, def.TLSHandshakeTimeout, tr.(*http.Transport).TLSHandshakeTimeout)
+	assert.Equal(t, def.IdleConnTimeout, tr.(*http.Transport).IdleConnTimeout)
+	assert.True(t, tr.(*http.Transport).TLSClientConfig.InsecureSkipVerify)
+}
+
+func TestGetTransport_ShouldReuseTransportUntilInputsChange(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	caBundle := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}))
+	cm := &coreV1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Data: map[string]string{
+			defaultCaBundleKey: caBundle,
+		},
+	}
+	c := fake.NewFakeClient(cm)
+	ps := createTransportPerfServer(&v1alpha1.PerfServerTransport{CaBundleName: fakeName})
+	defer EvictTransport(ps.Namespace, ps.Name)
+
+	first, err := GetTransport(c, ps)
+	assert.NoError(t, err)
+	second, err := GetTransport(c, ps)
+	assert.NoError(t, err)
+	assert.True(t, first == second)
+
+	ps.Spec.Transport.ProxyUrl = "http://proxy.example.com:3128"
+	third, err := GetTransport(c, ps)
+	assert.NoError(t, err)
+	assert.False(t, second == third)
+
+	cm.Data[defaultCaBundleKey] = "# rotated\n" + caBundle
+	assert.NoError(t, c.Update(context.TODO(), cm))
+	fourth, err := GetTransport(c, ps)
+	assert.NoError(t, err)
+	assert.False(t, third == fourth)
+}
+
+func TestEvictTransport(t *testing.T) {
+	ps := createTransportPerfServer(&v1alpha1.PerfServerTransport{Insecure: true})
+
+	first, err := GetTransport(fake.NewFakeClient(), ps)
+	assert.NoError(t, err)
+
+	EvictTransport(ps.Namespace, ps.Name)
+	second, err := GetTransport(fake.NewFakeClient(), ps)
+	assert.NoError(t, err)
+	assert.False(t, first == second)
+	EvictTransport(ps.Namespace, ps.Name)
+}
@@ -0,0 +1,131 @@
+package sonar
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"gopkg.in/resty.v1"
+	"strconv"
+	"strings"
+)
+
+type SonarClient interface {
+	GetProjectKeys(keys []string) ([]string, error)
+	GetProjectKeysByPrefix(prefix string) ([]string, error)
+	GetProjectKeysByTags(tags []string) ([]string, error)
+}
+
+type SonarClientAdapter struct {
+	client resty.Client
+}
+
+// the max page size of SonarQube Web API
+const maxPageSize = 500
+
+func NewSonarRestClient(apiUrl, username, password string) SonarClientAdapter {
+	cl := resty.New().
+		SetHostURL(apiUrl).
+		SetBasicAuth(username, password)
+	return SonarClientAdapter{client: *cl}
+}
+
+// GetProjectKeys returns the keys of the given projects that exist in SonarQube.
+func (c SonarClientAdapter) GetProjectKeys(keys []string) ([]string, error) {
+	var res []string
+	for i := 0; i < len(keys); i += maxPageSize {
+		end := i + maxPageSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		found, err := c.searchProjects(keys[i:end])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, found...)
+	}
+	return res, nil
+}
+
+func (c SonarClientAdapter) searchProjects(keys []string) ([]string, error) {
+	sr, err := c.search("/api/projects/search", map[string]string{
+		"projects": strings.Join(keys, ","),
+		"ps":       strconv.Itoa(maxPageSize),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sr.keys(), nil
+}
+
+// GetProjectKeysByPrefix returns the keys of the projects starting with the prefix.
+func (c SonarClientAdapter) GetProjectKeysByPrefix(prefix string) ([]string, error) {
+	keys, err := c.searchAllPages("/api/projects/search", map[string]string{"q": prefix})
+	if err != nil {
+		return nil, err
+	}
+	var res []string
+	for _, k := range keys {
+		if strings.HasPrefix(k, prefix) {
+			res = append(res, k)
+		}
+	}
+	return res, nil
+}
+
+// GetProjectKeysByTags returns the keys of the projects having any of the tags.
+func (c SonarClientAdapter) GetProjectKeysByTags(tags []string) ([]string, error) {
+	return c.searchAllPages("/api/components/search_projects", map[string]string{
+		"filter": fmt.Sprintf("tags IN (%v)", strings.Join(tags, ", ")),
+	})
+}
+
+func (c SonarClientAdapter) searchAllPages(resource string, params map[string]string) ([]string, error) {
+	var res []string
+	for page := 1; ; page++ {
+		params["p"] = strconv.Itoa(page)
+		params["ps"] = strconv.Itoa(maxPageSize)
+		sr, err := c.search(resource, params)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, sr.keys()...)
+		if len(sr.Components) == 0 || page*maxPageSize >= sr.Paging.Total {
+			return res, nil
+		}
+	}
+}
+
+type searchResult struct {
+	Paging struct {
+		Total int `json:"total"`
+	} `json:"paging"`
+	Components []struct {
+		Key string `json:"key"`
+	} `json:"components"`
+}
+
+func (r searchResult) keys() []string {
+	res := make([]string, 0, len(r.Components))
+	for _, c := range r.Components {
+		res = append(res, c.Key)
+	}
+	return res
+}
+
+func (c SonarClientAdapter) search(resource string, params map[string]string) (*searchResult, error) {
+	resp, err := c.client.R().
+		SetQueryParams(params).
+		Get(resource)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't search projects in SonarQube")
+	}
+	if resp.IsError() {
+		return nil, errors.Errorf("couldn't search projects in SonarQube. Status - %v", resp.StatusCode())
+	}
+
+	var sr searchResult
+	if err := json.Unmarshal(resp.Body(), &sr); err != nil {
+		return nil, errors.Wrap(err, "couldn't parse SonarQube projects")
+	}
+	return &sr, nil
+}
@@ -0,0 +1,68 @@
+package sonar
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSonarClientAdapter_GetProjectKeys(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/projects/search", r.URL.Path)
+		assert.Equal(t, "app,missing", r.URL.Query().Get("projects"))
+		u, _, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "user", u)
+		_, _ = w.Write([]byte(`{"components":[{"key":"app"}]}`))
+	}))
+	defer srv.Close()
+
+	keys, err := NewSonarRestClient(srv.URL, "user", "pwd").GetProjectKeys([]string{"app", "missing"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"app"}, keys)
+}
+
+func TestSonarClientAdapter_GetProjectKeysErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	_, err := NewSonarRestClient(srv.URL, "user", "pwd").GetProjectKeys([]string{"app"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "403")
+}
+
+func TestSonarClientAdapter_GetProjectKeysByPrefix(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/projects/search", r.URL.Path)
+		assert.Equal(t, "edp", r.URL.Query().Get("q"))
+		switch r.URL.Query().Get("p") {
+		case "1":
+			_, _ = w.Write([]byte(`{"paging":{"total":501},"components":[{"key":"edp-app"},{"key":"old-edp"}]}`))
+		case "2":
+			_, _ = w.Write([]byte(`{"paging":{"total":501},"components":[{"key":"edp-api"}]}`))
+		default:
+			t.Errorf("unexpected page %v", r.URL.Query().Get("p"))
+		}
+	}))
+	defer srv.Close()
+
+	keys, err := NewSonarRestClient(srv.URL, "user", "pwd").GetProjectKeysByPrefix("edp")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"edp-app", "edp-api"}, keys)
+}
+
+func TestSonarClientAdapter_GetProjectKeysByTags(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/components/search_projects", r.URL.Path)
+		assert.Equal(t, "tags IN (edp, java)", r.URL.Query().Get("filter"))
+		_, _ = w.Write([]byte(`{"paging":{"total":2},"components":[{"key":"app"},{"key":"api"}]}`))
+	}))
+	defer srv.Close()
+
+	keys, err := NewSonarRestClient(srv.URL, "user", "pwd").GetProjectKeysByTags([]string{"edp", "java"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"app", "api"}, keys)
+}
@@ -1,13 +1,20 @@
 package controller
 
 import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/controller/clusterperfserver"
+	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfdatasourceazuredevops"
+	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfdatasourcebitbucket"
 	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfdatasourcegitlab"
 	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfdatasourcejenkins"
 	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfdatasourcesonar"
+	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfdatasourcetekton"
+	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfdorametrics"
+	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfreport"
 	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfserver"
 )
 
 func init() {
 	AddToManagerFuncs = append(AddToManagerFuncs, perfserver.Add, perfdatasourcejenkins.Add,
-		perfdatasourcesonar.Add, perfdatasourcegitlab.Add)
+		perfdatasourcesonar.Add, perfdatasourcegitlab.Add, perfdatasourcebitbucket.Add, perfdatasourceazuredevops.Add,
+		perfdatasourcetekton.Add, perfdorametrics.Add, perfreport.Add, clusterperfserver.Add)
 }
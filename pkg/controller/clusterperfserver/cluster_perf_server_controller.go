@@ -0,0 +1,185 @@
+package clusterperfserver
+
+import (
+	"context"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/luminate"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf"
+	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfserver/chain"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/cluster"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/watch"
+	coreV1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"time"
+)
+
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcileClusterPerfServer{
+		client: mgr.GetClient(),
+	}
+}
+
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	o, err := watch.GetControllerOptions(mgr, r)
+	if err != nil {
+		return err
+	}
+
+	c, err := controller.New("clusterperfserver-controller", mgr, o)
+	if err != nil {
+		return err
+	}
+
+	p := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldObject := e.ObjectOld.(*v1alpha1.ClusterPerfServer)
+			newObject := e.ObjectNew.(*v1alpha1.ClusterPerfServer)
+			return !reflect.DeepEqual(oldObject.Spec, newObject.Spec)
+		},
+	}
+
+	err = c.Watch(&source.Kind{Type: &v1alpha1.ClusterPerfServer{}}, &handler.EnqueueRequestForObject{}, p)
+	if err != nil {
+		return err
+	}
+
+	cl := mgr.GetClient()
+	if err = c.Watch(&source.Kind{Type: &coreV1.ConfigMap{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(func(o handler.MapObject) []reconcile.Request {
+			return getDependentClusterPerfServers(cl, o.Meta.GetNamespace(), func(d perf.AuthDependencies) []string {
+				return d.ConfigMaps
+			}, o.Meta.GetName())
+		}),
+	}); err != nil {
+		return err
+	}
+
+	if err = c.Watch(&source.Kind{Type: &coreV1.Secret{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(func(o handler.MapObject) []reconcile.Request {
+			return getDependentClusterPerfServers(cl, o.Meta.GetNamespace(), func(d perf.AuthDependencies) []string {
+				return d.Secrets
+			}, o.Meta.GetName())
+		}),
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// getDependentClusterPerfServers returns requests for the ClusterPerfServers whose authentication is built
+// from the named object. Only the objects of the operator namespace are taken into account.
+func getDependentClusterPerfServers(c client.Client, namespace string, deps func(d perf.AuthDependencies) []string,
+	name string) []reconcile.Request {
+	operatorNamespace, err := cluster.GetOperatorNamespace()
+	if err != nil || namespace != operatorNamespace {
+		return nil
+	}
+
+	list := &v1alpha1.ClusterPerfServerList{}
+	if err := c.List(context.TODO(), &client.ListOptions{}, list); err != nil {
+		log.Error(err, "couldn't list ClusterPerfServers")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range list.Items {
+		cps := &list.Items[i]
+		for _, n := range deps(perf.GetAuthDependencies(c, cluster.ToPerfServer(cps, operatorNamespace))) {
+			if n == name {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+					Name: cps.Name,
+				}})
+				break
+			}
+		}
+	}
+	return requests
+}
+
+var (
+	_   reconcile.Reconciler = &ReconcileClusterPerfServer{}
+	log                      = logf.Log.WithName("controller_cluster_perf_server")
+)
+
+type ReconcileClusterPerfServer struct {
+	client client.Client
+}
+
+func (r *ReconcileClusterPerfServer) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	rl := log.WithValues("Request.Name", request.Name)
+	rl.Info("Reconciling ClusterPerfServer")
+
+	operatorNamespace, err := cluster.GetOperatorNamespace()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	i := &v1alpha1.ClusterPerfServer{}
+	if err := r.client.Get(context.TODO(), request.NamespacedName, i); err != nil {
+		if k8serrors.IsNotFound(err) {
+			perf.EvictTransport(operatorNamespace, request.Name)
+			luminate.EvictTokenSource(operatorNamespace, request.Name)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	defer r.updateStatus(i)
+
+	ps := cluster.ToPerfServer(i, operatorNamespace)
+	pc, err := r.newPerfRestClient(ps)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	err = chain.CreateClusterChain(pc).ServeRequest(ps)
+	i.Status = ps.Status
+	if err != nil {
+		i.Status.DetailedMessage = err.Error()
+		log.Error(err, "couldn't handle ClusterPerfServer CR")
+		return reconcile.Result{RequeueAfter: 5 * time.Minute}, nil
+	}
+
+	rl.Info("Reconciling ClusterPerfServer has been finished")
+	return reconcile.Result{}, nil
+}
+
+func (r ReconcileClusterPerfServer) updateStatus(server *v1alpha1.ClusterPerfServer) {
+	server.Status.LastTimeUpdated = time.Now()
+	if err := r.client.Status().Update(context.TODO(), server); err != nil {
+		_ = r.client.Update(context.TODO(), server)
+	}
+}
+
+func (r ReconcileClusterPerfServer) newPerfRestClient(ps *v1alpha1.PerfServer) (*perf.PerfClientAdapter, error) {
+	transport, err := perf.GetTransport(r.client, ps)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := perf.GetAuthProvider(r.client, ps, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	perfClient, err := perf.NewRestClient(ps.Spec.ApiUrl, provider, transport)
+	if err != nil {
+		return nil, err
+	}
+	return perfClient, nil
+}
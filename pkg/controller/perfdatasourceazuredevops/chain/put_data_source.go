@@ -0,0 +1,153 @@
+package chain
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf"
+	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfdatasourceazuredevops/chain/handler"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/command"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/dto"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/cluster"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/datasource"
+	coreV1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type PutDataSource struct {
+	next       handler.PerfDataSourceAzureDevOpsHandler
+	client     client.Client
+	perfClient perf.PerfClient
+}
+
+const (
+	azureDevOpsSecretName = "azure-devops-access-token"
+)
+
+func (h PutDataSource) ServeRequest(dataSource *v1alpha1.PerfDataSourceAzureDevOps) error {
+	log.Info("start creating/updating Azure DevOps data source in PERF", "name", dataSource.Name)
+	if err := h.tryToPutDataSource(dataSource); err != nil {
+		setFailedStatus(dataSource)
+		return err
+	}
+	setSuccessStatus(dataSource)
+	log.Info("PERF DataSourceAzureDevOps has been created.", "name", dataSource.Name)
+	return nil
+}
+
+func setFailedStatus(ds *v1alpha1.PerfDataSourceAzureDevOps) {
+	ds.Status.Status = "error"
+}
+
+func setSuccessStatus(ds *v1alpha1.PerfDataSourceAzureDevOps) {
+	ds.Status.Status = "created"
+}
+
+func (h PutDataSource) tryToPutDataSource(dsResource *v1alpha1.PerfDataSourceAzureDevOps) error {
+	ps, err := cluster.GetPerfServerCr(h.client, dsResource.Spec.PerfServerKind,
+		dsResource.Spec.PerfServerName, dsResource.Namespace)
+	if err != nil {
+		return err
+	}
+
+	projectId, err := cluster.GetPerfProjectId(ps)
+	if err != nil {
+		return err
+	}
+
+	nodeId, err := perf.ResolveNode(h.perfClient, projectId, dsResource.Spec.PerfNode, dsResource.Spec.CreatePerfNode)
+	if err != nil {
+		return err
+	}
+
+	dsReq, err := h.perfClient.GetProjectDataSource(nodeId, getDataSourceKey(dsResource))
+	if err != nil {
+		return err
+	}
+
+	if dsReq != nil {
+		log.Info("PERF Azure DevOps data source already exists. try to update.", "type", dsResource.Spec.Type)
+		if err := h.tryToActivateDataSource(dsReq); err != nil {
+			return err
+		}
+		return h.tryToUpdateDataSource(dsResource, dsReq)
+	}
+
+	return h.createDataSource(nodeId, dsResource)
+}
+
+func (h PutDataSource) tryToActivateDataSource(dsReq *dto.DataSource) error {
+	if dsReq.Active {
+		log.Info("PERF data source is already activated.", "name", dsReq.Name)
+		return nil
+	}
+	return h.perfClient.ActivateDataSource(dsReq.Id)
+}
+
+func (h PutDataSource) tryToUpdateDataSource(dsResource *v1alpha1.PerfDataSourceAzureDevOps, dsReq *dto.DataSource) error {
+	current, err := command.DecodeAzureDevOpsConfig(dsReq)
+	if err != nil {
+		return err
+	}
+	s, err := h.getSecret(dsResource)
+	if err != nil {
+		return err
+	}
+
+	branchDiff := getBranchConfigDifference(dsResource, current)
+	repoDiff := getRepositoryConfigDifference(dsResource, current)
+	if branchDiff == nil && repoDiff == nil && !hasOptionsDifference(dsResource, current, string(s.Data["username"])) {
+		log.Info("nothing to update in Azure DevOps data source", "name", dsReq.Name)
+		return nil
+	}
+
+	dsCommand := command.GetAzureDevOpsDsUpdateCommand(dsReq, current, command.DataSourceRepositoryConfigDto{
+		Type:         dsReq.Type,
+		ApiUrl:       dsResource.Spec.Config.Url,
+		Scope:        dsResource.Spec.Config.Project,
+		Username:     string(s.Data["username"]),
+		Token:        string(s.Data["token"]),
+		Repositories: repoDiff,
+		Branches:     branchDiff,
+	})
+	return h.perfClient.UpdateDataSource(dsCommand)
+}
+
+func getBranchConfigDifference(dsResource *v1alpha1.PerfDataSourceAzureDevOps, current command.DataSourceAzureDevOpsConfig) []string {
+	return datasource.GetMissingElementsInDataSource(dsResource.Spec.Config.Branches, current.Branches)
+}
+
+func getRepositoryConfigDifference(dsResource *v1alpha1.PerfDataSourceAzureDevOps, current command.DataSourceAzureDevOpsConfig) []string {
+	return datasource.GetMissingElementsInDataSource(dsResource.Spec.Config.Repositories, current.Repositories)
+}
+
+// hasOptionsDifference reports if the url, the project or the username differ from PERF ones.
+func hasOptionsDifference(dsResource *v1alpha1.PerfDataSourceAzureDevOps, current command.DataSourceAzureDevOpsConfig,
+	username string) bool {
+	c := dsResource.Spec.Config
+	return !perf.EqualUrls(current.Url, c.Url) || current.Project != c.Project || current.Username != username
+}
+
+func (h PutDataSource) createDataSource(nodeId int, dsResource *v1alpha1.PerfDataSourceAzureDevOps) error {
+	s, err := h.getSecret(dsResource)
+	if err != nil {
+		return err
+	}
+
+	dsCommand := command.GetAzureDevOpsDsCreateCommand(dsResource, string(s.Data["username"]), string(s.Data["token"]))
+	return h.perfClient.CreateDataSource(nodeId, dsCommand)
+}
+
+func (h PutDataSource) getSecret(dsResource *v1alpha1.PerfDataSourceAzureDevOps) (*coreV1.Secret, error) {
+	name := dsResource.Spec.Config.CredentialName
+	if name == "" {
+		name = azureDevOpsSecretName
+	}
+	return cluster.GetSecret(h.client, name, dsResource.Namespace)
+}
+
+func getDataSourceKey(ds *v1alpha1.PerfDataSourceAzureDevOps) perf.DataSourceKey {
+	return perf.DataSourceKey{
+		Type: ds.Spec.Type,
+		Name: ds.Spec.Name,
+		Url:  ds.Spec.Config.Url,
+	}
+}
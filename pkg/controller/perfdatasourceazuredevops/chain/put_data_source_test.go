@@ -0,0 +1,274 @@
+package chain
+
+import (
+	"errors"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf/mock"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/command"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/dto"
+	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+const (
+	azureDevOpsDsType = "AZURE_DEVOPS"
+	fakeProject       = "fake-project"
+)
+
+func createAzureDevOpsDataSource() *v1alpha1.PerfDataSourceAzureDevOps {
+	return &v1alpha1.PerfDataSourceAzureDevOps{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace: fakeNamespace,
+			OwnerReferences: []v1.OwnerReference{
+				{
+					Kind: "PerfServer",
+					Name: fakeName,
+				},
+			},
+		},
+		Spec: v1alpha1.PerfDataSourceAzureDevOpsSpec{
+			Type: azureDevOpsDsType,
+			Config: v1alpha1.DataSourceAzureDevOpsConfig{
+				Project:      fakeProject,
+				Repositories: []string{"repo1"},
+				Branches:     []string{"master"},
+				Url:          fakeName,
+			},
+		},
+	}
+}
+
+func createPerfServer() *v1alpha1.PerfServer {
+	return &v1alpha1.PerfServer{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Spec: v1alpha1.PerfServerSpec{
+			ProjectName: fakeName,
+		},
+		Status: v1alpha1.PerfServerStatus{
+			ProjectId: fakeProjectId,
+		},
+	}
+}
+
+func createSecret(name string) *coreV1.Secret {
+	return &coreV1.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      name,
+			Namespace: fakeNamespace,
+		},
+		Data: map[string][]byte{
+			"username": []byte("fake"),
+			"token":    []byte("fake"),
+		},
+	}
+}
+
+func TestPutDataSource_ShouldUpdateAzureDevOpsDataSourceWithActivating(t *testing.T) {
+	pds := createAzureDevOpsDataSource()
+	ps := createPerfServer()
+	objs := []runtime.Object{
+		pds, ps, createSecret(azureDevOpsSecretName),
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, pds, ps)
+
+	mPerfCl := new(mock.MockPerfClient)
+	ch := PutDataSource{
+		client:     fake.NewFakeClient(objs...),
+		perfClient: mPerfCl,
+	}
+
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).
+		Return(&dto.DataSource{
+			Active: false,
+			Type:   azureDevOpsDsType,
+			Config: map[string]interface{}{
+				"repositories": []interface{}{"repo2"},
+				"branches":     []interface{}{"develop"},
+			},
+		}, nil)
+
+	mPerfCl.On("UpdateDataSource", command.DataSourceCommand{
+		Type: azureDevOpsDsType,
+		Config: command.DataSourceAzureDevOpsConfig{
+			Project:      fakeProject,
+			Repositories: []string{"repo2", "repo1"},
+			Url:          fakeName,
+			Branches:     []string{"develop", "master"},
+			Username:     "fake",
+			Token:        "fake",
+		},
+	}).Return(nil)
+
+	mPerfCl.On("ActivateDataSource", 0).Return(nil)
+
+	assert.NoError(t, ch.ServeRequest(pds))
+	assert.Equal(t, "created", pds.Status.Status)
+}
+
+func TestPutDataSource_ShouldNotUpdateAzureDevOpsDataSourceWithoutChanges(t *testing.T) {
+	pds := createAzureDevOpsDataSource()
+	ps := createPerfServer()
+	objs := []runtime.Object{
+		pds, ps, createSecret(azureDevOpsSecretName),
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, pds, ps)
+
+	mPerfCl := new(mock.MockPerfClient)
+	ch := PutDataSource{
+		client:     fake.NewFakeClient(objs...),
+		perfClient: mPerfCl,
+	}
+
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).
+		Return(&dto.DataSource{
+			Active: true,
+			Type:   azureDevOpsDsType,
+			Config: map[string]interface{}{
+				"project":      fakeProject,
+				"url":          fakeName + "/",
+				"username":     "fake",
+				"repositories": []interface{}{"repo1"},
+				"branches":     []interface{}{"master"},
+			},
+		}, nil)
+
+	assert.NoError(t, ch.ServeRequest(pds))
+	assert.Equal(t, "created", pds.Status.Status)
+}
+
+func TestPutDataSource_ShouldUpdateAzureDevOpsDataSourceOptions(t *testing.T) {
+	pds := createAzureDevOpsDataSource()
+	ps := createPerfServer()
+	objs := []runtime.Object{
+		pds, ps, createSecret(azureDevOpsSecretName),
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, pds, ps)
+
+	mPerfCl := new(mock.MockPerfClient)
+	ch := PutDataSource{
+		client:     fake.NewFakeClient(objs...),
+		perfClient: mPerfCl,
+	}
+
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).
+		Return(&dto.DataSource{
+			Active: true,
+			Type:   azureDevOpsDsType,
+			Config: map[string]interface{}{
+				"project":      "old-project",
+				"url":          fakeName,
+				"username":     "old",
+				"repositories": []interface{}{"repo1"},
+				"branches":     []interface{}{"master"},
+			},
+		}, nil)
+
+	mPerfCl.On("UpdateDataSource", command.DataSourceCommand{
+		Type: azureDevOpsDsType,
+		Config: command.DataSourceAzureDevOpsConfig{
+			Project:      fakeProject,
+			Repositories: []string{"repo1"},
+			Url:          fakeName,
+			Branches:     []string{"master"},
+			Username:     "fake",
+			Token:        "fake",
+		},
+	}).Return(nil)
+
+	assert.NoError(t, ch.ServeRequest(pds))
+	mPerfCl.AssertCalled(t, "UpdateDataSource", testifyMock.Anything)
+}
+
+func TestPutDataSource_ShouldCreateAzureDevOpsDataSourceWithCustomCredentials(t *testing.T) {
+	pds := createAzureDevOpsDataSource()
+	pds.Spec.Config.CredentialName = "custom-secret"
+	ps := createPerfServer()
+	objs := []runtime.Object{
+		pds, ps, createSecret("custom-secret"),
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, pds, ps)
+
+	mPerfCl := new(mock.MockPerfClient)
+	ch := PutDataSource{
+		client:     fake.NewFakeClient(objs...),
+		perfClient: mPerfCl,
+	}
+
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).Return(nil, nil)
+
+	mPerfCl.On("CreateDataSource", fakeProjectId, command.DataSourceCommand{
+		Type: azureDevOpsDsType,
+		Config: command.DataSourceAzureDevOpsConfig{
+			Project:      fakeProject,
+			Repositories: []string{"repo1"},
+			Branches:     []string{"master"},
+			Url:          fakeName,
+			Username:     "fake",
+			Token:        "fake",
+		},
+	}).Return(nil)
+
+	assert.NoError(t, ch.ServeRequest(pds))
+	assert.Equal(t, "created", pds.Status.Status)
+}
+
+func TestPutDataSource_ShouldFailBecauseOfMissingCredentials(t *testing.T) {
+	pds := createAzureDevOpsDataSource()
+	ps := createPerfServer()
+	objs := []runtime.Object{
+		pds, ps,
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, pds, ps)
+
+	mPerfCl := new(mock.MockPerfClient)
+	ch := PutDataSource{
+		client:     fake.NewFakeClient(objs...),
+		perfClient: mPerfCl,
+	}
+
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).Return(nil, nil)
+
+	assert.Error(t, ch.ServeRequest(pds))
+	assert.Equal(t, "error", pds.Status.Status)
+}
+
+func TestPutDataSource_ShouldNotFindAzureDevOpsDataSourceInPERF(t *testing.T) {
+	pds := createAzureDevOpsDataSource()
+	ps := createPerfServer()
+	objs := []runtime.Object{
+		ps,
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, ps)
+
+	mPerfCl := new(mock.MockPerfClient)
+	ch := PutDataSource{
+		client:     fake.NewFakeClient(objs...),
+		perfClient: mPerfCl,
+	}
+
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).Return(nil, errors.New("failed"))
+
+	assert.Error(t, ch.ServeRequest(pds))
+	assert.Equal(t, "error", pds.Status.Status)
+}
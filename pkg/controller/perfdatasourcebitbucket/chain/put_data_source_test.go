@@ -0,0 +1,274 @@
+package chain
+
+import (
+	"errors"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf/mock"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/command"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/dto"
+	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+const (
+	bitbucketDsType = "BITBUCKET"
+	fakeWorkspace   = "fake-workspace"
+)
+
+func createBitbucketDataSource() *v1alpha1.PerfDataSourceBitbucket {
+	return &v1alpha1.PerfDataSourceBitbucket{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace: fakeNamespace,
+			OwnerReferences: []v1.OwnerReference{
+				{
+					Kind: "PerfServer",
+					Name: fakeName,
+				},
+			},
+		},
+		Spec: v1alpha1.PerfDataSourceBitbucketSpec{
+			Type: bitbucketDsType,
+			Config: v1alpha1.DataSourceBitbucketConfig{
+				Workspace:    fakeWorkspace,
+				Repositories: []string{"repo1"},
+				Branches:     []string{"master"},
+				Url:          fakeName,
+			},
+		},
+	}
+}
+
+func createPerfServer() *v1alpha1.PerfServer {
+	return &v1alpha1.PerfServer{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Spec: v1alpha1.PerfServerSpec{
+			ProjectName: fakeName,
+		},
+		Status: v1alpha1.PerfServerStatus{
+			ProjectId: fakeProjectId,
+		},
+	}
+}
+
+func createSecret(name string) *coreV1.Secret {
+	return &coreV1.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      name,
+			Namespace: fakeNamespace,
+		},
+		Data: map[string][]byte{
+			"username": []byte("fake"),
+			"token":    []byte("fake"),
+		},
+	}
+}
+
+func TestPutDataSource_ShouldUpdateBitbucketDataSourceWithActivating(t *testing.T) {
+	pds := createBitbucketDataSource()
+	ps := createPerfServer()
+	objs := []runtime.Object{
+		pds, ps, createSecret(bitbucketSecretName),
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, pds, ps)
+
+	mPerfCl := new(mock.MockPerfClient)
+	ch := PutDataSource{
+		client:     fake.NewFakeClient(objs...),
+		perfClient: mPerfCl,
+	}
+
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).
+		Return(&dto.DataSource{
+			Active: false,
+			Type:   bitbucketDsType,
+			Config: map[string]interface{}{
+				"repositories": []interface{}{"repo2"},
+				"branches":     []interface{}{"develop"},
+			},
+		}, nil)
+
+	mPerfCl.On("UpdateDataSource", command.DataSourceCommand{
+		Type: bitbucketDsType,
+		Config: command.DataSourceBitbucketConfig{
+			Workspace:    fakeWorkspace,
+			Repositories: []string{"repo2", "repo1"},
+			Url:          fakeName,
+			Branches:     []string{"develop", "master"},
+			Username:     "fake",
+			Token:        "fake",
+		},
+	}).Return(nil)
+
+	mPerfCl.On("ActivateDataSource", 0).Return(nil)
+
+	assert.NoError(t, ch.ServeRequest(pds))
+	assert.Equal(t, "created", pds.Status.Status)
+}
+
+func TestPutDataSource_ShouldNotUpdateBitbucketDataSourceWithoutChanges(t *testing.T) {
+	pds := createBitbucketDataSource()
+	ps := createPerfServer()
+	objs := []runtime.Object{
+		pds, ps, createSecret(bitbucketSecretName),
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, pds, ps)
+
+	mPerfCl := new(mock.MockPerfClient)
+	ch := PutDataSource{
+		client:     fake.NewFakeClient(objs...),
+		perfClient: mPerfCl,
+	}
+
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).
+		Return(&dto.DataSource{
+			Active: true,
+			Type:   bitbucketDsType,
+			Config: map[string]interface{}{
+				"workspace":    fakeWorkspace,
+				"url":          fakeName + "/",
+				"username":     "fake",
+				"repositories": []interface{}{"repo1"},
+				"branches":     []interface{}{"master"},
+			},
+		}, nil)
+
+	assert.NoError(t, ch.ServeRequest(pds))
+	assert.Equal(t, "created", pds.Status.Status)
+}
+
+func TestPutDataSource_ShouldUpdateBitbucketDataSourceOptions(t *testing.T) {
+	pds := createBitbucketDataSource()
+	ps := createPerfServer()
+	objs := []runtime.Object{
+		pds, ps, createSecret(bitbucketSecretName),
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, pds, ps)
+
+	mPerfCl := new(mock.MockPerfClient)
+	ch := PutDataSource{
+		client:     fake.NewFakeClient(objs...),
+		perfClient: mPerfCl,
+	}
+
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).
+		Return(&dto.DataSource{
+			Active: true,
+			Type:   bitbucketDsType,
+			Config: map[string]interface{}{
+				"workspace":    "old-workspace",
+				"url":          fakeName,
+				"username":     "old",
+				"repositories": []interface{}{"repo1"},
+				"branches":     []interface{}{"master"},
+			},
+		}, nil)
+
+	mPerfCl.On("UpdateDataSource", command.DataSourceCommand{
+		Type: bitbucketDsType,
+		Config: command.DataSourceBitbucketConfig{
+			Workspace:    fakeWorkspace,
+			Repositories: []string{"repo1"},
+			Url:          fakeName,
+			Branches:     []string{"master"},
+			Username:     "fake",
+			Token:        "fake",
+		},
+	}).Return(nil)
+
+	assert.NoError(t, ch.ServeRequest(pds))
+	mPerfCl.AssertCalled(t, "UpdateDataSource", testifyMock.Anything)
+}
+
+func TestPutDataSource_ShouldCreateBitbucketDataSourceWithCustomCredentials(t *testing.T) {
+	pds := createBitbucketDataSource()
+	pds.Spec.Config.CredentialName = "custom-secret"
+	ps := createPerfServer()
+	objs := []runtime.Object{
+		pds, ps, createSecret("custom-secret"),
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, pds, ps)
+
+	mPerfCl := new(mock.MockPerfClient)
+	ch := PutDataSource{
+		client:     fake.NewFakeClient(objs...),
+		perfClient: mPerfCl,
+	}
+
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).Return(nil, nil)
+
+	mPerfCl.On("CreateDataSource", fakeProjectId, command.DataSourceCommand{
+		Type: bitbucketDsType,
+		Config: command.DataSourceBitbucketConfig{
+			Workspace:    fakeWorkspace,
+			Repositories: []string{"repo1"},
+			Branches:     []string{"master"},
+			Url:          fakeName,
+			Username:     "fake",
+			Token:        "fake",
+		},
+	}).Return(nil)
+
+	assert.NoError(t, ch.ServeRequest(pds))
+	assert.Equal(t, "created", pds.Status.Status)
+}
+
+func TestPutDataSource_ShouldFailBecauseOfMissingCredentials(t *testing.T) {
+	pds := createBitbucketDataSource()
+	ps := createPerfServer()
+	objs := []runtime.Object{
+		pds, ps,
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, pds, ps)
+
+	mPerfCl := new(mock.MockPerfClient)
+	ch := PutDataSource{
+		client:     fake.NewFakeClient(objs...),
+		perfClient: mPerfCl,
+	}
+
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).Return(nil, nil)
+
+	assert.Error(t, ch.ServeRequest(pds))
+	assert.Equal(t, "error", pds.Status.Status)
+}
+
+func TestPutDataSource_ShouldNotFindBitbucketDataSourceInPERF(t *testing.T) {
+	pds := createBitbucketDataSource()
+	ps := createPerfServer()
+	objs := []runtime.Object{
+		ps,
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, ps)
+
+	mPerfCl := new(mock.MockPerfClient)
+	ch := PutDataSource{
+		client:     fake.NewFakeClient(objs...),
+		perfClient: mPerfCl,
+	}
+
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).Return(nil, errors.New("failed"))
+
+	assert.Error(t, ch.ServeRequest(pds))
+	assert.Equal(t, "error", pds.Status.Status)
+}
@@ -0,0 +1,146 @@
+package perfdatasourcebitbucket
+
+import (
+	"context"
+	v1alpha12 "github.com/epmd-edp/codebase-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf"
+	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfdatasourcebitbucket/chain"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/cluster"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/watch"
+	"github.com/pkg/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"time"
+)
+
+var (
+	log = logf.Log.WithName("controller_perf_data_source_bitbucket")
+)
+
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	scheme := mgr.GetScheme()
+	addKnownTypes(scheme)
+	return &ReconcilePerfDataSourceBitbucket{
+		client: mgr.GetClient(),
+		scheme: scheme,
+	}
+}
+
+func addKnownTypes(scheme *runtime.Scheme) {
+	schemeGroupVersion := schema.GroupVersion{Group: "v2.edp.epam.com", Version: "v1alpha1"}
+	scheme.AddKnownTypes(schemeGroupVersion,
+		&v1alpha12.Codebase{},
+		&v1alpha12.CodebaseList{},
+	)
+	metav1.AddToGroupVersion(scheme, schemeGroupVersion)
+}
+
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	o, err := watch.GetControllerOptions(mgr, r)
+	if err != nil {
+		return err
+	}
+
+	c, err := controller.New("perfdatasourcebitbucket-controller", mgr, o)
+	if err != nil {
+		return err
+	}
+
+	p := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldSpec := e.ObjectOld.(*v1alpha1.PerfDataSourceBitbucket).Spec
+			newSpec := e.ObjectNew.(*v1alpha1.PerfDataSourceBitbucket).Spec
+			return !reflect.DeepEqual(oldSpec, newSpec)
+		},
+	}
+
+	if err = c.Watch(&source.Kind{Type: &v1alpha1.PerfDataSourceBitbucket{}}, &handler.EnqueueRequestForObject{}, p); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcilePerfDataSourceBitbucket{}
+
+type ReconcilePerfDataSourceBitbucket struct {
+	client client.Client
+	scheme *runtime.Scheme
+}
+
+func (r *ReconcilePerfDataSourceBitbucket) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	rl := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	rl.V(2).Info("Reconciling PerfDataSourceBitbucket")
+
+	i := &v1alpha1.PerfDataSourceBitbucket{}
+	if err := r.client.Get(context.TODO(), request.NamespacedName, i); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	defer r.updateStatus(i)
+
+	ps, err := cluster.GetPerfServerCr(r.client, i.Spec.PerfServerKind, i.Spec.PerfServerName, i.Namespace)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "couldn't get %v PerfServer from cluster", i.Spec.PerfServerName)
+	}
+
+	if !ps.Status.Available {
+		log.Info("Perf instance is unavailable. skip creating/updating data source in PERF", "name", ps.Name)
+		return reconcile.Result{RequeueAfter: 2 * time.Minute}, nil
+	}
+
+	pc, err := r.newPerfRestClient(ps)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err := chain.CreateDefChain(r.client, r.scheme, pc).ServeRequest(i); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	rl.Info("Reconciling PerfDataSourceBitbucket has been finished")
+	return reconcile.Result{}, nil
+}
+
+func (r ReconcilePerfDataSourceBitbucket) updateStatus(ds *v1alpha1.PerfDataSourceBitbucket) {
+	if err := r.client.Status().Update(context.TODO(), ds); err != nil {
+		_ = r.client.Update(context.TODO(), ds)
+	}
+}
+
+func (r ReconcilePerfDataSourceBitbucket) newPerfRestClient(ps *v1alpha1.PerfServer) (*perf.PerfClientAdapter, error) {
+	transport, err := perf.GetTransport(r.client, ps)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := perf.GetAuthProvider(r.client, ps, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	perfClient, err := perf.NewRestClient(ps.Spec.ApiUrl, provider, transport)
+	if err != nil {
+		return nil, err
+	}
+	return perfClient, nil
+}
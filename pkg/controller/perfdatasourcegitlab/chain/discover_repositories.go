@@ -0,0 +1,60 @@
+package chain
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/gitlab"
+	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfdatasourcegitlab/chain/handler"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/command"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/cluster"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/datasource"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type DiscoverRepositories struct {
+	next   handler.PerfDataSourceGitLabHandler
+	client client.Client
+}
+
+func (h DiscoverRepositories) ServeRequest(dataSource *v1alpha1.PerfDataSourceGitLab) error {
+	if dataSource.Spec.Discovery == nil {
+		dataSource.Status.DiscoveredRepositories = nil
+		return nextServeOrNil(h.next, dataSource)
+	}
+
+	log.Info("start discovering GitLab repositories", "name", dataSource.Name)
+	if err := h.discoverRepositories(dataSource); err != nil {
+		setFailedStatus(dataSource)
+		return errors.Wrapf(err, "couldn't discover GitLab repositories of %v data source", dataSource.Name)
+	}
+	log.Info("GitLab repositories have been discovered.", "name", dataSource.Name,
+		"count", len(dataSource.Status.DiscoveredRepositories))
+	return nextServeOrNil(h.next, dataSource)
+}
+
+func (h DiscoverRepositories) discoverRepositories(ds *v1alpha1.PerfDataSourceGitLab) error {
+	s, err := cluster.GetSecret(h.client, getCredentialName(ds), ds.Namespace)
+	if err != nil {
+		return err
+	}
+
+	gc := gitlab.NewGitLabRestClient(command.GetGitLabUrl(ds), string(s.Data["password"]))
+	repos, err := findRepositories(gc, ds.Spec.Discovery)
+	if err != nil {
+		return err
+	}
+	ds.Status.DiscoveredRepositories = repos
+	return nil
+}
+
+func findRepositories(gc gitlab.GitLabClient, d *v1alpha1.GitLabRepositoryDiscovery) ([]string, error) {
+	var res []string
+	for _, g := range d.Groups {
+		repos, err := gc.GetGroupProjects(g)
+		if err != nil {
+			return nil, err
+		}
+		res = datasource.MergeEntries(res, repos)
+	}
+	return datasource.FilterEntries(res, d.Include, d.Exclude)
+}
@@ -0,0 +1,81 @@
+package chain
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func createGitLabGroupServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/api/v4/groups/group/projects":
+			_, _ = w.Write([]byte(`[{"path_with_namespace":"group/app"},{"path_with_namespace":"group/app-api"},` +
+				`{"path_with_namespace":"group/sandbox/app"}]`))
+		case "/api/v4/groups/edp%2Fbackend/projects":
+			_, _ = w.Write([]byte(`[{"path_with_namespace":"edp/backend/service"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func createDiscoverRepositories() DiscoverRepositories {
+	return DiscoverRepositories{
+		client: createValidateRepositories(nil).client,
+	}
+}
+
+func TestDiscoverRepositories_ShouldSkipDiscoveryWithoutSpec(t *testing.T) {
+	ds := createValidatedDataSource("http://gitlab.invalid", "")
+	ds.Status.DiscoveredRepositories = []string{"group/old"}
+
+	assert.NoError(t, DiscoverRepositories{}.ServeRequest(ds))
+	assert.Nil(t, ds.Status.DiscoveredRepositories)
+	assert.Equal(t, []string{"group/app", "group/missing"}, getRepositories(ds))
+}
+
+func TestDiscoverRepositories_ShouldFindGroupRepositoriesWithFilters(t *testing.T) {
+	srv := createGitLabGroupServer()
+	defer srv.Close()
+	ds := createValidatedDataSource(srv.URL, "")
+	ds.Spec.Discovery = &v1alpha1.GitLabRepositoryDiscovery{
+		Groups:  []string{"group", "edp/backend"},
+		Include: []string{"group/*", "regex:^edp/"},
+		Exclude: []string{"*/*-api"},
+	}
+
+	assert.NoError(t, createDiscoverRepositories().ServeRequest(ds))
+	assert.Equal(t, []string{"group/app", "edp/backend/service"}, ds.Status.DiscoveredRepositories)
+	assert.Equal(t, []string{"group/app", "group/missing", "edp/backend/service"}, getRepositories(ds))
+}
+
+func TestDiscoverRepositories_ShouldFailOnMissingGroup(t *testing.T) {
+	srv := createGitLabGroupServer()
+	defer srv.Close()
+	ds := createValidatedDataSource(srv.URL, "")
+	ds.Spec.Discovery = &v1alpha1.GitLabRepositoryDiscovery{
+		Groups: []string{"missing"},
+	}
+
+	err := createDiscoverRepositories().ServeRequest(ds)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "404")
+	assert.Equal(t, "error", ds.Status.Status)
+}
+
+func TestDiscoverRepositories_ShouldFailOnInvalidPattern(t *testing.T) {
+	srv := createGitLabGroupServer()
+	defer srv.Close()
+	ds := createValidatedDataSource(srv.URL, "")
+	ds.Spec.Discovery = &v1alpha1.GitLabRepositoryDiscovery{
+		Groups:  []string{"group"},
+		Include: []string{"regex:("},
+	}
+
+	err := createDiscoverRepositories().ServeRequest(ds)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "couldn't parse regex:( pattern")
+}
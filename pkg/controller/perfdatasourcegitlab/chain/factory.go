@@ -5,19 +5,31 @@ import (
 	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf"
 	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfdatasourcegitlab/chain/handler"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
 )
 
 var log = logf.Log.WithName("perf_data_source_gitlab_handler")
 
-func CreateDefChain(client client.Client, scheme *runtime.Scheme, perfClient perf.PerfClient) handler.PerfDataSourceGitLabHandler {
+func CreateDefChain(client client.Client, scheme *runtime.Scheme, perfClient perf.PerfClient,
+	recorder record.EventRecorder) handler.PerfDataSourceGitLabHandler {
 	return PutOwnerReference{
 		client: client,
 		scheme: scheme,
-		next: PutDataSource{
-			client:     client,
-			perfClient: perfClient,
+		next: ResolveConnection{
+			client: client,
+			next: DiscoverRepositories{
+				client: client,
+				next: ValidateRepositories{
+					client:   client,
+					recorder: recorder,
+					next: PutDataSource{
+						client:     client,
+						perfClient: perfClient,
+					},
+				},
+			},
 		},
 	}
 }
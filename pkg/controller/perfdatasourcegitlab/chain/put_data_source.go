@@ -7,7 +7,6 @@ import (
 	"github.com/epmd-edp/perf-operator/v2/pkg/model/command"
 	"github.com/epmd-edp/perf-operator/v2/pkg/model/dto"
 	"github.com/epmd-edp/perf-operator/v2/pkg/util/cluster"
-	"github.com/epmd-edp/perf-operator/v2/pkg/util/common"
 	"github.com/epmd-edp/perf-operator/v2/pkg/util/datasource"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -29,6 +28,7 @@ func (h PutDataSource) ServeRequest(dataSource *v1alpha1.PerfDataSourceGitLab) e
 		return err
 	}
 	setSuccessStatus(dataSource)
+	dataSource.Status.PreviousUrl = ""
 	log.Info("PERF DataSourceGitLab has been created.", "name", dataSource.Name)
 	return nil
 }
@@ -42,75 +42,129 @@ func setSuccessStatus(ds *v1alpha1.PerfDataSourceGitLab) {
 }
 
 func (h PutDataSource) tryToPutDataSource(dsResource *v1alpha1.PerfDataSourceGitLab) error {
-	ps, err := cluster.GetPerfServerCr(h.client, dsResource.Spec.PerfServerName, dsResource.Namespace)
+	ps, err := cluster.GetPerfServerCr(h.client, dsResource.Spec.PerfServerKind,
+		dsResource.Spec.PerfServerName, dsResource.Namespace)
 	if err != nil {
 		return err
 	}
 
-	dsReq, err := h.perfClient.GetProjectDataSource(ps.Spec.ProjectName, dsResource.Spec.Type)
+	projectId, err := cluster.GetPerfProjectId(ps)
+	if err != nil {
+		return err
+	}
+
+	nodeId, err := perf.ResolveNode(h.perfClient, projectId, dsResource.Spec.PerfNode, dsResource.Spec.CreatePerfNode)
+	if err != nil {
+		return err
+	}
+
+	dsReq, err := h.getDataSource(nodeId, dsResource)
 	if err != nil {
 		return err
 	}
 
 	if dsReq != nil {
 		log.Info("PERF GitLab data source already exists. try to update.", "type", dsResource.Spec.Type)
-		if err := h.tryToActivateDataSource(dsReq, ps); err != nil {
+		if err := h.tryToActivateDataSource(dsReq); err != nil {
 			return err
 		}
 		return h.tryToUpdateDataSource(dsResource, dsReq)
 	}
 
-	return h.createDataSource(ps.Spec.ProjectName, dsResource)
+	return h.createDataSource(nodeId, dsResource)
+}
+
+// getDataSource looks the data source up by the url PERF still holds if the url has changed,
+// so it's updated instead of a new one being created.
+func (h PutDataSource) getDataSource(nodeId int, dsResource *v1alpha1.PerfDataSourceGitLab) (*dto.DataSource, error) {
+	if dsResource.Status.PreviousUrl != "" {
+		key := getDataSourceKey(dsResource)
+		key.Url = dsResource.Status.PreviousUrl
+		dsReq, err := h.perfClient.GetProjectDataSource(nodeId, key)
+		if err != nil || dsReq != nil {
+			return dsReq, err
+		}
+	}
+	return h.perfClient.GetProjectDataSource(nodeId, getDataSourceKey(dsResource))
 }
 
-func (h PutDataSource) tryToActivateDataSource(dsReq *dto.DataSource, ps *v1alpha1.PerfServer) error {
+func (h PutDataSource) tryToActivateDataSource(dsReq *dto.DataSource) error {
 	if dsReq.Active {
 		log.Info("PERF data source is already activated.", "name", dsReq.Name)
 		return nil
 	}
-	return h.perfClient.ActivateDataSource(ps.Spec.ProjectName, dsReq.Id)
+	return h.perfClient.ActivateDataSource(dsReq.Id)
 }
 
 func (h PutDataSource) tryToUpdateDataSource(dsResource *v1alpha1.PerfDataSourceGitLab, dsReq *dto.DataSource) error {
-	branchDiff := getBranchConfigDifference(dsResource, dsReq)
-	repoDiff := getRepositoryConfigDifference(dsResource, dsReq)
-	if branchDiff == nil && repoDiff == nil {
+	current, err := command.DecodeGitLabConfig(dsReq)
+	if err != nil {
+		return err
+	}
+	branchDiff := getBranchConfigDifference(dsResource, current)
+	repoDiff := getRepositoryConfigDifference(dsResource, current)
+	if branchDiff == nil && repoDiff == nil && !hasOptionsDifference(dsResource, current) {
 		log.Info("nothing to update in GitLab data source", "name", dsReq.Name)
 		return nil
 	}
 
-	s, err := cluster.GetSecret(h.client, gitLabSecretName, dsResource.Namespace)
+	s, err := cluster.GetSecret(h.client, getCredentialName(dsResource), dsResource.Namespace)
 	if err != nil {
 		return err
 	}
 
-	dsCommand := command.GetGitLabDsUpdateCommand(dsReq, command.DataSourceGitLabConfigDto{
-		Type:         dsReq.Type,
-		ApiUrl:       dsResource.Spec.Config.Url,
-		Username:     string(s.Data["username"]),
-		Password:     string(s.Data["password"]),
-		Repositories: repoDiff,
-		Branches:     branchDiff,
+	dsCommand := command.GetGitLabDsUpdateCommand(dsReq, current, command.DataSourceGitLabConfigDto{
+		Type:           dsReq.Type,
+		ApiUrl:         command.GetGitLabUrl(dsResource),
+		InstanceId:     dsResource.Spec.Config.InstanceId,
+		WithMembership: dsResource.Spec.Config.WithMembership,
+		AllPublic:      dsResource.Spec.Config.AllPublic,
+		AllBranches:    dsResource.Spec.Config.AllBranches,
+		Username:       string(s.Data["username"]),
+		Password:       string(s.Data["password"]),
+		Repositories:   repoDiff,
+		Branches:       branchDiff,
 	})
 	return h.perfClient.UpdateDataSource(dsCommand)
 }
 
-func getBranchConfigDifference(dsResource *v1alpha1.PerfDataSourceGitLab, dsReq *dto.DataSource) []string {
-	conf := common.ConvertToStringArray(dsReq.Config["branches"])
-	return datasource.GetMissingElementsInDataSource(dsResource.Spec.Config.Branches, conf)
+func getBranchConfigDifference(dsResource *v1alpha1.PerfDataSourceGitLab, current command.DataSourceGitlabConfig) []string {
+	return datasource.GetMissingElementsInDataSource(getBranches(dsResource), current.Branches)
+}
+
+func getRepositoryConfigDifference(dsResource *v1alpha1.PerfDataSourceGitLab, current command.DataSourceGitlabConfig) []string {
+	return datasource.GetMissingElementsInDataSource(getRepositories(dsResource), current.Repositories)
 }
 
-func getRepositoryConfigDifference(dsResource *v1alpha1.PerfDataSourceGitLab, dsReq *dto.DataSource) []string {
-	conf := common.ConvertToStringArray(dsReq.Config["repositories"])
-	return datasource.GetMissingElementsInDataSource(dsResource.Spec.Config.Repositories, conf)
+// hasOptionsDifference reports if the url or the options set in the spec differ from PERF ones,
+// the omitted options are kept.
+func hasOptionsDifference(dsResource *v1alpha1.PerfDataSourceGitLab, current command.DataSourceGitlabConfig) bool {
+	c := dsResource.Spec.Config
+	return !perf.EqualUrls(current.Url, command.GetGitLabUrl(dsResource)) ||
+		(c.InstanceId != "" && current.InstanceId != c.InstanceId) ||
+		flagDiffers(c.WithMembership, current.WithMembership) ||
+		flagDiffers(c.AllPublic, current.AllPublic) ||
+		flagDiffers(c.AllBranches, current.AllBranches)
 }
 
-func (h PutDataSource) createDataSource(projectName string, dsResource *v1alpha1.PerfDataSourceGitLab) error {
-	s, err := cluster.GetSecret(h.client, gitLabSecretName, dsResource.Namespace)
+func flagDiffers(flag *bool, current bool) bool {
+	return flag != nil && *flag != current
+}
+
+func (h PutDataSource) createDataSource(nodeId int, dsResource *v1alpha1.PerfDataSourceGitLab) error {
+	s, err := cluster.GetSecret(h.client, getCredentialName(dsResource), dsResource.Namespace)
 	if err != nil {
 		return err
 	}
 
-	dsCommand := command.GetGitLabDsCreateCommand(dsResource, string(s.Data["username"]), string(s.Data["password"]))
-	return h.perfClient.CreateDataSource(projectName, dsCommand)
+	dsCommand := command.GetGitLabDsCreateCommand(dsResource, getRepositories(dsResource), getBranches(dsResource), string(s.Data["username"]), string(s.Data["password"]))
+	return h.perfClient.CreateDataSource(nodeId, dsCommand)
+}
+
+func getDataSourceKey(ds *v1alpha1.PerfDataSourceGitLab) perf.DataSourceKey {
+	return perf.DataSourceKey{
+		Type: ds.Spec.Type,
+		Name: ds.Spec.Name,
+		Url:  command.GetGitLabUrl(ds),
+	}
 }
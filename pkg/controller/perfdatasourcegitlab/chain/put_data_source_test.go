@@ -7,6 +7,7 @@ import (
 	"github.com/epmd-edp/perf-operator/v2/pkg/model/command"
 	"github.com/epmd-edp/perf-operator/v2/pkg/model/dto"
 	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
 	coreV1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -49,6 +50,9 @@ func TestPutDataSource_ShouldUpdateGitLabDataSourceWithoutActivating(t *testing.
 		Spec: v1alpha1.PerfServerSpec{
 			ProjectName: fakeName,
 		},
+		Status: v1alpha1.PerfServerStatus{
+			ProjectId: fakeProjectId,
+		},
 	}
 
 	sec := &coreV1.Secret{
@@ -75,13 +79,14 @@ func TestPutDataSource_ShouldUpdateGitLabDataSourceWithoutActivating(t *testing.
 		perfClient: mPerfCl,
 	}
 
-	mPerfCl.On("GetProjectDataSource", fakeName, gitlabDsType).
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).
 		Return(&dto.DataSource{
 			Active: true,
 			Type:   gitlabDsType,
 			Config: map[string]interface{}{
 				"repositories": []interface{}{"repo2"},
 				"branches":     []interface{}{"develop"},
+				"instanceId":   fakeName,
 			},
 		}, nil)
 
@@ -133,6 +138,9 @@ func TestPutDataSource_ShouldUpdateGitLabDataSourceWithActivating(t *testing.T)
 		Spec: v1alpha1.PerfServerSpec{
 			ProjectName: fakeName,
 		},
+		Status: v1alpha1.PerfServerStatus{
+			ProjectId: fakeProjectId,
+		},
 	}
 
 	sec := &coreV1.Secret{
@@ -159,13 +167,14 @@ func TestPutDataSource_ShouldUpdateGitLabDataSourceWithActivating(t *testing.T)
 		perfClient: mPerfCl,
 	}
 
-	mPerfCl.On("GetProjectDataSource", fakeName, gitlabDsType).
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).
 		Return(&dto.DataSource{
 			Active: false,
 			Type:   gitlabDsType,
 			Config: map[string]interface{}{
 				"repositories": []interface{}{"repo2"},
 				"branches":     []interface{}{"develop"},
+				"instanceId":   fakeName,
 			},
 		}, nil)
 
@@ -184,7 +193,7 @@ func TestPutDataSource_ShouldUpdateGitLabDataSourceWithActivating(t *testing.T)
 		},
 	}).Return(nil)
 
-	mPerfCl.On("ActivateDataSource", fakeName, 0).Return(nil)
+	mPerfCl.On("ActivateDataSource", 0).Return(nil)
 
 	assert.NoError(t, ch.ServeRequest(pds))
 	assert.Equal(t, "created", pds.Status.Status)
@@ -219,6 +228,9 @@ func TestPutDataSource_ShouldCreateGitLabDataSource(t *testing.T) {
 		Spec: v1alpha1.PerfServerSpec{
 			ProjectName: fakeName,
 		},
+		Status: v1alpha1.PerfServerStatus{
+			ProjectId: fakeProjectId,
+		},
 	}
 
 	sec := &coreV1.Secret{
@@ -245,9 +257,9 @@ func TestPutDataSource_ShouldCreateGitLabDataSource(t *testing.T) {
 		perfClient: mPerfCl,
 	}
 
-	mPerfCl.On("GetProjectDataSource", fakeName, gitlabDsType).Return(nil, nil)
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).Return(nil, nil)
 
-	mPerfCl.On("CreateDataSource", fakeName, command.DataSourceCommand{
+	mPerfCl.On("CreateDataSource", fakeProjectId, command.DataSourceCommand{
 		Type: gitlabDsType,
 		Config: command.DataSourceGitlabConfig{
 			Repositories: []string{"repo1"},
@@ -272,6 +284,9 @@ func TestPutDataSource_ShouldNotFindDataSourceInPERF(t *testing.T) {
 		Spec: v1alpha1.PerfServerSpec{
 			ProjectName: fakeName,
 		},
+		Status: v1alpha1.PerfServerStatus{
+			ProjectId: fakeProjectId,
+		},
 	}
 
 	objs := []runtime.Object{
@@ -287,7 +302,7 @@ func TestPutDataSource_ShouldNotFindDataSourceInPERF(t *testing.T) {
 		perfClient: mPerfCl,
 	}
 
-	mPerfCl.On("GetProjectDataSource", fakeName, "").Return(nil, errors.New("failed"))
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).Return(nil, errors.New("failed"))
 
 	pds := &v1alpha1.PerfDataSourceGitLab{
 		ObjectMeta: v1.ObjectMeta{
@@ -334,6 +349,9 @@ func TestPutDataSource_ShouldNotActivateDataSource(t *testing.T) {
 		Spec: v1alpha1.PerfServerSpec{
 			ProjectName: fakeName,
 		},
+		Status: v1alpha1.PerfServerStatus{
+			ProjectId: fakeProjectId,
+		},
 	}
 
 	sec := &coreV1.Secret{
@@ -360,13 +378,14 @@ func TestPutDataSource_ShouldNotActivateDataSource(t *testing.T) {
 		perfClient: mPerfCl,
 	}
 
-	mPerfCl.On("GetProjectDataSource", fakeName, gitlabDsType).
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).
 		Return(&dto.DataSource{
 			Active: false,
 			Type:   gitlabDsType,
 			Config: map[string]interface{}{
 				"repositories": []interface{}{"repo2"},
 				"branches":     []interface{}{"develop"},
+				"instanceId":   fakeName,
 			},
 		}, nil)
 
@@ -385,7 +404,7 @@ func TestPutDataSource_ShouldNotActivateDataSource(t *testing.T) {
 		},
 	}).Return(nil)
 
-	mPerfCl.On("ActivateDataSource", fakeName, 0).Return(errors.New("failed"))
+	mPerfCl.On("ActivateDataSource", 0).Return(errors.New("failed"))
 
 	assert.Error(t, ch.ServeRequest(pds))
 	assert.Equal(t, "error", pds.Status.Status)
@@ -420,6 +439,9 @@ func TestPutDataSource_ShouldNotUpdateDataSourceBecauseOfMissingNewParameters(t
 		Spec: v1alpha1.PerfServerSpec{
 			ProjectName: fakeName,
 		},
+		Status: v1alpha1.PerfServerStatus{
+			ProjectId: fakeProjectId,
+		},
 	}
 
 	sec := &coreV1.Secret{
@@ -446,13 +468,14 @@ func TestPutDataSource_ShouldNotUpdateDataSourceBecauseOfMissingNewParameters(t
 		perfClient: mPerfCl,
 	}
 
-	mPerfCl.On("GetProjectDataSource", fakeName, gitlabDsType).
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).
 		Return(&dto.DataSource{
 			Active: true,
 			Type:   gitlabDsType,
 			Config: map[string]interface{}{
 				"repositories": []interface{}{"repo2"},
 				"branches":     []interface{}{"develop"},
+				"instanceId":   fakeName,
 			},
 		}, nil)
 
@@ -473,3 +496,142 @@ func TestPutDataSource_ShouldNotUpdateDataSourceBecauseOfMissingNewParameters(t
 
 	assert.NoError(t, ch.ServeRequest(pds))
 }
+
+func TestPutDataSource_ShouldSyncGitLabOptions(t *testing.T) {
+	enabled := true
+	pds := &v1alpha1.PerfDataSourceGitLab{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Spec: v1alpha1.PerfDataSourceGitLabSpec{
+			Type: gitlabDsType,
+			Config: v1alpha1.DataSourceGitLabConfig{
+				Repositories: []string{"repo1"},
+				Url:          fakeName,
+				InstanceId:   "gitlab-main",
+				AllPublic:    &enabled,
+				AllBranches:  &enabled,
+			},
+			PerfServerName: fakeName,
+		},
+	}
+
+	ps := &v1alpha1.PerfServer{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Status: v1alpha1.PerfServerStatus{
+			ProjectId: fakeProjectId,
+		},
+	}
+
+	sec := &coreV1.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      gitLabSecretName,
+			Namespace: fakeNamespace,
+		},
+		Data: map[string][]byte{
+			"username": []byte("fake"),
+			"password": []byte("fake"),
+		},
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, pds, ps)
+
+	mPerfCl := new(mock.MockPerfClient)
+	ch := PutDataSource{
+		client:     fake.NewFakeClient([]runtime.Object{pds, ps, sec}...),
+		perfClient: mPerfCl,
+	}
+
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).
+		Return(&dto.DataSource{
+			Id:     2,
+			Active: true,
+			Type:   gitlabDsType,
+			Config: map[string]interface{}{
+				"repositories":   []interface{}{"repo1"},
+				"branches":       []interface{}{},
+				"url":            fakeName,
+				"instanceId":     fakeName,
+				"withMembership": true,
+			},
+		}, nil)
+
+	mPerfCl.On("UpdateDataSource", command.DataSourceCommand{
+		Id:   2,
+		Type: gitlabDsType,
+		Config: command.DataSourceGitlabConfig{
+			Repositories:   []string{"repo1"},
+			Url:            fakeName,
+			InstanceId:     "gitlab-main",
+			WithMembership: true,
+			AllPublic:      true,
+			AllBranches:    true,
+			Branches:       []string{},
+			Username:       "fake",
+			Password:       "fake",
+		},
+	}).Return(nil)
+
+	assert.NoError(t, ch.ServeRequest(pds))
+	assert.Equal(t, "created", pds.Status.Status)
+}
+
+func TestPutDataSource_ShouldKeepGitLabOptionsOmittedInSpec(t *testing.T) {
+	pds := &v1alpha1.PerfDataSourceGitLab{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Spec: v1alpha1.PerfDataSourceGitLabSpec{
+			Type: gitlabDsType,
+			Config: v1alpha1.DataSourceGitLabConfig{
+				Repositories: []string{"repo1"},
+				Url:          fakeName,
+			},
+			PerfServerName: fakeName,
+		},
+	}
+
+	ps := &v1alpha1.PerfServer{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Status: v1alpha1.PerfServerStatus{
+			ProjectId: fakeProjectId,
+		},
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, pds, ps)
+
+	mPerfCl := new(mock.MockPerfClient)
+	ch := PutDataSource{
+		client:     fake.NewFakeClient([]runtime.Object{pds, ps}...),
+		perfClient: mPerfCl,
+	}
+
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).
+		Return(&dto.DataSource{
+			Id:     2,
+			Active: true,
+			Type:   gitlabDsType,
+			Config: map[string]interface{}{
+				"repositories":   []interface{}{"repo1"},
+				"branches":       []interface{}{"master"},
+				"url":            fakeName,
+				"instanceId":     "gitlab-main",
+				"withMembership": true,
+				"allBranches":    true,
+			},
+		}, nil)
+
+	assert.NoError(t, ch.ServeRequest(pds))
+	mPerfCl.AssertNotCalled(t, "UpdateDataSource", testifyMock.Anything)
+	assert.Equal(t, "created", pds.Status.Status)
+}
@@ -0,0 +1,120 @@
+package chain
+
+import (
+	edpApi "github.com/epmd-edp/edp-component-operator/pkg/apis/v1/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/command"
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func createUrlDataSource(url, component string) *v1alpha1.PerfDataSourceGitLab {
+	return &v1alpha1.PerfDataSourceGitLab{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Spec: v1alpha1.PerfDataSourceGitLabSpec{
+			Config: v1alpha1.DataSourceGitLabConfig{
+				Repositories: []string{"group/app"},
+				Url:          url,
+			},
+			EdpComponent: component,
+		},
+	}
+}
+
+func createResolveConnection() ResolveConnection {
+	comp := &edpApi.EDPComponent{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "gitlab",
+			Namespace: fakeNamespace,
+		},
+		Spec: edpApi.EDPComponentSpec{
+			Url: "https://gitlab.example.com",
+		},
+	}
+	ciComp := &edpApi.EDPComponent{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "ci-gitlab",
+			Namespace: fakeNamespace,
+		},
+		Spec: edpApi.EDPComponentSpec{
+			Url: "https://ci-gitlab.example.com",
+		},
+	}
+	ciSecret := &coreV1.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "ci-gitlab-admin-password",
+			Namespace: fakeNamespace,
+		},
+	}
+	scheme.Scheme.AddKnownTypes(v1.SchemeGroupVersion, comp)
+	return ResolveConnection{
+		client: fake.NewFakeClient(comp, ciComp, ciSecret),
+	}
+}
+
+func TestResolveConnection_ShouldPreferSpecUrl(t *testing.T) {
+	ds := createUrlDataSource("https://own.example.com", "gitlab")
+
+	assert.NoError(t, createResolveConnection().ServeRequest(ds))
+	assert.Equal(t, "https://own.example.com", ds.Status.Url)
+	assert.Equal(t, "https://own.example.com", command.GetGitLabUrl(ds))
+}
+
+func TestResolveConnection_ShouldTakeUrlFromEdpComponent(t *testing.T) {
+	ds := createUrlDataSource("", "gitlab")
+
+	assert.NoError(t, createResolveConnection().ServeRequest(ds))
+	assert.Equal(t, "https://gitlab.example.com", ds.Status.Url)
+	assert.Equal(t, "https://gitlab.example.com", command.GetGitLabUrl(ds))
+	assert.Empty(t, ds.Spec.Config.Url)
+	assert.Equal(t, gitLabSecretName, ds.Status.CredentialName)
+}
+
+func TestResolveConnection_ShouldTakeCredentialsOfEdpComponent(t *testing.T) {
+	ds := createUrlDataSource("", "ci-gitlab")
+
+	assert.NoError(t, createResolveConnection().ServeRequest(ds))
+	assert.Equal(t, "https://ci-gitlab.example.com", ds.Status.Url)
+	assert.Equal(t, "ci-gitlab-admin-password", getCredentialName(ds))
+}
+
+func TestResolveConnection_ShouldPreferSpecCredentials(t *testing.T) {
+	ds := createUrlDataSource("", "ci-gitlab")
+	ds.Spec.Config.CredentialName = "own-password"
+
+	assert.NoError(t, createResolveConnection().ServeRequest(ds))
+	assert.Equal(t, "own-password", ds.Status.CredentialName)
+}
+
+func TestResolveConnection_ShouldKeepUrlOfPerfOnChange(t *testing.T) {
+	ds := createUrlDataSource("", "gitlab")
+	ds.Status.Url = "https://old-gitlab.example.com"
+
+	assert.NoError(t, createResolveConnection().ServeRequest(ds))
+	assert.Equal(t, "https://gitlab.example.com", ds.Status.Url)
+	assert.Equal(t, "https://old-gitlab.example.com", ds.Status.PreviousUrl)
+}
+
+func TestResolveConnection_ShouldFailWithoutUrlAndEdpComponent(t *testing.T) {
+	ds := createUrlDataSource("", "")
+
+	err := createResolveConnection().ServeRequest(ds)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "neither url nor EDP component is set")
+	assert.Equal(t, "error", ds.Status.Status)
+}
+
+func TestResolveConnection_ShouldFailOnMissingEdpComponent(t *testing.T) {
+	ds := createUrlDataSource("", "missing")
+
+	err := createResolveConnection().ServeRequest(ds)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "couldn't get missing EDP component")
+}
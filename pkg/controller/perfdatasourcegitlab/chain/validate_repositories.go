@@ -0,0 +1,149 @@
+package chain
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/gitlab"
+	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfdatasourcegitlab/chain/handler"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/command"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/cluster"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/datasource"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"strings"
+)
+
+type ValidateRepositories struct {
+	next     handler.PerfDataSourceGitLabHandler
+	client   client.Client
+	recorder record.EventRecorder
+}
+
+const (
+	repositoriesValidCondition = "RepositoriesValid"
+	branchesValidCondition     = "BranchesValid"
+)
+
+func (h ValidateRepositories) ServeRequest(dataSource *v1alpha1.PerfDataSourceGitLab) error {
+	if dataSource.Spec.Validation == "" {
+		dataSource.Status.UnknownRepositories = nil
+		dataSource.Status.UnknownBranches = nil
+		dataSource.Status.Conditions = datasource.RemoveCondition(dataSource.Status.Conditions, repositoriesValidCondition)
+		dataSource.Status.Conditions = datasource.RemoveCondition(dataSource.Status.Conditions, branchesValidCondition)
+		return nextServeOrNil(h.next, dataSource)
+	}
+
+	log.Info("start validating GitLab repositories", "name", dataSource.Name, "policy", dataSource.Spec.Validation)
+	if err := h.validateRepositories(dataSource); err != nil {
+		setFailedStatus(dataSource)
+		return err
+	}
+	log.Info("GitLab repositories have been validated.", "name", dataSource.Name)
+	return nextServeOrNil(h.next, dataSource)
+}
+
+func (h ValidateRepositories) validateRepositories(ds *v1alpha1.PerfDataSourceGitLab) error {
+	s, err := cluster.GetSecret(h.client, getCredentialName(ds), ds.Namespace)
+	if err != nil {
+		return err
+	}
+
+	gc := gitlab.NewGitLabRestClient(command.GetGitLabUrl(ds), string(s.Data["password"]))
+	repos, found := checkRepositories(gc, ds.Spec.Config.Repositories)
+	ds.Status.UnknownRepositories = repos.Unknown
+	ds.Status.Conditions = datasource.ReportValidation(h.recorder, ds, ds.Status.Conditions, repos)
+
+	branches := datasource.ValidationResult{
+		ConditionType: branchesValidCondition,
+		Entries:       "GitLab branches",
+	}
+	switch {
+	case repos.Err != nil:
+		branches.Err = errors.New("GitLab repositories haven't been checked")
+	case ds.Spec.Config.AllBranches == nil || !*ds.Spec.Config.AllBranches:
+		branches = checkBranches(gc, found, ds.Spec.Config.Branches)
+	}
+	ds.Status.UnknownBranches = branches.Unknown
+	ds.Status.Conditions = datasource.ReportValidation(h.recorder, ds, ds.Status.Conditions, branches)
+
+	return datasource.EnforceValidation(ds.Spec.Validation, repos, branches)
+}
+
+// checkRepositories returns the validation result and the repositories that have been found.
+// All the repositories are checked, the errors of the ones that couldn't be checked are joined.
+func checkRepositories(gc gitlab.GitLabClient, repos []string) (datasource.ValidationResult, []string) {
+	r := datasource.ValidationResult{
+		ConditionType: repositoriesValidCondition,
+		Entries:       "GitLab repositories",
+	}
+	var found, msgs []string
+	for _, repo := range repos {
+		exists, err := gc.ProjectExists(repo)
+		if err != nil {
+			msgs = append(msgs, err.Error())
+			continue
+		}
+		if !exists {
+			r.Unknown = append(r.Unknown, repo)
+			continue
+		}
+		found = append(found, repo)
+	}
+	if len(msgs) > 0 {
+		r.Err = errors.New(strings.Join(msgs, "; "))
+	}
+	return r, found
+}
+
+// checkBranches treats a branch as unknown if none of the repositories has it.
+func checkBranches(gc gitlab.GitLabClient, repos, branches []string) datasource.ValidationResult {
+	r := datasource.ValidationResult{
+		ConditionType: branchesValidCondition,
+		Entries:       "GitLab branches",
+	}
+	if len(repos) == 0 {
+		return r
+	}
+	var msgs []string
+	for _, b := range branches {
+		exists, err := branchExists(gc, repos, b)
+		if err != nil {
+			msgs = append(msgs, err.Error())
+			continue
+		}
+		if !exists {
+			r.Unknown = append(r.Unknown, b)
+		}
+	}
+	if len(msgs) > 0 {
+		r.Err = errors.New(strings.Join(msgs, "; "))
+	}
+	return r
+}
+
+func branchExists(gc gitlab.GitLabClient, repos []string, branch string) (bool, error) {
+	for _, repo := range repos {
+		exists, err := gc.BranchExists(repo, branch)
+		if err != nil || exists {
+			return exists, err
+		}
+	}
+	return false, nil
+}
+
+// getRepositories returns the repositories along with the discovered ones to be sent to PERF.
+func getRepositories(ds *v1alpha1.PerfDataSourceGitLab) []string {
+	return datasource.MergeEntries(
+		datasource.GetValidEntries(ds.Spec.Validation, ds.Spec.Config.Repositories, ds.Status.UnknownRepositories),
+		ds.Status.DiscoveredRepositories)
+}
+
+func getBranches(ds *v1alpha1.PerfDataSourceGitLab) []string {
+	return datasource.GetValidEntries(ds.Spec.Validation, ds.Spec.Config.Branches, ds.Status.UnknownBranches)
+}
+
+// HasInvalidEntries reports if the last validation found unknown repositories or branches or couldn't check them.
+func HasInvalidEntries(ds *v1alpha1.PerfDataSourceGitLab) bool {
+	return datasource.HasFailedCondition(ds.Status.Conditions, repositoriesValidCondition) ||
+		datasource.HasFailedCondition(ds.Status.Conditions, branchesValidCondition)
+}
@@ -0,0 +1,122 @@
+package chain
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"net/http"
+	"net/http/httptest"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func createGitLabServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/api/v4/projects/group%2Fapp", "/api/v4/projects/group%2Fapp/repository/branches/master":
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func createValidateRepositories(recorder record.EventRecorder) ValidateRepositories {
+	return ValidateRepositories{
+		client: fake.NewFakeClient(&coreV1.Secret{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      gitLabSecretName,
+				Namespace: fakeNamespace,
+			},
+			Data: map[string][]byte{
+				"username": []byte("fake"),
+				"password": []byte("fake"),
+			},
+		}),
+		recorder: recorder,
+	}
+}
+
+func createValidatedDataSource(url, policy string) *v1alpha1.PerfDataSourceGitLab {
+	return &v1alpha1.PerfDataSourceGitLab{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Spec: v1alpha1.PerfDataSourceGitLabSpec{
+			Type: gitlabDsType,
+			Config: v1alpha1.DataSourceGitLabConfig{
+				Repositories: []string{"group/app", "group/missing"},
+				Branches:     []string{"master", "develop"},
+				Url:          url,
+			},
+			Validation: policy,
+		},
+	}
+}
+
+func TestValidateRepositories_ShouldReportUnknownEntries(t *testing.T) {
+	srv := createGitLabServer()
+	defer srv.Close()
+	ds := createValidatedDataSource(srv.URL, v1alpha1.ValidationSkip)
+	recorder := record.NewFakeRecorder(10)
+
+	assert.NoError(t, createValidateRepositories(recorder).ServeRequest(ds))
+	assert.Equal(t, []string{"group/missing"}, ds.Status.UnknownRepositories)
+	assert.Equal(t, []string{"develop"}, ds.Status.UnknownBranches)
+	assert.Len(t, ds.Status.Conditions, 2)
+	assert.Len(t, recorder.Events, 2)
+	assert.Equal(t, []string{"group/app"}, getRepositories(ds))
+	assert.Equal(t, []string{"master"}, getBranches(ds))
+	assert.True(t, HasInvalidEntries(ds))
+}
+
+func TestValidateRepositories_ShouldNotCheckBranchesWithAllBranches(t *testing.T) {
+	srv := createGitLabServer()
+	defer srv.Close()
+	ds := createValidatedDataSource(srv.URL, v1alpha1.ValidationBlock)
+	ds.Spec.Config.Repositories = []string{"group/app"}
+	allBranches := true
+	ds.Spec.Config.AllBranches = &allBranches
+
+	assert.NoError(t, createValidateRepositories(record.NewFakeRecorder(10)).ServeRequest(ds))
+	assert.Empty(t, ds.Status.UnknownBranches)
+	assert.False(t, HasInvalidEntries(ds))
+}
+
+func TestValidateRepositories_ShouldBlockOnUnknownEntries(t *testing.T) {
+	srv := createGitLabServer()
+	defer srv.Close()
+	ds := createValidatedDataSource(srv.URL, v1alpha1.ValidationBlock)
+
+	err := createValidateRepositories(record.NewFakeRecorder(10)).ServeRequest(ds)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "group/missing")
+	assert.Equal(t, "error", ds.Status.Status)
+}
+
+func TestValidateRepositories_ShouldReportAllRepositoryErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/api/v4/projects/group%2Fapp":
+			_, _ = w.Write([]byte(`{}`))
+		case "/api/v4/projects/group%2Fmissing":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+	ds := createValidatedDataSource(srv.URL, v1alpha1.ValidationSkip)
+	ds.Spec.Config.Repositories = []string{"group/broken", "group/app", "group/missing", "group/failed"}
+
+	assert.NoError(t, createValidateRepositories(record.NewFakeRecorder(10)).ServeRequest(ds))
+	assert.Equal(t, []string{"group/missing"}, ds.Status.UnknownRepositories)
+	assert.Len(t, ds.Status.Conditions, 2)
+	assert.Equal(t, v1alpha1.ConditionUnknown, ds.Status.Conditions[0].Status)
+	assert.Contains(t, ds.Status.Conditions[0].Message, "group/broken")
+	assert.Contains(t, ds.Status.Conditions[0].Message, "group/failed")
+	assert.True(t, HasInvalidEntries(ds))
+}
@@ -3,16 +3,20 @@ package perfdatasourcegitlab
 import (
 	"context"
 	v1alpha12 "github.com/epmd-edp/codebase-operator/v2/pkg/apis/edp/v1alpha1"
+	edpCompApi "github.com/epmd-edp/edp-component-operator/pkg/apis/v1/v1alpha1"
 	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
 	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf"
 	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfdatasourcegitlab/chain"
 	"github.com/epmd-edp/perf-operator/v2/pkg/util/cluster"
-	"github.com/epmd-edp/perf-operator/v2/pkg/util/common"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/datasource"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/watch"
 	"github.com/pkg/errors"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"reflect"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -30,6 +34,8 @@ var (
 	log = logf.Log.WithName("controller_perf_data_source_gitlab")
 )
 
+const validationRequeueDelay = 10 * time.Minute
+
 func Add(mgr manager.Manager) error {
 	return add(mgr, newReconciler(mgr))
 }
@@ -38,8 +44,9 @@ func newReconciler(mgr manager.Manager) reconcile.Reconciler {
 	scheme := mgr.GetScheme()
 	addKnownTypes(scheme)
 	return &ReconcilePerfDataSourceGitLab{
-		client: mgr.GetClient(),
-		scheme: scheme,
+		client:   mgr.GetClient(),
+		scheme:   scheme,
+		recorder: mgr.GetRecorder("perfdatasourcegitlab-controller"),
 	}
 }
 
@@ -53,16 +60,21 @@ func addKnownTypes(scheme *runtime.Scheme) {
 }
 
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
-	c, err := controller.New("perfdatasourcegitlab-controller", mgr, controller.Options{Reconciler: r})
+	o, err := watch.GetControllerOptions(mgr, r)
+	if err != nil {
+		return err
+	}
+
+	c, err := controller.New("perfdatasourcegitlab-controller", mgr, o)
 	if err != nil {
 		return err
 	}
 
 	p := predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
-			oldPds := e.ObjectOld.(*v1alpha1.PerfDataSourceGitLab).Spec.Config.Branches
-			newPds := e.ObjectNew.(*v1alpha1.PerfDataSourceGitLab).Spec.Config.Branches
-			return dataSourceUpdated(oldPds, newPds)
+			oldSpec := e.ObjectOld.(*v1alpha1.PerfDataSourceGitLab).Spec
+			newSpec := e.ObjectNew.(*v1alpha1.PerfDataSourceGitLab).Spec
+			return !reflect.DeepEqual(oldSpec, newSpec)
 		},
 	}
 
@@ -70,20 +82,52 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	cp := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return e.ObjectOld.(*edpCompApi.EDPComponent).Spec.Url != e.ObjectNew.(*edpCompApi.EDPComponent).Spec.Url
+		},
+	}
+
+	cl := mgr.GetClient()
+	if err = c.Watch(&source.Kind{Type: &edpCompApi.EDPComponent{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(func(o handler.MapObject) []reconcile.Request {
+			return getEdpComponentDataSources(cl, o.Meta.GetNamespace(), o.Meta.GetName())
+		}),
+	}, cp); err != nil {
+		return err
+	}
+
 	return nil
 }
 
-func dataSourceUpdated(old, new []string) bool {
-	common.SortArray(old)
-	common.SortArray(new)
-	return !reflect.DeepEqual(old, new)
+// getEdpComponentDataSources returns requests for the data sources whose url or credentials are resolved
+// from the named EDPComponent.
+func getEdpComponentDataSources(c client.Client, namespace, name string) []reconcile.Request {
+	list := &v1alpha1.PerfDataSourceGitLabList{}
+	if err := c.List(context.TODO(), &client.ListOptions{Namespace: namespace}, list); err != nil {
+		log.Error(err, "couldn't list GitLab data sources", "namespace", namespace)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, ds := range list.Items {
+		if ds.Spec.EdpComponent == name &&
+			(ds.Spec.Config.Url == "" || ds.Spec.Config.CredentialName == "") {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+				Namespace: ds.Namespace,
+				Name:      ds.Name,
+			}})
+		}
+	}
+	return requests
 }
 
 var _ reconcile.Reconciler = &ReconcilePerfDataSourceGitLab{}
 
 type ReconcilePerfDataSourceGitLab struct {
-	client client.Client
-	scheme *runtime.Scheme
+	client   client.Client
+	scheme   *runtime.Scheme
+	recorder record.EventRecorder
 }
 
 func (r *ReconcilePerfDataSourceGitLab) Reconcile(request reconcile.Request) (reconcile.Result, error) {
@@ -99,7 +143,12 @@ func (r *ReconcilePerfDataSourceGitLab) Reconcile(request reconcile.Request) (re
 	}
 	defer r.updateStatus(i)
 
-	ps, err := cluster.GetPerfServerCr(r.client, i.Spec.PerfServerName, i.Namespace)
+	discoveryInterval, err := getDiscoveryInterval(i)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	ps, err := cluster.GetPerfServerCr(r.client, i.Spec.PerfServerKind, i.Spec.PerfServerName, i.Namespace)
 	if err != nil {
 		return reconcile.Result{}, errors.Wrapf(err, "couldn't get %v PerfServer from cluster", i.Spec.PerfServerName)
 	}
@@ -109,32 +158,55 @@ func (r *ReconcilePerfDataSourceGitLab) Reconcile(request reconcile.Request) (re
 		return reconcile.Result{RequeueAfter: 2 * time.Minute}, nil
 	}
 
-	pc, err := r.newPerfRestClient(ps.Spec.ApiUrl, ps.Spec.CredentialName, ps.Namespace)
+	pc, err := r.newPerfRestClient(ps)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	if err := chain.CreateDefChain(r.client, r.scheme, pc).ServeRequest(i); err != nil {
+	if err := chain.CreateDefChain(r.client, r.scheme, pc, r.recorder).ServeRequest(i); err != nil {
 		return reconcile.Result{}, err
 	}
 
+	if chain.HasInvalidEntries(i) && (discoveryInterval == 0 || validationRequeueDelay < discoveryInterval) {
+		rl.Info("GitLab repositories will be validated again", "after", validationRequeueDelay)
+		return reconcile.Result{RequeueAfter: validationRequeueDelay}, nil
+	}
+
+	if discoveryInterval > 0 {
+		rl.Info("GitLab repositories will be discovered again", "after", discoveryInterval)
+		return reconcile.Result{RequeueAfter: discoveryInterval}, nil
+	}
+
 	rl.Info("Reconciling PerfDataSourceGitLab has been finished")
 	return reconcile.Result{}, nil
 }
 
+// getDiscoveryInterval returns zero if the discovery isn't enabled.
+func getDiscoveryInterval(ds *v1alpha1.PerfDataSourceGitLab) (time.Duration, error) {
+	if ds.Spec.Discovery == nil {
+		return 0, nil
+	}
+	return datasource.GetDiscoveryInterval(ds.Spec.Discovery.Interval)
+}
+
 func (r ReconcilePerfDataSourceGitLab) updateStatus(ds *v1alpha1.PerfDataSourceGitLab) {
 	if err := r.client.Status().Update(context.TODO(), ds); err != nil {
 		_ = r.client.Update(context.TODO(), ds)
 	}
 }
 
-func (r ReconcilePerfDataSourceGitLab) newPerfRestClient(url, secretName, namespace string) (*perf.PerfClientAdapter, error) {
-	credentials, err := perf.GetPerfCredentials(r.client, secretName, namespace)
+func (r ReconcilePerfDataSourceGitLab) newPerfRestClient(ps *v1alpha1.PerfServer) (*perf.PerfClientAdapter, error) {
+	transport, err := perf.GetTransport(r.client, ps)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := perf.GetAuthProvider(r.client, ps, transport)
 	if err != nil {
 		return nil, err
 	}
 
-	perfClient, err := perf.NewRestClient(url, credentials.Username, credentials.Password, credentials.LuminateToken)
+	perfClient, err := perf.NewRestClient(ps.Spec.ApiUrl, provider, transport)
 	if err != nil {
 		return nil, err
 	}
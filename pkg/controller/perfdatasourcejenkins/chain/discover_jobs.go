@@ -0,0 +1,64 @@
+package chain
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/jenkins"
+	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfdatasourcejenkins/chain/handler"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/command"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/cluster"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/datasource"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type DiscoverJobs struct {
+	next   handler.PerfDataSourceJenkinsHandler
+	client client.Client
+}
+
+func (h DiscoverJobs) ServeRequest(dataSource *v1alpha1.PerfDataSourceJenkins) error {
+	if dataSource.Spec.Discovery == nil {
+		dataSource.Status.DiscoveredJobNames = nil
+		return nextServeOrNil(h.next, dataSource)
+	}
+
+	log.Info("start discovering Jenkins jobs", "name", dataSource.Name)
+	if err := h.discoverJobs(dataSource); err != nil {
+		setFailedStatus(dataSource)
+		return errors.Wrapf(err, "couldn't discover Jenkins jobs of %v data source", dataSource.Name)
+	}
+	log.Info("Jenkins jobs have been discovered.", "name", dataSource.Name, "count", len(dataSource.Status.DiscoveredJobNames))
+	return nextServeOrNil(h.next, dataSource)
+}
+
+func (h DiscoverJobs) discoverJobs(ds *v1alpha1.PerfDataSourceJenkins) error {
+	s, err := cluster.GetSecret(h.client, getCredentialName(ds), ds.Namespace)
+	if err != nil {
+		return err
+	}
+
+	jc := jenkins.NewJenkinsRestClient(command.GetJenkinsUrl(ds), string(s.Data["username"]), string(s.Data["password"]))
+	jobs, err := findJobs(jc, ds.Spec.Discovery)
+	if err != nil {
+		return err
+	}
+	ds.Status.DiscoveredJobNames = jobs
+	return nil
+}
+
+func findJobs(jc jenkins.JenkinsClient, d *v1alpha1.JenkinsJobDiscovery) ([]string, error) {
+	folders := d.Folders
+	if len(folders) == 0 {
+		folders = []string{""}
+	}
+
+	var res []string
+	for _, f := range folders {
+		jobs, err := jc.GetJobs(f)
+		if err != nil {
+			return nil, err
+		}
+		res = datasource.MergeEntries(res, jobs)
+	}
+	return datasource.FilterEntries(res, d.Patterns, nil)
+}
@@ -0,0 +1,79 @@
+package chain
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func createJenkinsFolderServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/json":
+			_, _ = w.Write([]byte(`{"jobs":[{"name":"seed"},{"name":"fake-name","jobs":[]},{"name":"other","jobs":[]}]}`))
+		case "/job/fake-name/api/json":
+			_, _ = w.Write([]byte(`{"jobs":[{"name":"MASTER-Build-fake-name"},{"name":"MASTER-Code-review-fake-name"}]}`))
+		case "/job/other/api/json":
+			_, _ = w.Write([]byte(`{"jobs":[{"name":"MASTER-Build-other"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestDiscoverJobs_ShouldSkipDiscoveryWithoutSpec(t *testing.T) {
+	ds := createValidatedDataSource("http://jenkins.invalid", "", "/fake-name/MASTER-Build-fake-name")
+	ds.Status.DiscoveredJobNames = []string{"/old/job"}
+
+	assert.NoError(t, DiscoverJobs{}.ServeRequest(ds))
+	assert.Nil(t, ds.Status.DiscoveredJobNames)
+	assert.Equal(t, []string{"/fake-name/MASTER-Build-fake-name"}, getJobNames(ds))
+}
+
+func TestDiscoverJobs_ShouldFindJobsByFoldersAndPatterns(t *testing.T) {
+	srv := createJenkinsFolderServer()
+	defer srv.Close()
+	ds := createValidatedDataSource(srv.URL, "", "/fake-name/MASTER-Build-fake-name", "/manual/job")
+	ds.Spec.Discovery = &v1alpha1.JenkinsJobDiscovery{
+		Folders:  []string{"/fake-name", "other"},
+		Patterns: []string{"/*/MASTER-Build-*"},
+	}
+
+	assert.NoError(t, createDiscoverJobs().ServeRequest(ds))
+	assert.Equal(t, []string{"/fake-name/MASTER-Build-fake-name", "/other/MASTER-Build-other"}, ds.Status.DiscoveredJobNames)
+	assert.Equal(t, []string{"/fake-name/MASTER-Build-fake-name", "/manual/job", "/other/MASTER-Build-other"}, getJobNames(ds))
+}
+
+func TestDiscoverJobs_ShouldSearchRootByRegexAndGlob(t *testing.T) {
+	srv := createJenkinsFolderServer()
+	defer srv.Close()
+	ds := createValidatedDataSource(srv.URL, "")
+	ds.Spec.Discovery = &v1alpha1.JenkinsJobDiscovery{
+		Patterns: []string{"regex:Code-review", "/seed"},
+	}
+
+	assert.NoError(t, createDiscoverJobs().ServeRequest(ds))
+	assert.Equal(t, []string{"/seed", "/fake-name/MASTER-Code-review-fake-name"}, ds.Status.DiscoveredJobNames)
+}
+
+func TestDiscoverJobs_ShouldFailOnMissingFolder(t *testing.T) {
+	srv := createJenkinsFolderServer()
+	defer srv.Close()
+	ds := createValidatedDataSource(srv.URL, "")
+	ds.Spec.Discovery = &v1alpha1.JenkinsJobDiscovery{
+		Folders: []string{"missing"},
+	}
+
+	err := createDiscoverJobs().ServeRequest(ds)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "couldn't discover Jenkins jobs")
+	assert.Equal(t, "error", ds.Status.Status)
+}
+
+func createDiscoverJobs() DiscoverJobs {
+	return DiscoverJobs{
+		client: createValidateJobs().client,
+	}
+}
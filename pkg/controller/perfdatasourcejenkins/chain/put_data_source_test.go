@@ -1,6 +1,7 @@
 package chain
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
@@ -8,6 +9,7 @@ import (
 	"github.com/epmd-edp/perf-operator/v2/pkg/model/command"
 	"github.com/epmd-edp/perf-operator/v2/pkg/model/dto"
 	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
 	coreV1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -51,6 +53,9 @@ func TestPutDataSource_ShouldUpdateJenkinsDataSourceWithoutActivating(t *testing
 		Spec: v1alpha1.PerfServerSpec{
 			ProjectName: fakeName,
 		},
+		Status: v1alpha1.PerfServerStatus{
+			ProjectId: fakeProjectId,
+		},
 	}
 
 	sec := &coreV1.Secret{
@@ -77,7 +82,7 @@ func TestPutDataSource_ShouldUpdateJenkinsDataSourceWithoutActivating(t *testing
 		perfClient: mPerfCl,
 	}
 
-	mPerfCl.On("GetProjectDataSource", fakeName, jenkinsDsType).
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).
 		Return(&dto.DataSource{
 			Active: true,
 			Type:   jenkinsDsType,
@@ -130,6 +135,9 @@ func TestPutDataSource_ShouldUpdateJenkinsDataSourceWithActivating(t *testing.T)
 		Spec: v1alpha1.PerfServerSpec{
 			ProjectName: fakeName,
 		},
+		Status: v1alpha1.PerfServerStatus{
+			ProjectId: fakeProjectId,
+		},
 	}
 
 	sec := &coreV1.Secret{
@@ -156,7 +164,7 @@ func TestPutDataSource_ShouldUpdateJenkinsDataSourceWithActivating(t *testing.T)
 		perfClient: mPerfCl,
 	}
 
-	mPerfCl.On("GetProjectDataSource", fakeName, jenkinsDsType).
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).
 		Return(&dto.DataSource{
 			Active: false,
 			Type:   jenkinsDsType,
@@ -176,7 +184,7 @@ func TestPutDataSource_ShouldUpdateJenkinsDataSourceWithActivating(t *testing.T)
 		},
 	}).Return(nil)
 
-	mPerfCl.On("ActivateDataSource", fakeName, 0).Return(nil)
+	mPerfCl.On("ActivateDataSource", 0).Return(nil)
 
 	assert.NoError(t, ch.ServeRequest(pds))
 	assert.Equal(t, "created", pds.Status.Status)
@@ -211,6 +219,9 @@ func TestPutDataSource_ShouldCreateJenkinsDataSource(t *testing.T) {
 		Spec: v1alpha1.PerfServerSpec{
 			ProjectName: fakeName,
 		},
+		Status: v1alpha1.PerfServerStatus{
+			ProjectId: fakeProjectId,
+		},
 	}
 
 	sec := &coreV1.Secret{
@@ -237,9 +248,9 @@ func TestPutDataSource_ShouldCreateJenkinsDataSource(t *testing.T) {
 		perfClient: mPerfCl,
 	}
 
-	mPerfCl.On("GetProjectDataSource", fakeName, jenkinsDsType).Return(nil, nil)
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).Return(nil, nil)
 
-	mPerfCl.On("CreateDataSource", fakeName, command.DataSourceCommand{
+	mPerfCl.On("CreateDataSource", fakeProjectId, command.DataSourceCommand{
 		Type: jenkinsDsType,
 		Config: command.DataSourceJenkinsConfig{
 			JobNames: []string{fmt.Sprintf("/%v/%v-Build-%v", fakeName, strings.ToUpper(fakeName), fakeName),
@@ -263,6 +274,9 @@ func TestPutDataSource_ShouldNotFindDataSourceInPERF(t *testing.T) {
 		Spec: v1alpha1.PerfServerSpec{
 			ProjectName: fakeName,
 		},
+		Status: v1alpha1.PerfServerStatus{
+			ProjectId: fakeProjectId,
+		},
 	}
 
 	objs := []runtime.Object{
@@ -278,7 +292,7 @@ func TestPutDataSource_ShouldNotFindDataSourceInPERF(t *testing.T) {
 		perfClient: mPerfCl,
 	}
 
-	mPerfCl.On("GetProjectDataSource", fakeName, "").Return(nil, errors.New("failed"))
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).Return(nil, errors.New("failed"))
 
 	pds := &v1alpha1.PerfDataSourceJenkins{
 		ObjectMeta: v1.ObjectMeta{
@@ -325,6 +339,9 @@ func TestPutDataSource_ShouldNotActivateDataSource(t *testing.T) {
 		Spec: v1alpha1.PerfServerSpec{
 			ProjectName: fakeName,
 		},
+		Status: v1alpha1.PerfServerStatus{
+			ProjectId: fakeProjectId,
+		},
 	}
 
 	sec := &coreV1.Secret{
@@ -351,7 +368,7 @@ func TestPutDataSource_ShouldNotActivateDataSource(t *testing.T) {
 		perfClient: mPerfCl,
 	}
 
-	mPerfCl.On("GetProjectDataSource", fakeName, jenkinsDsType).
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).
 		Return(&dto.DataSource{
 			Active: false,
 			Type:   jenkinsDsType,
@@ -371,7 +388,7 @@ func TestPutDataSource_ShouldNotActivateDataSource(t *testing.T) {
 		},
 	}).Return(nil)
 
-	mPerfCl.On("ActivateDataSource", fakeName, 0).Return(errors.New("failed"))
+	mPerfCl.On("ActivateDataSource", 0).Return(errors.New("failed"))
 
 	assert.Error(t, ch.ServeRequest(pds))
 	assert.Equal(t, "error", pds.Status.Status)
@@ -405,6 +422,9 @@ func TestPutDataSource_ShouldNotUpdateDataSourceBecauseOfMissingNewParameters(t
 		Spec: v1alpha1.PerfServerSpec{
 			ProjectName: fakeName,
 		},
+		Status: v1alpha1.PerfServerStatus{
+			ProjectId: fakeProjectId,
+		},
 	}
 
 	sec := &coreV1.Secret{
@@ -431,12 +451,13 @@ func TestPutDataSource_ShouldNotUpdateDataSourceBecauseOfMissingNewParameters(t
 		perfClient: mPerfCl,
 	}
 
-	mPerfCl.On("GetProjectDataSource", fakeName, jenkinsDsType).
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).
 		Return(&dto.DataSource{
 			Active: true,
 			Type:   jenkinsDsType,
 			Config: map[string]interface{}{
 				"jobNames": []interface{}{fmt.Sprintf("/%v/%v-Build-%v", fakeName, strings.ToUpper(fakeName), fakeName)},
+				"url":      fakeName,
 			},
 		}, nil)
 
@@ -453,3 +474,321 @@ func TestPutDataSource_ShouldNotUpdateDataSourceBecauseOfMissingNewParameters(t
 
 	assert.NoError(t, ch.ServeRequest(pds))
 }
+
+func TestPutDataSource_ShouldCreateJenkinsDataSourceUnderChildNode(t *testing.T) {
+	pds := &v1alpha1.PerfDataSourceJenkins{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Spec: v1alpha1.PerfDataSourceJenkinsSpec{
+			Type: jenkinsDsType,
+			Config: v1alpha1.DataSourceJenkinsConfig{
+				JobNames: []string{"/fake-name/MASTER-Build-fake-name"},
+				Url:      fakeName,
+			},
+			PerfServerName: fakeName,
+			PerfNode:       "Stream-A/Backend",
+			CreatePerfNode: true,
+		},
+	}
+
+	ps := &v1alpha1.PerfServer{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Status: v1alpha1.PerfServerStatus{
+			ProjectId: fakeProjectId,
+		},
+	}
+
+	sec := &coreV1.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      jenkinsDataSourceSecretName,
+			Namespace: fakeNamespace,
+		},
+		Data: map[string][]byte{
+			"username": []byte("fake"),
+			"password": []byte("fake"),
+		},
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, pds, ps)
+
+	mPerfCl := new(mock.MockPerfClient)
+	ch := PutDataSource{
+		client:     fake.NewFakeClient([]runtime.Object{pds, ps, sec}...),
+		perfClient: mPerfCl,
+	}
+
+	mPerfCl.On("GetProjectById", fakeProjectId).Return(&dto.PerfProject{
+		Id:       fakeProjectId,
+		Name:     fakeName,
+		Children: []dto.PerfProject{{Id: 2, Name: "stream-a"}},
+	}, fakeName, nil)
+	mPerfCl.On("CreateNode", 2, "Backend").Return(&dto.PerfProject{Id: 3, Name: "Backend"}, nil)
+	mPerfCl.On("GetProjectDataSource", 3, getDataSourceKey(pds)).Return(nil, nil)
+	mPerfCl.On("CreateDataSource", 3, command.DataSourceCommand{
+		Type: jenkinsDsType,
+		Config: command.DataSourceJenkinsConfig{
+			JobNames: []string{"/fake-name/MASTER-Build-fake-name"},
+			Url:      fakeName,
+			Username: "fake",
+			Password: "fake",
+		},
+	}).Return(nil)
+
+	assert.NoError(t, ch.ServeRequest(pds))
+	assert.Equal(t, "created", pds.Status.Status)
+}
+
+func TestPutDataSource_ShouldFailWhenChildNodeIsMissing(t *testing.T) {
+	pds := &v1alpha1.PerfDataSourceJenkins{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Spec: v1alpha1.PerfDataSourceJenkinsSpec{
+			Type:           jenkinsDsType,
+			PerfServerName: fakeName,
+			PerfNode:       "Stream-B",
+		},
+	}
+
+	ps := &v1alpha1.PerfServer{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Status: v1alpha1.PerfServerStatus{
+			ProjectId: fakeProjectId,
+		},
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, pds, ps)
+
+	mPerfCl := new(mock.MockPerfClient)
+	ch := PutDataSource{
+		client:     fake.NewFakeClient([]runtime.Object{pds, ps}...),
+		perfClient: mPerfCl,
+	}
+
+	mPerfCl.On("GetProjectById", fakeProjectId).Return(&dto.PerfProject{Id: fakeProjectId, Name: fakeName}, fakeName, nil)
+
+	assert.Error(t, ch.ServeRequest(pds))
+	assert.Equal(t, "error", pds.Status.Status)
+	mPerfCl.AssertNotCalled(t, "CreateNode", fakeProjectId, "Stream-B")
+}
+
+func createUpdatedJenkinsDataSource() (*v1alpha1.PerfDataSourceJenkins, *PutDataSource, *mock.MockPerfClient) {
+	pds := &v1alpha1.PerfDataSourceJenkins{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Spec: v1alpha1.PerfDataSourceJenkinsSpec{
+			Type: jenkinsDsType,
+			Config: v1alpha1.DataSourceJenkinsConfig{
+				JobNames: []string{"/fake-name/MASTER-Build-fake-name"},
+				Url:      fakeName,
+			},
+			PerfServerName: fakeName,
+		},
+	}
+
+	ps := &v1alpha1.PerfServer{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Status: v1alpha1.PerfServerStatus{
+			ProjectId: fakeProjectId,
+		},
+	}
+
+	sec := &coreV1.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      jenkinsDataSourceSecretName,
+			Namespace: fakeNamespace,
+		},
+		Data: map[string][]byte{
+			"username": []byte("fake"),
+			"password": []byte("fake"),
+		},
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, pds, ps)
+
+	mPerfCl := new(mock.MockPerfClient)
+	return pds, &PutDataSource{
+		client:     fake.NewFakeClient([]runtime.Object{pds, ps, sec}...),
+		perfClient: mPerfCl,
+	}, mPerfCl
+}
+
+func TestPutDataSource_ShouldUpdateNullJobNamesKeepingUnknownFields(t *testing.T) {
+	pds, ch, mPerfCl := createUpdatedJenkinsDataSource()
+
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).
+		Return(&dto.DataSource{
+			Id:     2,
+			Active: true,
+			Type:   jenkinsDsType,
+			Config: map[string]interface{}{
+				"jobNames":    nil,
+				"crumbIssuer": true,
+			},
+		}, nil)
+	mPerfCl.On("UpdateDataSource", command.DataSourceCommand{
+		Id:   2,
+		Type: jenkinsDsType,
+		Config: command.DataSourceJenkinsConfig{
+			JobNames: []string{"/fake-name/MASTER-Build-fake-name"},
+			Url:      fakeName,
+			Username: "fake",
+			Password: "fake",
+			Extra:    map[string]interface{}{"crumbIssuer": true},
+		},
+	}).Return(nil)
+
+	assert.NoError(t, ch.ServeRequest(pds))
+	assert.Equal(t, "created", pds.Status.Status)
+}
+
+func TestPutDataSource_ShouldFailOnMalformedJenkinsConfig(t *testing.T) {
+	pds, ch, mPerfCl := createUpdatedJenkinsDataSource()
+
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).
+		Return(&dto.DataSource{
+			Id:     2,
+			Active: true,
+			Type:   jenkinsDsType,
+			Config: map[string]interface{}{
+				"jobNames": "/fake-name/MASTER-Build-fake-name",
+			},
+		}, nil)
+
+	assert.Error(t, ch.ServeRequest(pds))
+	assert.Equal(t, "error", pds.Status.Status)
+}
+
+func TestPutDataSource_ShouldUpdateUrlResolvedFromEdpComponent(t *testing.T) {
+	pds, ch, mPerfCl := createUpdatedJenkinsDataSource()
+	pds.Spec.Config.Url = ""
+	pds.Spec.EdpComponent = "jenkins"
+	pds.Status.Url = "https://jenkins.example.com"
+
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).
+		Return(&dto.DataSource{
+			Id:     2,
+			Active: true,
+			Type:   jenkinsDsType,
+			Config: map[string]interface{}{
+				"jobNames": []interface{}{"/fake-name/MASTER-Build-fake-name"},
+				"url":      "https://old-jenkins.example.com",
+			},
+		}, nil)
+	mPerfCl.On("UpdateDataSource", command.DataSourceCommand{
+		Id:   2,
+		Type: jenkinsDsType,
+		Config: command.DataSourceJenkinsConfig{
+			JobNames: []string{"/fake-name/MASTER-Build-fake-name"},
+			Url:      "https://jenkins.example.com",
+			Username: "fake",
+			Password: "fake",
+		},
+	}).Return(nil)
+
+	assert.NoError(t, ch.ServeRequest(pds))
+	assert.Equal(t, "created", pds.Status.Status)
+}
+
+func TestPutDataSource_ShouldUpdateDataSourceFoundByPreviousUrl(t *testing.T) {
+	pds, ch, mPerfCl := createUpdatedJenkinsDataSource()
+	pds.Spec.Config.Url = ""
+	pds.Spec.EdpComponent = "jenkins"
+	pds.Status.Url = "https://jenkins.example.com"
+	pds.Status.PreviousUrl = "https://old-jenkins.example.com"
+
+	previousKey := getDataSourceKey(pds)
+	previousKey.Url = "https://old-jenkins.example.com"
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, previousKey).
+		Return(&dto.DataSource{
+			Id:     2,
+			Active: true,
+			Type:   jenkinsDsType,
+			Config: map[string]interface{}{
+				"jobNames": []interface{}{"/fake-name/MASTER-Build-fake-name"},
+				"url":      "https://old-jenkins.example.com",
+			},
+		}, nil)
+	mPerfCl.On("UpdateDataSource", command.DataSourceCommand{
+		Id:   2,
+		Type: jenkinsDsType,
+		Config: command.DataSourceJenkinsConfig{
+			JobNames: []string{"/fake-name/MASTER-Build-fake-name"},
+			Url:      "https://jenkins.example.com",
+			Username: "fake",
+			Password: "fake",
+		},
+	}).Return(nil)
+
+	assert.NoError(t, ch.ServeRequest(pds))
+	assert.Equal(t, "created", pds.Status.Status)
+	assert.Empty(t, pds.Status.PreviousUrl)
+	mPerfCl.AssertNotCalled(t, "GetProjectDataSource", fakeProjectId, getDataSourceKey(pds))
+	mPerfCl.AssertNotCalled(t, "CreateDataSource", testifyMock.Anything, testifyMock.Anything)
+}
+
+func TestPutDataSource_ShouldNotUpdateUrlDifferingInTrailingSlash(t *testing.T) {
+	pds, ch, mPerfCl := createUpdatedJenkinsDataSource()
+	pds.Spec.Config.Url = "https://jenkins.example.com"
+
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).
+		Return(&dto.DataSource{
+			Id:     2,
+			Active: true,
+			Type:   jenkinsDsType,
+			Config: map[string]interface{}{
+				"jobNames": []interface{}{"/fake-name/MASTER-Build-fake-name"},
+				"url":      "https://jenkins.example.com/",
+			},
+		}, nil)
+
+	assert.NoError(t, ch.ServeRequest(pds))
+	assert.Equal(t, "created", pds.Status.Status)
+	mPerfCl.AssertNotCalled(t, "UpdateDataSource", testifyMock.Anything)
+}
+
+func TestPutDataSource_ShouldUseResolvedCredentials(t *testing.T) {
+	pds, ch, mPerfCl := createUpdatedJenkinsDataSource()
+	pds.Status.CredentialName = "ci-jenkins-admin-token"
+	assert.NoError(t, ch.client.Create(context.TODO(), &coreV1.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "ci-jenkins-admin-token",
+			Namespace: fakeNamespace,
+		},
+		Data: map[string][]byte{
+			"username": []byte("ci"),
+			"password": []byte("ci-token"),
+		},
+	}))
+
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).Return(nil, nil)
+	mPerfCl.On("CreateDataSource", fakeProjectId, command.DataSourceCommand{
+		Type: jenkinsDsType,
+		Config: command.DataSourceJenkinsConfig{
+			JobNames: []string{"/fake-name/MASTER-Build-fake-name"},
+			Url:      fakeName,
+			Username: "ci",
+			Password: "ci-token",
+		},
+	}).Return(nil)
+
+	assert.NoError(t, ch.ServeRequest(pds))
+	assert.Equal(t, "created", pds.Status.Status)
+}
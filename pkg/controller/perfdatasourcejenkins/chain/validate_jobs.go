@@ -0,0 +1,88 @@
+package chain
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/jenkins"
+	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfdatasourcejenkins/chain/handler"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/command"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/cluster"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/datasource"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"strings"
+)
+
+type ValidateJobs struct {
+	next     handler.PerfDataSourceJenkinsHandler
+	client   client.Client
+	recorder record.EventRecorder
+}
+
+const jobNamesValidCondition = "JobNamesValid"
+
+func (h ValidateJobs) ServeRequest(dataSource *v1alpha1.PerfDataSourceJenkins) error {
+	if dataSource.Spec.Validation == "" {
+		dataSource.Status.UnknownJobNames = nil
+		dataSource.Status.Conditions = datasource.RemoveCondition(dataSource.Status.Conditions, jobNamesValidCondition)
+		return nextServeOrNil(h.next, dataSource)
+	}
+
+	log.Info("start validating Jenkins jobs", "name", dataSource.Name, "policy", dataSource.Spec.Validation)
+	if err := h.validateJobs(dataSource); err != nil {
+		setFailedStatus(dataSource)
+		return err
+	}
+	log.Info("Jenkins jobs have been validated.", "name", dataSource.Name)
+	return nextServeOrNil(h.next, dataSource)
+}
+
+func (h ValidateJobs) validateJobs(ds *v1alpha1.PerfDataSourceJenkins) error {
+	s, err := cluster.GetSecret(h.client, getCredentialName(ds), ds.Namespace)
+	if err != nil {
+		return err
+	}
+
+	jc := jenkins.NewJenkinsRestClient(command.GetJenkinsUrl(ds), string(s.Data["username"]), string(s.Data["password"]))
+	r := checkJobs(jc, ds.Spec.Config.JobNames)
+
+	ds.Status.UnknownJobNames = r.Unknown
+	ds.Status.Conditions = datasource.ReportValidation(h.recorder, ds, ds.Status.Conditions, r)
+	return datasource.EnforceValidation(ds.Spec.Validation, r)
+}
+
+// checkJobs returns the jobs that haven't been found in Jenkins. The result has an error
+// if some of the jobs couldn't be checked, they aren't considered unknown then.
+func checkJobs(jc jenkins.JenkinsClient, names []string) datasource.ValidationResult {
+	r := datasource.ValidationResult{
+		ConditionType: jobNamesValidCondition,
+		Entries:       "Jenkins jobs",
+	}
+	var msgs []string
+	for _, n := range names {
+		exists, err := jc.JobExists(n)
+		if err != nil {
+			msgs = append(msgs, err.Error())
+			continue
+		}
+		if !exists {
+			r.Unknown = append(r.Unknown, n)
+		}
+	}
+	if len(msgs) > 0 {
+		r.Err = errors.New(strings.Join(msgs, "; "))
+	}
+	return r
+}
+
+// getJobNames returns the job names along with the discovered ones to be sent to PERF.
+func getJobNames(ds *v1alpha1.PerfDataSourceJenkins) []string {
+	return datasource.MergeEntries(
+		datasource.GetValidEntries(ds.Spec.Validation, ds.Spec.Config.JobNames, ds.Status.UnknownJobNames),
+		ds.Status.DiscoveredJobNames)
+}
+
+// HasInvalidEntries reports if the last validation found unknown jobs or couldn't check them.
+func HasInvalidEntries(ds *v1alpha1.PerfDataSourceJenkins) bool {
+	return datasource.HasFailedCondition(ds.Status.Conditions, jobNamesValidCondition)
+}
@@ -0,0 +1,134 @@
+package chain
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"net/http"
+	"net/http/httptest"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func createJenkinsServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/job/fake-name/job/MASTER-Build-fake-name/api/json":
+			_, _ = w.Write([]byte(`{"name":"MASTER-Build-fake-name"}`))
+		case "/job/broken/api/json":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func createValidatedDataSource(url, policy string, jobNames ...string) *v1alpha1.PerfDataSourceJenkins {
+	return &v1alpha1.PerfDataSourceJenkins{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Spec: v1alpha1.PerfDataSourceJenkinsSpec{
+			Type: jenkinsDsType,
+			Config: v1alpha1.DataSourceJenkinsConfig{
+				JobNames: jobNames,
+				Url:      url,
+			},
+			Validation: policy,
+		},
+	}
+}
+
+func createValidateJobs(recorder record.EventRecorder) ValidateJobs {
+	return ValidateJobs{
+		recorder: recorder,
+		client: fake.NewFakeClient(&coreV1.Secret{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      jenkinsDataSourceSecretName,
+				Namespace: fakeNamespace,
+			},
+			Data: map[string][]byte{
+				"username": []byte("fake"),
+				"password": []byte("fake"),
+			},
+		}),
+	}
+}
+
+func TestValidateJobs_ShouldSkipValidationWithoutPolicy(t *testing.T) {
+	ds := createValidatedDataSource("http://jenkins.invalid", "", "/fake-name/missing")
+	ds.Status.UnknownJobNames = []string{"/fake-name/missing"}
+	ds.Status.Conditions = []v1alpha1.DataSourceCondition{{Type: jobNamesValidCondition, Status: v1alpha1.ConditionFalse}}
+
+	assert.NoError(t, ValidateJobs{}.ServeRequest(ds))
+	assert.Nil(t, ds.Status.UnknownJobNames)
+	assert.Empty(t, ds.Status.Conditions)
+	assert.Equal(t, []string{"/fake-name/missing"}, getJobNames(ds))
+}
+
+func TestValidateJobs_ShouldWarnAboutMissingJobs(t *testing.T) {
+	srv := createJenkinsServer()
+	defer srv.Close()
+	ds := createValidatedDataSource(srv.URL, v1alpha1.ValidationWarn,
+		"/fake-name/MASTER-Build-fake-name", "/fake-name/missing")
+	recorder := record.NewFakeRecorder(10)
+
+	assert.NoError(t, createValidateJobs(recorder).ServeRequest(ds))
+	assert.Equal(t, []string{"/fake-name/missing"}, ds.Status.UnknownJobNames)
+	assert.Len(t, ds.Status.Conditions, 1)
+	assert.Equal(t, v1alpha1.ConditionFalse, ds.Status.Conditions[0].Status)
+	assert.Contains(t, ds.Status.Conditions[0].Message, "/fake-name/missing")
+	assert.Contains(t, <-recorder.Events, "UnknownEntries")
+	assert.True(t, HasInvalidEntries(ds))
+	assert.Equal(t, ds.Spec.Config.JobNames, getJobNames(ds))
+}
+
+func TestValidateJobs_ShouldReportUncheckedJobs(t *testing.T) {
+	srv := createJenkinsServer()
+	defer srv.Close()
+	ds := createValidatedDataSource(srv.URL, v1alpha1.ValidationWarn,
+		"/fake-name/MASTER-Build-fake-name", "/fake-name/missing", "broken")
+	recorder := record.NewFakeRecorder(10)
+
+	assert.NoError(t, createValidateJobs(recorder).ServeRequest(ds))
+	assert.Equal(t, []string{"/fake-name/missing"}, ds.Status.UnknownJobNames)
+	assert.Equal(t, v1alpha1.ConditionUnknown, ds.Status.Conditions[0].Status)
+	assert.Contains(t, ds.Status.Conditions[0].Message, "broken")
+	assert.Contains(t, <-recorder.Events, "ValidationFailed")
+	assert.True(t, HasInvalidEntries(ds))
+}
+
+func TestValidateJobs_ShouldSkipMissingJobs(t *testing.T) {
+	srv := createJenkinsServer()
+	defer srv.Close()
+	ds := createValidatedDataSource(srv.URL, v1alpha1.ValidationSkip,
+		"/fake-name/MASTER-Build-fake-name", "/fake-name/missing", "broken")
+
+	assert.NoError(t, createValidateJobs(record.NewFakeRecorder(10)).ServeRequest(ds))
+	assert.Equal(t, []string{"/fake-name/MASTER-Build-fake-name", "broken"}, getJobNames(ds))
+}
+
+func TestValidateJobs_ShouldBlockOnMissingJobs(t *testing.T) {
+	srv := createJenkinsServer()
+	defer srv.Close()
+	ds := createValidatedDataSource(srv.URL, v1alpha1.ValidationBlock,
+		"/fake-name/MASTER-Build-fake-name", "/fake-name/missing")
+
+	err := createValidateJobs(record.NewFakeRecorder(10)).ServeRequest(ds)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "/fake-name/missing")
+	assert.Equal(t, "error", ds.Status.Status)
+}
+
+func TestValidateJobs_ShouldPassWhenAllJobsExist(t *testing.T) {
+	srv := createJenkinsServer()
+	defer srv.Close()
+	ds := createValidatedDataSource(srv.URL, v1alpha1.ValidationBlock, "/fake-name/MASTER-Build-fake-name")
+
+	assert.NoError(t, createValidateJobs(record.NewFakeRecorder(10)).ServeRequest(ds))
+	assert.Equal(t, v1alpha1.ConditionTrue, ds.Status.Conditions[0].Status)
+	assert.False(t, HasInvalidEntries(ds))
+}
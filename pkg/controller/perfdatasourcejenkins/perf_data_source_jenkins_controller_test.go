@@ -0,0 +1,204 @@
+package perfdatasourcejenkins
+
+import (
+	"context"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf"
+	perfFake "github.com/epmd-edp/perf-operator/v2/pkg/client/perf/fake"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/consts"
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"net/http"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"testing"
+)
+
+const (
+	fakeName      = "fake-name"
+	fakeNamespace = "fake-namespace"
+	projectName   = "Fake-Project"
+)
+
+func createObjects(apiUrl string, projectId int) []runtime.Object {
+	ds := &v1alpha1.PerfDataSourceJenkins{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+			OwnerReferences: []v1.OwnerReference{
+				{
+					Kind: consts.CodebaseKind,
+					Name: fakeName,
+				},
+			},
+		},
+		Spec: v1alpha1.PerfDataSourceJenkinsSpec{
+			Name: "jenkins",
+			Type: "jenkins",
+			Config: v1alpha1.DataSourceJenkinsConfig{
+				JobNames: []string{"/fake-name/MASTER-Build-fake-name"},
+				Url:      "https://jenkins.example.com",
+			},
+			PerfServerName: fakeName,
+			CodebaseName:   fakeName,
+		},
+	}
+	ps := &v1alpha1.PerfServer{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Spec: v1alpha1.PerfServerSpec{
+			ApiUrl:      apiUrl,
+			ProjectName: projectName,
+			Auth: &v1alpha1.PerfServerAuth{
+				Type:       perf.BearerAuthType,
+				SecretName: "perf-token",
+			},
+		},
+		Status: v1alpha1.PerfServerStatus{
+			Available: true,
+			ProjectId: projectId,
+		},
+	}
+	token := &coreV1.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "perf-token",
+			Namespace: fakeNamespace,
+		},
+		Data: map[string][]byte{
+			"token": []byte(perfFake.Token),
+		},
+	}
+	jenkins := &coreV1.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "jenkins-admin-token",
+			Namespace: fakeNamespace,
+		},
+		Data: map[string][]byte{
+			"username": []byte("admin"),
+			"password": []byte("admin"),
+		},
+	}
+
+	scheme.Scheme.AddKnownTypes(v1.SchemeGroupVersion, ds, ps)
+	return []runtime.Object{ds, ps, token, jenkins}
+}
+
+func TestReconcile_ShouldCreateAndUpdateDataSourceInPerf(t *testing.T) {
+	srv := perfFake.NewServer()
+	defer srv.Close()
+	project := srv.AddNode(0, projectName)
+
+	c := fake.NewFakeClient(createObjects(srv.URL, project)...)
+	r := ReconcilePerfDataSourceJenkins{client: c, scheme: scheme.Scheme}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: fakeName, Namespace: fakeNamespace}}
+
+	_, err := r.Reconcile(req)
+	assert.NoError(t, err)
+
+	dss := srv.DataSources(project)
+	assert.Len(t, dss, 1)
+	assert.Equal(t, "JENKINS", dss[0].Type)
+	assert.Equal(t, []interface{}{"/fake-name/MASTER-Build-fake-name"}, dss[0].Config["jobNames"])
+
+	ds := &v1alpha1.PerfDataSourceJenkins{}
+	assert.NoError(t, c.Get(context.TODO(), req.NamespacedName, ds))
+	assert.Equal(t, "created", ds.Status.Status)
+
+	ds.Spec.Config.JobNames = append(ds.Spec.Config.JobNames, "/fake-name/MASTER-Code-review-fake-name")
+	assert.NoError(t, c.Update(context.TODO(), ds))
+
+	_, err = r.Reconcile(req)
+	assert.NoError(t, err)
+
+	dss = srv.DataSources(project)
+	assert.Len(t, dss, 1)
+	assert.True(t, dss[0].Active)
+	assert.Equal(t, []interface{}{"/fake-name/MASTER-Build-fake-name", "/fake-name/MASTER-Code-review-fake-name"},
+		dss[0].Config["jobNames"])
+}
+
+func TestReconcile_ShouldFailOnPerfError(t *testing.T) {
+	srv := perfFake.NewServer()
+	defer srv.Close()
+	project := srv.AddNode(0, projectName)
+	srv.Inject(perfFake.Fault{Path: "/api/v2/datasources", Status: http.StatusServiceUnavailable})
+
+	c := fake.NewFakeClient(createObjects(srv.URL, project)...)
+	r := ReconcilePerfDataSourceJenkins{client: c, scheme: scheme.Scheme}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: fakeName, Namespace: fakeNamespace}}
+
+	_, err := r.Reconcile(req)
+	assert.Error(t, err)
+
+	ds := &v1alpha1.PerfDataSourceJenkins{}
+	assert.NoError(t, c.Get(context.TODO(), req.NamespacedName, ds))
+	assert.Equal(t, "error", ds.Status.Status)
+}
+
+func TestGetEdpComponentDataSources_ShouldReturnDataSourcesWithoutUrl(t *testing.T) {
+	objs := createObjects("", 0)
+	ds := objs[0].(*v1alpha1.PerfDataSourceJenkins)
+	ds.Spec.EdpComponent = "jenkins"
+	ds.Spec.Config.Url = ""
+	explicit := ds.DeepCopy()
+	explicit.Name = "explicit-url"
+	explicit.Spec.Config.Url = "https://jenkins.example.com"
+	scheme.Scheme.AddKnownTypes(v1.SchemeGroupVersion, &v1alpha1.PerfDataSourceJenkinsList{})
+
+	c := fake.NewFakeClient(append(objs, explicit)...)
+
+	assert.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Name: fakeName, Namespace: fakeNamespace}}},
+		getEdpComponentDataSources(c, fakeNamespace, "jenkins"))
+	assert.Empty(t, getEdpComponentDataSources(c, fakeNamespace, "sonar"))
+}
+
+func assertSpecUpdated(t *testing.T, expected bool, update func(ds *v1alpha1.PerfDataSourceJenkins)) {
+	oldDs := createObjects("", 0)[0].(*v1alpha1.PerfDataSourceJenkins)
+	newDs := oldDs.DeepCopy()
+	update(newDs)
+	assert.Equal(t, expected, specUpdated(event.UpdateEvent{ObjectOld: oldDs, ObjectNew: newDs}))
+}
+
+func TestSpecUpdated_ShouldReportPerfNodeChanges(t *testing.T) {
+	assertSpecUpdated(t, true, func(ds *v1alpha1.PerfDataSourceJenkins) {
+		ds.Spec.PerfNode = "Backend"
+	})
+	assertSpecUpdated(t, true, func(ds *v1alpha1.PerfDataSourceJenkins) {
+		ds.Spec.CreatePerfNode = true
+	})
+	assertSpecUpdated(t, false, func(ds *v1alpha1.PerfDataSourceJenkins) {
+		ds.Status.Status = "created"
+	})
+}
+
+func TestSpecUpdated_ShouldKeepJobNamesOrder(t *testing.T) {
+	oldDs := createObjects("", 0)[0].(*v1alpha1.PerfDataSourceJenkins)
+	oldDs.Spec.Config.JobNames = []string{"/b", "/a"}
+	newDs := oldDs.DeepCopy()
+	newDs.Spec.Config.JobNames = []string{"/a", "/b"}
+
+	assert.True(t, specUpdated(event.UpdateEvent{ObjectOld: oldDs, ObjectNew: newDs}))
+	assert.Equal(t, []string{"/b", "/a"}, oldDs.Spec.Config.JobNames)
+}
+
+func TestSpecUpdated_ShouldReportPerfServerChanges(t *testing.T) {
+	assertSpecUpdated(t, true, func(ds *v1alpha1.PerfDataSourceJenkins) {
+		ds.Spec.PerfServerKind = consts.ClusterPerfServerKind
+	})
+	assertSpecUpdated(t, true, func(ds *v1alpha1.PerfDataSourceJenkins) {
+		ds.Spec.PerfServerName = "other-perf"
+	})
+}
+
+func TestSpecUpdated_ShouldReportDataSourceRename(t *testing.T) {
+	assertSpecUpdated(t, true, func(ds *v1alpha1.PerfDataSourceJenkins) {
+		ds.Spec.Name = "jenkins-renamed"
+	})
+}
@@ -0,0 +1,67 @@
+package chain
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/sonar"
+	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfdatasourcesonar/chain/handler"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/command"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/cluster"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/datasource"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type DiscoverProjectKeys struct {
+	next   handler.PerfDataSourceSonarHandler
+	client client.Client
+}
+
+func (h DiscoverProjectKeys) ServeRequest(dataSource *v1alpha1.PerfDataSourceSonar) error {
+	if dataSource.Spec.Discovery == nil {
+		dataSource.Status.DiscoveredProjectKeys = nil
+		return nextServeOrNil(h.next, dataSource)
+	}
+
+	log.Info("start discovering Sonar project keys", "name", dataSource.Name)
+	if err := h.discoverProjectKeys(dataSource); err != nil {
+		setFailedStatus(dataSource)
+		return errors.Wrapf(err, "couldn't discover Sonar project keys of %v data source", dataSource.Name)
+	}
+	log.Info("Sonar project keys have been discovered.", "name", dataSource.Name,
+		"count", len(dataSource.Status.DiscoveredProjectKeys))
+	return nextServeOrNil(h.next, dataSource)
+}
+
+func (h DiscoverProjectKeys) discoverProjectKeys(ds *v1alpha1.PerfDataSourceSonar) error {
+	s, err := cluster.GetSecret(h.client, getCredentialName(ds), ds.Namespace)
+	if err != nil {
+		return err
+	}
+
+	sc := sonar.NewSonarRestClient(command.GetSonarUrl(ds), string(s.Data["username"]), string(s.Data["password"]))
+	keys, err := findProjectKeys(sc, ds.Spec.Discovery)
+	if err != nil {
+		return err
+	}
+	ds.Status.DiscoveredProjectKeys = keys
+	return nil
+}
+
+func findProjectKeys(sc sonar.SonarClient, d *v1alpha1.SonarProjectDiscovery) ([]string, error) {
+	var res []string
+	for _, p := range d.KeyPrefixes {
+		keys, err := sc.GetProjectKeysByPrefix(p)
+		if err != nil {
+			return nil, err
+		}
+		res = datasource.MergeEntries(res, keys)
+	}
+	if len(d.Tags) > 0 {
+		keys, err := sc.GetProjectKeysByTags(d.Tags)
+		if err != nil {
+			return nil, err
+		}
+		res = datasource.MergeEntries(res, keys)
+	}
+	return res, nil
+}
@@ -0,0 +1,65 @@
+package chain
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func createSonarSearchServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/projects/search" && r.URL.Query().Get("q") == "edp":
+			_, _ = w.Write([]byte(`{"paging":{"total":2},"components":[{"key":"edp-app"},{"key":"old-edp"}]}`))
+		case r.URL.Path == "/api/components/search_projects":
+			_, _ = w.Write([]byte(`{"paging":{"total":2},"components":[{"key":"tagged"},{"key":"edp-app"}]}`))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+}
+
+func createDiscoverProjectKeys() DiscoverProjectKeys {
+	return DiscoverProjectKeys{
+		client: createValidateProjectKeys(nil).client,
+	}
+}
+
+func TestDiscoverProjectKeys_ShouldSkipDiscoveryWithoutSpec(t *testing.T) {
+	ds := createValidatedDataSource("http://sonar.invalid", "", "app")
+	ds.Status.DiscoveredProjectKeys = []string{"old"}
+
+	assert.NoError(t, DiscoverProjectKeys{}.ServeRequest(ds))
+	assert.Nil(t, ds.Status.DiscoveredProjectKeys)
+	assert.Equal(t, []string{"app"}, getProjectKeys(ds))
+}
+
+func TestDiscoverProjectKeys_ShouldFindKeysByPrefixesAndTags(t *testing.T) {
+	srv := createSonarSearchServer()
+	defer srv.Close()
+	ds := createValidatedDataSource(srv.URL, "", "app")
+	ds.Spec.Discovery = &v1alpha1.SonarProjectDiscovery{
+		KeyPrefixes: []string{"edp"},
+		Tags:        []string{"edp"},
+	}
+
+	assert.NoError(t, createDiscoverProjectKeys().ServeRequest(ds))
+	assert.Equal(t, []string{"edp-app", "tagged"}, ds.Status.DiscoveredProjectKeys)
+	assert.Equal(t, []string{"app", "edp-app", "tagged"}, getProjectKeys(ds))
+}
+
+func TestDiscoverProjectKeys_ShouldFailOnSonarError(t *testing.T) {
+	srv := createSonarSearchServer()
+	defer srv.Close()
+	ds := createValidatedDataSource(srv.URL, "", "app")
+	ds.Spec.Discovery = &v1alpha1.SonarProjectDiscovery{
+		KeyPrefixes: []string{"forbidden"},
+	}
+
+	err := createDiscoverProjectKeys().ServeRequest(ds)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "403")
+	assert.Equal(t, "error", ds.Status.Status)
+}
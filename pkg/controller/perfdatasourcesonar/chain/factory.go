@@ -4,20 +4,34 @@ import (
 	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
 	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf"
 	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfdatasourcesonar/chain/handler"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/tool"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
 )
 
 var log = logf.Log.WithName("perf_data_source_handler")
 
-func CreateDefChain(client client.Client, scheme *runtime.Scheme, perfClient perf.PerfClient) handler.PerfDataSourceSonarHandler {
+func CreateDefChain(client client.Client, scheme *runtime.Scheme, perfClient perf.PerfClient,
+	toolClient tool.Client, recorder record.EventRecorder) handler.PerfDataSourceSonarHandler {
 	return PutOwnerReference{
 		client: client,
 		scheme: scheme,
-		next: PutDataSource{
+		next: ResolveConnection{
 			client:     client,
-			perfClient: perfClient,
+			toolClient: toolClient,
+			next: DiscoverProjectKeys{
+				client: client,
+				next: ValidateProjectKeys{
+					client:   client,
+					recorder: recorder,
+					next: PutDataSource{
+						client:     client,
+						perfClient: perfClient,
+					},
+				},
+			},
 		},
 	}
 }
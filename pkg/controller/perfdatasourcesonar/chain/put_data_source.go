@@ -7,7 +7,6 @@ import (
 	"github.com/epmd-edp/perf-operator/v2/pkg/model/command"
 	"github.com/epmd-edp/perf-operator/v2/pkg/model/dto"
 	"github.com/epmd-edp/perf-operator/v2/pkg/util/cluster"
-	"github.com/epmd-edp/perf-operator/v2/pkg/util/common"
 	"github.com/epmd-edp/perf-operator/v2/pkg/util/datasource"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -29,6 +28,7 @@ func (h PutDataSource) ServeRequest(dataSource *v1alpha1.PerfDataSourceSonar) er
 		return err
 	}
 	setSuccessStatus(dataSource)
+	dataSource.Status.PreviousUrl = ""
 	log.Info("PERF DataSourceSonar has been created.", "name", dataSource.Name)
 	return nil
 }
@@ -42,50 +42,79 @@ func setSuccessStatus(ds *v1alpha1.PerfDataSourceSonar) {
 }
 
 func (h PutDataSource) tryToPutDataSource(dsResource *v1alpha1.PerfDataSourceSonar) error {
-	ps, err := cluster.GetPerfServerCr(h.client, dsResource.Spec.PerfServerName, dsResource.Namespace)
+	ps, err := cluster.GetPerfServerCr(h.client, dsResource.Spec.PerfServerKind,
+		dsResource.Spec.PerfServerName, dsResource.Namespace)
 	if err != nil {
 		return err
 	}
 
-	dsReq, err := h.perfClient.GetProjectDataSource(ps.Spec.ProjectName, dsResource.Spec.Type)
+	projectId, err := cluster.GetPerfProjectId(ps)
+	if err != nil {
+		return err
+	}
+
+	nodeId, err := perf.ResolveNode(h.perfClient, projectId, dsResource.Spec.PerfNode, dsResource.Spec.CreatePerfNode)
+	if err != nil {
+		return err
+	}
+
+	dsReq, err := h.getDataSource(nodeId, dsResource)
 	if err != nil {
 		return err
 	}
 
 	if dsReq != nil {
 		log.Info("PERF Sonar data source already exists. try to update.", "type", dsResource.Spec.Type)
-		if err := h.tryToActivateDataSource(dsReq, ps); err != nil {
+		if err := h.tryToActivateDataSource(dsReq); err != nil {
 			return err
 		}
 		return h.tryToUpdateDataSource(dsResource, dsReq)
 	}
 
-	return h.createDataSource(ps.Spec.ProjectName, dsResource)
+	return h.createDataSource(nodeId, dsResource)
+}
+
+// getDataSource looks the data source up by the url PERF still holds if the url has changed,
+// so it's updated instead of a new one being created.
+func (h PutDataSource) getDataSource(nodeId int, dsResource *v1alpha1.PerfDataSourceSonar) (*dto.DataSource, error) {
+	if dsResource.Status.PreviousUrl != "" {
+		key := getDataSourceKey(dsResource)
+		key.Url = dsResource.Status.PreviousUrl
+		dsReq, err := h.perfClient.GetProjectDataSource(nodeId, key)
+		if err != nil || dsReq != nil {
+			return dsReq, err
+		}
+	}
+	return h.perfClient.GetProjectDataSource(nodeId, getDataSourceKey(dsResource))
 }
 
-func (h PutDataSource) tryToActivateDataSource(dsReq *dto.DataSource, ps *v1alpha1.PerfServer) error {
+func (h PutDataSource) tryToActivateDataSource(dsReq *dto.DataSource) error {
 	if dsReq.Active {
 		log.Info("PERF data source is already activated.", "name", dsReq.Name)
 		return nil
 	}
-	return h.perfClient.ActivateDataSource(ps.Spec.ProjectName, dsReq.Id)
+	return h.perfClient.ActivateDataSource(dsReq.Id)
 }
 
 func (h PutDataSource) tryToUpdateDataSource(dsResource *v1alpha1.PerfDataSourceSonar, dsReq *dto.DataSource) error {
-	diff := getConfigDifference(dsResource, dsReq)
-	if len(diff) == 0 {
+	current, err := command.DecodeSonarConfig(dsReq)
+	if err != nil {
+		return err
+	}
+	diff := getConfigDifference(dsResource, current)
+	if len(diff) == 0 && perf.EqualUrls(current.Url, command.GetSonarUrl(dsResource)) {
 		log.Info("nothing to update in Sonar data source", "name", dsReq.Name)
 		return nil
 	}
 
-	s, err := cluster.GetSecret(h.client, sonarDataSourceSecretName, dsResource.Namespace)
+	s, err := cluster.GetSecret(h.client, getCredentialName(dsResource), dsResource.Namespace)
 	if err != nil {
 		return err
 	}
 
-	dsCommand := command.GetSonarDsUpdateCommand(dsReq, command.DataSourceConfigDto{
+	dsCommand := command.GetSonarDsUpdateCommand(dsReq, current, command.DataSourceConfigDto{
 		Type:       dsReq.Type,
-		ApiUrl:     dsResource.Spec.Config.Url,
+		ApiUrl:     command.GetSonarUrl(dsResource),
 		Username:   string(s.Data["username"]),
 		Password:   string(s.Data["password"]),
 		Parameters: diff,
@@ -93,17 +122,24 @@ func (h PutDataSource) tryToUpdateDataSource(dsResource *v1alpha1.PerfDataSource
 	return h.perfClient.UpdateDataSource(dsCommand)
 }
 
-func getConfigDifference(dsResource *v1alpha1.PerfDataSourceSonar, dsReq *dto.DataSource) []string {
-	conf := common.ConvertToStringArray(dsReq.Config["projectKeys"])
-	return datasource.GetMissingElementsInDataSource(dsResource.Spec.Config.ProjectKeys, conf)
+func getConfigDifference(dsResource *v1alpha1.PerfDataSourceSonar, current command.DataSourceSonarConfig) []string {
+	return datasource.GetMissingElementsInDataSource(getProjectKeys(dsResource), current.ProjectKeys)
 }
 
-func (h PutDataSource) createDataSource(projectName string, dsResource *v1alpha1.PerfDataSourceSonar) error {
-	s, err := cluster.GetSecret(h.client, sonarDataSourceSecretName, dsResource.Namespace)
+func (h PutDataSource) createDataSource(nodeId int, dsResource *v1alpha1.PerfDataSourceSonar) error {
+	s, err := cluster.GetSecret(h.client, getCredentialName(dsResource), dsResource.Namespace)
 	if err != nil {
 		return err
 	}
 
-	dsCommand := command.GetSonarDsCreateCommand(dsResource, string(s.Data["username"]), string(s.Data["password"]))
-	return h.perfClient.CreateDataSource(projectName, dsCommand)
+	dsCommand := command.GetSonarDsCreateCommand(dsResource, getProjectKeys(dsResource), string(s.Data["username"]), string(s.Data["password"]))
+	return h.perfClient.CreateDataSource(nodeId, dsCommand)
+}
+
+func getDataSourceKey(ds *v1alpha1.PerfDataSourceSonar) perf.DataSourceKey {
+	return perf.DataSourceKey{
+		Type: ds.Spec.Type,
+		Name: ds.Spec.Name,
+		Url:  command.GetSonarUrl(ds),
+	}
 }
@@ -51,6 +51,9 @@ func TestPutDataSource_ShouldUpdateSonarDataSourceWithoutActivating(t *testing.T
 		Spec: v1alpha1.PerfServerSpec{
 			ProjectName: fakeName,
 		},
+		Status: v1alpha1.PerfServerStatus{
+			ProjectId: fakeProjectId,
+		},
 	}
 
 	sec := &coreV1.Secret{
@@ -77,7 +80,7 @@ func TestPutDataSource_ShouldUpdateSonarDataSourceWithoutActivating(t *testing.T
 		perfClient: mPerfCl,
 	}
 
-	mPerfCl.On("GetProjectDataSource", fakeName, sonarDsType).
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).
 		Return(&dto.DataSource{
 			Active: true,
 			Type:   sonarDsType,
@@ -130,6 +133,9 @@ func TestPutDataSource_ShouldUpdateSonarDataSourceWithActivating(t *testing.T) {
 		Spec: v1alpha1.PerfServerSpec{
 			ProjectName: fakeName,
 		},
+		Status: v1alpha1.PerfServerStatus{
+			ProjectId: fakeProjectId,
+		},
 	}
 
 	sec := &coreV1.Secret{
@@ -156,7 +162,7 @@ func TestPutDataSource_ShouldUpdateSonarDataSourceWithActivating(t *testing.T) {
 		perfClient: mPerfCl,
 	}
 
-	mPerfCl.On("GetProjectDataSource", fakeName, sonarDsType).
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).
 		Return(&dto.DataSource{
 			Active: false,
 			Type:   sonarDsType,
@@ -176,7 +182,7 @@ func TestPutDataSource_ShouldUpdateSonarDataSourceWithActivating(t *testing.T) {
 		},
 	}).Return(nil)
 
-	mPerfCl.On("ActivateDataSource", fakeName, 0).Return(nil)
+	mPerfCl.On("ActivateDataSource", 0).Return(nil)
 
 	assert.NoError(t, ch.ServeRequest(pds))
 	assert.Equal(t, "created", pds.Status.Status)
@@ -211,6 +217,9 @@ func TestPutDataSource_ShouldCreateSonarDataSource(t *testing.T) {
 		Spec: v1alpha1.PerfServerSpec{
 			ProjectName: fakeName,
 		},
+		Status: v1alpha1.PerfServerStatus{
+			ProjectId: fakeProjectId,
+		},
 	}
 
 	sec := &coreV1.Secret{
@@ -237,9 +246,9 @@ func TestPutDataSource_ShouldCreateSonarDataSource(t *testing.T) {
 		perfClient: mPerfCl,
 	}
 
-	mPerfCl.On("GetProjectDataSource", fakeName, sonarDsType).Return(nil, nil)
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).Return(nil, nil)
 
-	mPerfCl.On("CreateDataSource", fakeName, command.DataSourceCommand{
+	mPerfCl.On("CreateDataSource", fakeProjectId, command.DataSourceCommand{
 		Type: sonarDsType,
 		Config: command.DataSourceSonarConfig{
 			ProjectKeys: []string{fmt.Sprintf("/%v/%v-Build-%v", fakeName, strings.ToUpper(fakeName), fakeName),
@@ -263,6 +272,9 @@ func TestPutDataSource_ShouldNotFindDataSourceInPERF(t *testing.T) {
 		Spec: v1alpha1.PerfServerSpec{
 			ProjectName: fakeName,
 		},
+		Status: v1alpha1.PerfServerStatus{
+			ProjectId: fakeProjectId,
+		},
 	}
 
 	objs := []runtime.Object{
@@ -278,7 +290,7 @@ func TestPutDataSource_ShouldNotFindDataSourceInPERF(t *testing.T) {
 		perfClient: mPerfCl,
 	}
 
-	mPerfCl.On("GetProjectDataSource", fakeName, "").Return(nil, errors.New("failed"))
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).Return(nil, errors.New("failed"))
 
 	pds := &v1alpha1.PerfDataSourceSonar{
 		ObjectMeta: v1.ObjectMeta{
@@ -325,6 +337,9 @@ func TestPutDataSource_ShouldNotActivateDataSource(t *testing.T) {
 		Spec: v1alpha1.PerfServerSpec{
 			ProjectName: fakeName,
 		},
+		Status: v1alpha1.PerfServerStatus{
+			ProjectId: fakeProjectId,
+		},
 	}
 
 	sec := &coreV1.Secret{
@@ -351,7 +366,7 @@ func TestPutDataSource_ShouldNotActivateDataSource(t *testing.T) {
 		perfClient: mPerfCl,
 	}
 
-	mPerfCl.On("GetProjectDataSource", fakeName, sonarDsType).
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).
 		Return(&dto.DataSource{
 			Active: false,
 			Type:   sonarDsType,
@@ -371,7 +386,7 @@ func TestPutDataSource_ShouldNotActivateDataSource(t *testing.T) {
 		},
 	}).Return(nil)
 
-	mPerfCl.On("ActivateDataSource", fakeName, 0).Return(errors.New("failed"))
+	mPerfCl.On("ActivateDataSource", 0).Return(errors.New("failed"))
 
 	assert.Error(t, ch.ServeRequest(pds))
 	assert.Equal(t, "error", pds.Status.Status)
@@ -405,6 +420,9 @@ func TestPutDataSource_ShouldNotUpdateDataSourceBecauseOfMissingNewParameters(t
 		Spec: v1alpha1.PerfServerSpec{
 			ProjectName: fakeName,
 		},
+		Status: v1alpha1.PerfServerStatus{
+			ProjectId: fakeProjectId,
+		},
 	}
 
 	sec := &coreV1.Secret{
@@ -431,12 +449,13 @@ func TestPutDataSource_ShouldNotUpdateDataSourceBecauseOfMissingNewParameters(t
 		perfClient: mPerfCl,
 	}
 
-	mPerfCl.On("GetProjectDataSource", fakeName, sonarDsType).
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).
 		Return(&dto.DataSource{
 			Active: true,
 			Type:   sonarDsType,
 			Config: map[string]interface{}{
 				"projectKeys": []interface{}{fmt.Sprintf("/%v/%v-Build-%v", fakeName, strings.ToUpper(fakeName), fakeName)},
+				"url":         fakeName,
 			},
 		}, nil)
 
@@ -453,3 +472,83 @@ func TestPutDataSource_ShouldNotUpdateDataSourceBecauseOfMissingNewParameters(t
 
 	assert.NoError(t, ch.ServeRequest(pds))
 }
+
+func TestPutDataSource_ShouldUpdateDataSourceFoundByPreviousUrl(t *testing.T) {
+	pds := &v1alpha1.PerfDataSourceSonar{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Spec: v1alpha1.PerfDataSourceSonarSpec{
+			Type: sonarDsType,
+			Config: v1alpha1.DataSourceSonarConfig{
+				ProjectKeys: []string{"app"},
+			},
+			PerfServerName: fakeName,
+			SonarName:      "sonar",
+		},
+		Status: v1alpha1.PerfDataSourceSonarStatus{
+			Url:            "https://sonar.example.com",
+			CredentialName: "sonar-admin-password",
+			PreviousUrl:    "https://old-sonar.example.com",
+		},
+	}
+
+	ps := &v1alpha1.PerfServer{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Status: v1alpha1.PerfServerStatus{
+			ProjectId: fakeProjectId,
+		},
+	}
+
+	sec := &coreV1.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "sonar-admin-password",
+			Namespace: fakeNamespace,
+		},
+		Data: map[string][]byte{
+			"username": []byte("fake"),
+			"password": []byte("fake"),
+		},
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, pds, ps)
+
+	mPerfCl := new(mock.MockPerfClient)
+	ch := PutDataSource{
+		client:     fake.NewFakeClient([]runtime.Object{pds, ps, sec}...),
+		perfClient: mPerfCl,
+	}
+
+	previousKey := getDataSourceKey(pds)
+	previousKey.Url = "https://old-sonar.example.com"
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, previousKey).
+		Return(&dto.DataSource{
+			Id:     2,
+			Active: true,
+			Type:   sonarDsType,
+			Config: map[string]interface{}{
+				"projectKeys": []interface{}{"app"},
+				"url":         "https://old-sonar.example.com/",
+			},
+		}, nil)
+	mPerfCl.On("UpdateDataSource", command.DataSourceCommand{
+		Id:   2,
+		Type: sonarDsType,
+		Config: command.DataSourceSonarConfig{
+			ProjectKeys: []string{"app"},
+			Url:         "https://sonar.example.com",
+			Username:    "fake",
+			Password:    "fake",
+		},
+	}).Return(nil)
+
+	assert.NoError(t, ch.ServeRequest(pds))
+	assert.Equal(t, "created", pds.Status.Status)
+	assert.Empty(t, pds.Status.PreviousUrl)
+	mPerfCl.AssertNotCalled(t, "GetProjectDataSource", fakeProjectId, getDataSourceKey(pds))
+}
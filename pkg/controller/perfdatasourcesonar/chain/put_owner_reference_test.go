@@ -14,6 +14,7 @@ import (
 const (
 	fakeName      = "fake-name"
 	fakeNamespace = "fake-namespace"
+	fakeProjectId = 1
 )
 
 func TestPutOwnerReference_PerfDataSourceContainsPerfServerOwnerReference(t *testing.T) {
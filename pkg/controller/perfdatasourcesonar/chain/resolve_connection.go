@@ -0,0 +1,81 @@
+package chain
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf"
+	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfdatasourcesonar/chain/handler"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/tool"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type ResolveConnection struct {
+	next       handler.PerfDataSourceSonarHandler
+	client     client.Client
+	toolClient tool.Client
+}
+
+func (h ResolveConnection) ServeRequest(dataSource *v1alpha1.PerfDataSourceSonar) error {
+	conn, err := h.resolveConnection(dataSource)
+	if err != nil {
+		setFailedStatus(dataSource)
+		return err
+	}
+	if dataSource.Status.Url != conn.Url || dataSource.Status.CredentialName != conn.CredentialName {
+		log.Info("Sonar connection of data source has been resolved", "name", dataSource.Name,
+			"url", conn.Url, "secret", conn.CredentialName)
+	}
+	if dataSource.Status.Url != "" && !perf.EqualUrls(dataSource.Status.Url, conn.Url) &&
+		dataSource.Status.PreviousUrl == "" {
+		dataSource.Status.PreviousUrl = dataSource.Status.Url
+	}
+	dataSource.Status.Url = conn.Url
+	dataSource.Status.CredentialName = conn.CredentialName
+	return nextServeOrNil(h.next, dataSource)
+}
+
+// resolveConnection takes the url and the credentials set in the spec, the missing ones are resolved
+// from the Sonar CR or the EDPComponent.
+func (h ResolveConnection) resolveConnection(ds *v1alpha1.PerfDataSourceSonar) (tool.Connection, error) {
+	conn := tool.Connection{Url: ds.Spec.Config.Url, CredentialName: ds.Spec.Config.CredentialName}
+	if conn.Url != "" && conn.CredentialName != "" {
+		return conn, nil
+	}
+
+	resolved, err := h.getConnection(ds)
+	if err != nil {
+		return tool.Connection{}, err
+	}
+	if conn.Url == "" {
+		conn.Url = resolved.Url
+	}
+	if conn.CredentialName == "" {
+		conn.CredentialName = resolved.CredentialName
+	}
+	return conn, nil
+}
+
+func (h ResolveConnection) getConnection(ds *v1alpha1.PerfDataSourceSonar) (tool.Connection, error) {
+	switch {
+	case ds.Spec.SonarName != "":
+		return tool.GetOperatorConnection(h.toolClient, h.client, tool.SonarResource, ds.Namespace,
+			ds.Spec.SonarName, tool.PasswordSecretSuffix)
+	case ds.Spec.EdpComponent != "":
+		return tool.GetComponentConnection(h.client, ds.Namespace, ds.Spec.EdpComponent, tool.PasswordSecretSuffix,
+			sonarDataSourceSecretName)
+	case ds.Spec.Config.Url != "":
+		return tool.Connection{CredentialName: sonarDataSourceSecretName}, nil
+	}
+	return tool.Connection{}, errors.Errorf("neither url, Sonar nor EDP component is set in %v data source", ds.Name)
+}
+
+// getCredentialName returns the Secret resolved along with the url or the default one.
+func getCredentialName(ds *v1alpha1.PerfDataSourceSonar) string {
+	if ds.Spec.Config.CredentialName != "" {
+		return ds.Spec.Config.CredentialName
+	}
+	if ds.Status.CredentialName != "" {
+		return ds.Status.CredentialName
+	}
+	return sonarDataSourceSecretName
+}
@@ -0,0 +1,184 @@
+package chain
+
+import (
+	"fmt"
+	edpApi "github.com/epmd-edp/edp-component-operator/pkg/apis/v1/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/command"
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+type stubToolClient struct {
+	tools map[string]*unstructured.Unstructured
+}
+
+func (c stubToolClient) GetTool(resource schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
+	if t, ok := c.tools[name]; ok {
+		return t, nil
+	}
+	return nil, fmt.Errorf("couldn't get %v %v in %v namespace", name, resource.Resource, namespace)
+}
+
+func createUrlDataSource(url, component string) *v1alpha1.PerfDataSourceSonar {
+	return &v1alpha1.PerfDataSourceSonar{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Spec: v1alpha1.PerfDataSourceSonarSpec{
+			Config: v1alpha1.DataSourceSonarConfig{
+				ProjectKeys: []string{"app"},
+				Url:         url,
+			},
+			EdpComponent: component,
+		},
+	}
+}
+
+func createEdpComponent(name, url string) *edpApi.EDPComponent {
+	return &edpApi.EDPComponent{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      name,
+			Namespace: fakeNamespace,
+		},
+		Spec: edpApi.EDPComponentSpec{
+			Url: url,
+		},
+	}
+}
+
+func createResolveConnection() ResolveConnection {
+	comp := createEdpComponent("sonar", "https://sonar.example.com")
+	ciComp := createEdpComponent("ci-sonar", "https://ci-sonar.example.com")
+	ciSecret := &coreV1.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "ci-sonar-admin-password",
+			Namespace: fakeNamespace,
+		},
+	}
+	scheme.Scheme.AddKnownTypes(v1.SchemeGroupVersion, comp)
+
+	exposed := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	_ = unstructured.SetNestedField(exposed.Object, "https://exposed-sonar.example.com", "status", "externalUrl")
+	return ResolveConnection{
+		client: fake.NewFakeClient(comp, ciComp, ciSecret),
+		toolClient: stubToolClient{tools: map[string]*unstructured.Unstructured{
+			"sonar":         {Object: map[string]interface{}{}},
+			"exposed-sonar": exposed,
+		}},
+	}
+}
+
+func TestResolveConnection_ShouldPreferSpecUrl(t *testing.T) {
+	ds := createUrlDataSource("https://own.example.com", "sonar")
+
+	assert.NoError(t, createResolveConnection().ServeRequest(ds))
+	assert.Equal(t, "https://own.example.com", ds.Status.Url)
+	assert.Equal(t, "https://own.example.com", command.GetSonarUrl(ds))
+	assert.Equal(t, sonarDataSourceSecretName, getCredentialName(ds))
+}
+
+func TestResolveConnection_ShouldTakeUrlFromEdpComponent(t *testing.T) {
+	ds := createUrlDataSource("", "sonar")
+
+	assert.NoError(t, createResolveConnection().ServeRequest(ds))
+	assert.Equal(t, "https://sonar.example.com", ds.Status.Url)
+	assert.Equal(t, "https://sonar.example.com", command.GetSonarUrl(ds))
+	assert.Empty(t, ds.Spec.Config.Url)
+	assert.Equal(t, sonarDataSourceSecretName, ds.Status.CredentialName)
+}
+
+func TestResolveConnection_ShouldTakeCredentialsOfEdpComponent(t *testing.T) {
+	ds := createUrlDataSource("", "ci-sonar")
+
+	assert.NoError(t, createResolveConnection().ServeRequest(ds))
+	assert.Equal(t, "https://ci-sonar.example.com", ds.Status.Url)
+	assert.Equal(t, "ci-sonar-admin-password", ds.Status.CredentialName)
+	assert.Equal(t, "ci-sonar-admin-password", getCredentialName(ds))
+}
+
+func TestResolveConnection_ShouldTakeConnectionOfSonar(t *testing.T) {
+	ds := createUrlDataSource("", "ci-sonar")
+	ds.Spec.SonarName = "exposed-sonar"
+
+	assert.NoError(t, createResolveConnection().ServeRequest(ds))
+	assert.Equal(t, "https://exposed-sonar.example.com", ds.Status.Url)
+	assert.Equal(t, "exposed-sonar-admin-password", ds.Status.CredentialName)
+}
+
+func TestResolveConnection_ShouldTakeUrlOfSonarFromItsEdpComponent(t *testing.T) {
+	ds := createUrlDataSource("", "")
+	ds.Spec.SonarName = "sonar"
+
+	assert.NoError(t, createResolveConnection().ServeRequest(ds))
+	assert.Equal(t, "https://sonar.example.com", ds.Status.Url)
+	assert.Equal(t, "sonar-admin-password", ds.Status.CredentialName)
+}
+
+func TestResolveConnection_ShouldPreferSpecCredentials(t *testing.T) {
+	ds := createUrlDataSource("", "")
+	ds.Spec.SonarName = "exposed-sonar"
+	ds.Spec.Config.CredentialName = "own-token"
+
+	assert.NoError(t, createResolveConnection().ServeRequest(ds))
+	assert.Equal(t, "https://exposed-sonar.example.com", ds.Status.Url)
+	assert.Equal(t, "own-token", ds.Status.CredentialName)
+	assert.Equal(t, "own-token", getCredentialName(ds))
+}
+
+func TestResolveConnection_ShouldKeepUrlOfPerfOnChange(t *testing.T) {
+	ds := createUrlDataSource("", "sonar")
+	ds.Status.Url = "https://old-sonar.example.com"
+
+	h := createResolveConnection()
+	assert.NoError(t, h.ServeRequest(ds))
+	assert.Equal(t, "https://sonar.example.com", ds.Status.Url)
+	assert.Equal(t, "https://old-sonar.example.com", ds.Status.PreviousUrl)
+
+	ds.Spec.EdpComponent = "ci-sonar"
+	assert.NoError(t, h.ServeRequest(ds))
+	assert.Equal(t, "https://ci-sonar.example.com", ds.Status.Url)
+	assert.Equal(t, "https://old-sonar.example.com", ds.Status.PreviousUrl)
+}
+
+func TestResolveConnection_ShouldIgnoreTrailingSlashOfUrl(t *testing.T) {
+	ds := createUrlDataSource("", "sonar")
+	ds.Status.Url = "https://sonar.example.com/"
+
+	assert.NoError(t, createResolveConnection().ServeRequest(ds))
+	assert.Empty(t, ds.Status.PreviousUrl)
+}
+
+func TestResolveConnection_ShouldFailWithoutUrlAndEdpComponent(t *testing.T) {
+	ds := createUrlDataSource("", "")
+
+	err := createResolveConnection().ServeRequest(ds)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "neither url, Sonar nor EDP component is set")
+	assert.Equal(t, "error", ds.Status.Status)
+}
+
+func TestResolveConnection_ShouldFailOnMissingEdpComponent(t *testing.T) {
+	ds := createUrlDataSource("", "missing")
+
+	err := createResolveConnection().ServeRequest(ds)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "couldn't get missing EDP component")
+}
+
+func TestResolveConnection_ShouldFailOnMissingSonar(t *testing.T) {
+	ds := createUrlDataSource("", "")
+	ds.Spec.SonarName = "missing"
+
+	err := createResolveConnection().ServeRequest(ds)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "couldn't get missing sonars")
+	assert.Equal(t, "error", ds.Status.Status)
+}
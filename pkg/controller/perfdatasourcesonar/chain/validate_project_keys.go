@@ -0,0 +1,71 @@
+package chain
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/sonar"
+	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfdatasourcesonar/chain/handler"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/command"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/cluster"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/datasource"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type ValidateProjectKeys struct {
+	next     handler.PerfDataSourceSonarHandler
+	client   client.Client
+	recorder record.EventRecorder
+}
+
+const projectKeysValidCondition = "ProjectKeysValid"
+
+func (h ValidateProjectKeys) ServeRequest(dataSource *v1alpha1.PerfDataSourceSonar) error {
+	if dataSource.Spec.Validation == "" {
+		dataSource.Status.UnknownProjectKeys = nil
+		dataSource.Status.Conditions = datasource.RemoveCondition(dataSource.Status.Conditions, projectKeysValidCondition)
+		return nextServeOrNil(h.next, dataSource)
+	}
+
+	log.Info("start validating Sonar project keys", "name", dataSource.Name, "policy", dataSource.Spec.Validation)
+	if err := h.validateProjectKeys(dataSource); err != nil {
+		setFailedStatus(dataSource)
+		return err
+	}
+	log.Info("Sonar project keys have been validated.", "name", dataSource.Name)
+	return nextServeOrNil(h.next, dataSource)
+}
+
+func (h ValidateProjectKeys) validateProjectKeys(ds *v1alpha1.PerfDataSourceSonar) error {
+	s, err := cluster.GetSecret(h.client, getCredentialName(ds), ds.Namespace)
+	if err != nil {
+		return err
+	}
+
+	sc := sonar.NewSonarRestClient(command.GetSonarUrl(ds), string(s.Data["username"]), string(s.Data["password"]))
+	r := datasource.ValidationResult{
+		ConditionType: projectKeysValidCondition,
+		Entries:       "SonarQube project keys",
+	}
+	found, err := sc.GetProjectKeys(ds.Spec.Config.ProjectKeys)
+	if err != nil {
+		r.Err = err
+	} else {
+		r.Unknown = datasource.GetMissingElementsInDataSource(ds.Spec.Config.ProjectKeys, found)
+	}
+
+	ds.Status.UnknownProjectKeys = r.Unknown
+	ds.Status.Conditions = datasource.ReportValidation(h.recorder, ds, ds.Status.Conditions, r)
+	return datasource.EnforceValidation(ds.Spec.Validation, r)
+}
+
+// getProjectKeys returns the project keys along with the discovered ones to be sent to PERF.
+func getProjectKeys(ds *v1alpha1.PerfDataSourceSonar) []string {
+	return datasource.MergeEntries(
+		datasource.GetValidEntries(ds.Spec.Validation, ds.Spec.Config.ProjectKeys, ds.Status.UnknownProjectKeys),
+		ds.Status.DiscoveredProjectKeys)
+}
+
+// HasInvalidEntries reports if the last validation found unknown project keys or couldn't check them.
+func HasInvalidEntries(ds *v1alpha1.PerfDataSourceSonar) bool {
+	return datasource.HasFailedCondition(ds.Status.Conditions, projectKeysValidCondition)
+}
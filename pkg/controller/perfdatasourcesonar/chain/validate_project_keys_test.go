@@ -0,0 +1,99 @@
+package chain
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"net/http"
+	"net/http/httptest"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func createSonarServer(status int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"components":[{"key":"app"}]}`))
+	}))
+}
+
+func createValidatedDataSource(url, policy string, keys ...string) *v1alpha1.PerfDataSourceSonar {
+	return &v1alpha1.PerfDataSourceSonar{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Spec: v1alpha1.PerfDataSourceSonarSpec{
+			Type: sonarDsType,
+			Config: v1alpha1.DataSourceSonarConfig{
+				ProjectKeys: keys,
+				Url:         url,
+			},
+			Validation: policy,
+		},
+	}
+}
+
+func createValidateProjectKeys(recorder record.EventRecorder) ValidateProjectKeys {
+	return ValidateProjectKeys{
+		client: fake.NewFakeClient(&coreV1.Secret{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      sonarDataSourceSecretName,
+				Namespace: fakeNamespace,
+			},
+			Data: map[string][]byte{
+				"username": []byte("fake"),
+				"password": []byte("fake"),
+			},
+		}),
+		recorder: recorder,
+	}
+}
+
+func TestValidateProjectKeys_ShouldWarnAboutUnknownKeys(t *testing.T) {
+	srv := createSonarServer(http.StatusOK)
+	defer srv.Close()
+	ds := createValidatedDataSource(srv.URL, v1alpha1.ValidationWarn, "app", "missing")
+	recorder := record.NewFakeRecorder(10)
+
+	assert.NoError(t, createValidateProjectKeys(recorder).ServeRequest(ds))
+	assert.Equal(t, []string{"missing"}, ds.Status.UnknownProjectKeys)
+	assert.Len(t, ds.Status.Conditions, 1)
+	assert.Equal(t, v1alpha1.ConditionFalse, ds.Status.Conditions[0].Status)
+	assert.Contains(t, ds.Status.Conditions[0].Message, "missing")
+	assert.Contains(t, <-recorder.Events, "UnknownEntries")
+	assert.Equal(t, []string{"app", "missing"}, getProjectKeys(ds))
+	assert.True(t, HasInvalidEntries(ds))
+}
+
+func TestValidateProjectKeys_ShouldSkipUnknownKeys(t *testing.T) {
+	srv := createSonarServer(http.StatusOK)
+	defer srv.Close()
+	ds := createValidatedDataSource(srv.URL, v1alpha1.ValidationSkip, "app", "missing")
+
+	assert.NoError(t, createValidateProjectKeys(record.NewFakeRecorder(10)).ServeRequest(ds))
+	assert.Equal(t, []string{"app"}, getProjectKeys(ds))
+}
+
+func TestValidateProjectKeys_ShouldBlockWhenSonarFails(t *testing.T) {
+	srv := createSonarServer(http.StatusForbidden)
+	defer srv.Close()
+	ds := createValidatedDataSource(srv.URL, v1alpha1.ValidationBlock, "app")
+
+	assert.Error(t, createValidateProjectKeys(record.NewFakeRecorder(10)).ServeRequest(ds))
+	assert.Equal(t, "error", ds.Status.Status)
+	assert.Equal(t, v1alpha1.ConditionUnknown, ds.Status.Conditions[0].Status)
+}
+
+func TestValidateProjectKeys_ShouldRemoveConditionWithoutPolicy(t *testing.T) {
+	ds := createValidatedDataSource("http://sonar.invalid", "", "app", "missing")
+	ds.Status.UnknownProjectKeys = []string{"missing"}
+	ds.Status.Conditions = []v1alpha1.DataSourceCondition{{Type: projectKeysValidCondition, Status: v1alpha1.ConditionFalse}}
+
+	assert.NoError(t, ValidateProjectKeys{}.ServeRequest(ds))
+	assert.Empty(t, ds.Status.Conditions)
+	assert.Nil(t, ds.Status.UnknownProjectKeys)
+	assert.False(t, HasInvalidEntries(ds))
+}
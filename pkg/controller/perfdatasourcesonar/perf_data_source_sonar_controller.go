@@ -3,16 +3,21 @@ package perfdatasourcesonar
 import (
 	"context"
 	v1alpha12 "github.com/epmd-edp/codebase-operator/v2/pkg/apis/edp/v1alpha1"
+	edpCompApi "github.com/epmd-edp/edp-component-operator/pkg/apis/v1/v1alpha1"
 	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
 	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf"
 	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfdatasourcesonar/chain"
 	"github.com/epmd-edp/perf-operator/v2/pkg/util/cluster"
-	"github.com/epmd-edp/perf-operator/v2/pkg/util/common"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/datasource"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/tool"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/watch"
 	"github.com/pkg/errors"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"reflect"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -30,17 +35,29 @@ var (
 	log = logf.Log.WithName("controller_perf_data_source_sonar")
 )
 
+const validationRequeueDelay = 10 * time.Minute
+
 func Add(mgr manager.Manager) error {
-	return add(mgr, newReconciler(mgr))
+	r, err := newReconciler(mgr)
+	if err != nil {
+		return err
+	}
+	return add(mgr, r)
 }
 
-func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+func newReconciler(mgr manager.Manager) (reconcile.Reconciler, error) {
 	scheme := mgr.GetScheme()
 	addKnownTypes(scheme)
-	return &ReconcilePerfDataSourceSonar{
-		client: mgr.GetClient(),
-		scheme: scheme,
+	tc, err := tool.NewClient(mgr.GetConfig())
+	if err != nil {
+		return nil, err
 	}
+	return &ReconcilePerfDataSourceSonar{
+		client:     mgr.GetClient(),
+		scheme:     scheme,
+		recorder:   mgr.GetRecorder("perfdatasourcesonar-controller"),
+		toolClient: tc,
+	}, nil
 }
 
 func addKnownTypes(scheme *runtime.Scheme) {
@@ -53,37 +70,78 @@ func addKnownTypes(scheme *runtime.Scheme) {
 }
 
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
-	c, err := controller.New("perfdatasourcesonar-controller", mgr, controller.Options{Reconciler: r})
+	o, err := watch.GetControllerOptions(mgr, r)
+	if err != nil {
+		return err
+	}
+
+	c, err := controller.New("perfdatasourcesonar-controller", mgr, o)
 	if err != nil {
 		return err
 	}
 
 	p := predicate.Funcs{
+		UpdateFunc: specUpdated,
+	}
+
+	if err = c.Watch(&source.Kind{Type: &v1alpha1.PerfDataSourceSonar{}}, &handler.EnqueueRequestForObject{}, p); err != nil {
+		return err
+	}
+
+	cp := predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
-			oldPk := e.ObjectOld.(*v1alpha1.PerfDataSourceSonar).Spec.Config.ProjectKeys
-			newPk := e.ObjectNew.(*v1alpha1.PerfDataSourceSonar).Spec.Config.ProjectKeys
-			return dataSourceUpdated(oldPk, newPk)
+			return e.ObjectOld.(*edpCompApi.EDPComponent).Spec.Url != e.ObjectNew.(*edpCompApi.EDPComponent).Spec.Url
 		},
 	}
 
-	if err = c.Watch(&source.Kind{Type: &v1alpha1.PerfDataSourceSonar{}}, &handler.EnqueueRequestForObject{}, p); err != nil {
+	cl := mgr.GetClient()
+	if err = c.Watch(&source.Kind{Type: &edpCompApi.EDPComponent{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(func(o handler.MapObject) []reconcile.Request {
+			return getEdpComponentDataSources(cl, o.Meta.GetNamespace(), o.Meta.GetName())
+		}),
+	}, cp); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func dataSourceUpdated(old, new []string) bool {
-	common.SortArray(old)
-	common.SortArray(new)
-	return !reflect.DeepEqual(old, new)
+// specUpdated reports any change of the data source spec, the status updates made by the controller are ignored.
+func specUpdated(e event.UpdateEvent) bool {
+	oldSpec := e.ObjectOld.(*v1alpha1.PerfDataSourceSonar).Spec
+	newSpec := e.ObjectNew.(*v1alpha1.PerfDataSourceSonar).Spec
+	return !reflect.DeepEqual(oldSpec, newSpec)
+}
+
+// getEdpComponentDataSources returns requests for the data sources whose url or credentials are resolved
+// from the named EDPComponent or the Sonar CR it's published for.
+func getEdpComponentDataSources(c client.Client, namespace, name string) []reconcile.Request {
+	list := &v1alpha1.PerfDataSourceSonarList{}
+	if err := c.List(context.TODO(), &client.ListOptions{Namespace: namespace}, list); err != nil {
+		log.Error(err, "couldn't list Sonar data sources", "namespace", namespace)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, ds := range list.Items {
+		if (ds.Spec.EdpComponent == name || ds.Spec.SonarName == name) &&
+			(ds.Spec.Config.Url == "" || ds.Spec.Config.CredentialName == "") {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+				Namespace: ds.Namespace,
+				Name:      ds.Name,
+			}})
+		}
+	}
+	return requests
 }
 
 var _ reconcile.Reconciler = &ReconcilePerfDataSourceSonar{}
 
 type ReconcilePerfDataSourceSonar struct {
-	client client.Client
-	scheme *runtime.Scheme
+	client     client.Client
+	scheme     *runtime.Scheme
+	recorder   record.EventRecorder
+	toolClient tool.Client
 }
 
 func (r *ReconcilePerfDataSourceSonar) Reconcile(request reconcile.Request) (reconcile.Result, error) {
@@ -99,7 +157,12 @@ func (r *ReconcilePerfDataSourceSonar) Reconcile(request reconcile.Request) (rec
 	}
 	defer r.updateStatus(i)
 
-	ps, err := cluster.GetPerfServerCr(r.client, i.Spec.PerfServerName, i.Namespace)
+	discoveryInterval, err := getDiscoveryInterval(i)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	ps, err := cluster.GetPerfServerCr(r.client, i.Spec.PerfServerKind, i.Spec.PerfServerName, i.Namespace)
 	if err != nil {
 		return reconcile.Result{}, errors.Wrapf(err, "couldn't get %v PerfServer from cluster", i.Spec.PerfServerName)
 	}
@@ -109,32 +172,55 @@ func (r *ReconcilePerfDataSourceSonar) Reconcile(request reconcile.Request) (rec
 		return reconcile.Result{RequeueAfter: 2 * time.Minute}, nil
 	}
 
-	pc, err := r.newPerfRestClient(ps.Spec.ApiUrl, ps.Spec.CredentialName, ps.Namespace)
+	pc, err := r.newPerfRestClient(ps)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	if err := chain.CreateDefChain(r.client, r.scheme, pc).ServeRequest(i); err != nil {
+	if err := chain.CreateDefChain(r.client, r.scheme, pc, r.toolClient, r.recorder).ServeRequest(i); err != nil {
 		return reconcile.Result{}, err
 	}
 
+	if chain.HasInvalidEntries(i) && (discoveryInterval == 0 || validationRequeueDelay < discoveryInterval) {
+		rl.Info("Sonar project keys will be validated again", "after", validationRequeueDelay)
+		return reconcile.Result{RequeueAfter: validationRequeueDelay}, nil
+	}
+
+	if discoveryInterval > 0 {
+		rl.Info("Sonar project keys will be discovered again", "after", discoveryInterval)
+		return reconcile.Result{RequeueAfter: discoveryInterval}, nil
+	}
+
 	rl.Info("Reconciling PerfDataSourceSonar has been finished")
 	return reconcile.Result{}, nil
 }
 
+// getDiscoveryInterval returns zero if the discovery isn't enabled.
+func getDiscoveryInterval(ds *v1alpha1.PerfDataSourceSonar) (time.Duration, error) {
+	if ds.Spec.Discovery == nil {
+		return 0, nil
+	}
+	return datasource.GetDiscoveryInterval(ds.Spec.Discovery.Interval)
+}
+
 func (r ReconcilePerfDataSourceSonar) updateStatus(ds *v1alpha1.PerfDataSourceSonar) {
 	if err := r.client.Status().Update(context.TODO(), ds); err != nil {
 		_ = r.client.Update(context.TODO(), ds)
 	}
 }
 
-func (r ReconcilePerfDataSourceSonar) newPerfRestClient(url, secretName, namespace string) (*perf.PerfClientAdapter, error) {
-	credentials, err := perf.GetPerfCredentials(r.client, secretName, namespace)
+func (r ReconcilePerfDataSourceSonar) newPerfRestClient(ps *v1alpha1.PerfServer) (*perf.PerfClientAdapter, error) {
+	transport, err := perf.GetTransport(r.client, ps)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := perf.GetAuthProvider(r.client, ps, transport)
 	if err != nil {
 		return nil, err
 	}
 
-	perfClient, err := perf.NewRestClient(url, credentials.Username, credentials.Password, credentials.LuminateToken)
+	perfClient, err := perf.NewRestClient(ps.Spec.ApiUrl, provider, transport)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,108 @@
+package chain
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfdatasourcetekton/chain/handler"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/cluster"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/tekton"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"time"
+)
+
+type CollectPipelineRuns struct {
+	next              handler.PerfDataSourceTektonHandler
+	client            client.Client
+	pipelineRunClient tekton.PipelineRunClient
+}
+
+const defaultMetricsWindow = 7 * 24 * time.Hour
+
+func (h CollectPipelineRuns) ServeRequest(ds *v1alpha1.PerfDataSourceTekton) error {
+	log.Info("start collecting Tekton PipelineRuns", "name", ds.Name)
+	if err := h.collect(ds); err != nil {
+		setFailedStatus(ds)
+		return err
+	}
+	log.Info("Tekton PipelineRuns have been collected", "name", ds.Name, "codebases", len(ds.Status.Codebases))
+	return nextServeOrNil(h.next, ds)
+}
+
+func (h CollectPipelineRuns) collect(ds *v1alpha1.PerfDataSourceTekton) error {
+	window, err := getMetricsWindow(ds)
+	if err != nil {
+		return err
+	}
+
+	codebases, err := h.getCodebaseNames(ds)
+	if err != nil {
+		return err
+	}
+
+	prSelector, err := toSelector(ds.Spec.Config.PipelineRunSelector)
+	if err != nil {
+		return errors.Wrap(err, "couldn't parse PipelineRun selector")
+	}
+
+	items, err := h.pipelineRunClient.ListPipelineRuns(ds.Namespace, prSelector.String())
+	if err != nil {
+		return err
+	}
+
+	codebaseLabel := ds.Spec.Config.CodebaseLabel
+	if codebaseLabel == "" {
+		codebaseLabel = tekton.DefaultCodebaseLabel
+	}
+
+	var runs []tekton.PipelineRun
+	for _, i := range items {
+		pr, err := tekton.ConvertPipelineRun(i, codebaseLabel)
+		if err != nil {
+			return err
+		}
+		if _, ok := codebases[pr.Codebase]; ok {
+			runs = append(runs, *pr)
+		}
+	}
+
+	ds.Status.Codebases = tekton.CalculateMetrics(runs, time.Now().Add(-window))
+	return nil
+}
+
+func (h CollectPipelineRuns) getCodebaseNames(ds *v1alpha1.PerfDataSourceTekton) (map[string]struct{}, error) {
+	selector, err := toSelector(ds.Spec.Config.CodebaseSelector)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't parse Codebase selector")
+	}
+
+	cbs, err := cluster.GetCodebases(h.client, ds.Namespace, selector)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't get codebases in %v namespace", ds.Namespace)
+	}
+
+	names := make(map[string]struct{}, len(cbs))
+	for _, c := range cbs {
+		names[c.Name] = struct{}{}
+	}
+	return names, nil
+}
+
+func toSelector(ls *metav1.LabelSelector) (labels.Selector, error) {
+	if ls == nil {
+		return labels.Everything(), nil
+	}
+	return metav1.LabelSelectorAsSelector(ls)
+}
+
+func getMetricsWindow(ds *v1alpha1.PerfDataSourceTekton) (time.Duration, error) {
+	if ds.Spec.Config.Window == "" {
+		return defaultMetricsWindow, nil
+	}
+	d, err := time.ParseDuration(ds.Spec.Config.Window)
+	if err != nil {
+		return 0, errors.Wrapf(err, "couldn't parse %v metrics window", ds.Spec.Config.Window)
+	}
+	return d, nil
+}
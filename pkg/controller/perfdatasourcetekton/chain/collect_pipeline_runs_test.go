@@ -0,0 +1,127 @@
+package chain
+
+import (
+	"errors"
+	codebaseApi "github.com/epmd-edp/codebase-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+	"time"
+)
+
+type stubPipelineRunClient struct {
+	items []unstructured.Unstructured
+	err   error
+}
+
+func (c stubPipelineRunClient) ListPipelineRuns(namespace, selector string) ([]unstructured.Unstructured, error) {
+	return c.items, c.err
+}
+
+func createPipelineRun(codebase, status string, start time.Time, duration time.Duration) unstructured.Unstructured {
+	return unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"name": codebase + "-run",
+				"labels": map[string]interface{}{
+					"app.edp.epam.com/codebase": codebase,
+				},
+			},
+			"status": map[string]interface{}{
+				"startTime":      start.Format(time.RFC3339),
+				"completionTime": start.Add(duration).Format(time.RFC3339),
+				"conditions": []interface{}{
+					map[string]interface{}{
+						"type":   "Succeeded",
+						"status": status,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestCollectPipelineRuns_ShouldCalculateMetrics(t *testing.T) {
+	cb := &codebaseApi.Codebase{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+	}
+	cbl := &codebaseApi.CodebaseList{}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, cb, cbl)
+
+	start := time.Now().Add(-time.Hour)
+	ch := CollectPipelineRuns{
+		client: fake.NewFakeClient([]runtime.Object{cb}...),
+		pipelineRunClient: stubPipelineRunClient{
+			items: []unstructured.Unstructured{
+				createPipelineRun(fakeName, "True", start, 2*time.Minute),
+				createPipelineRun(fakeName, "False", start, 4*time.Minute),
+				createPipelineRun(fakeName, "Unknown", start, time.Minute),
+				createPipelineRun("unknown-codebase", "True", start, time.Minute),
+				createPipelineRun(fakeName, "True", start.Add(-30*24*time.Hour), time.Minute),
+			},
+		},
+	}
+
+	pds := &v1alpha1.PerfDataSourceTekton{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace: fakeNamespace,
+		},
+	}
+
+	assert.NoError(t, ch.ServeRequest(pds))
+	assert.Equal(t, []v1alpha1.PipelineRunMetrics{
+		{
+			Codebase:               fakeName,
+			Total:                  2,
+			Succeeded:              1,
+			Failed:                 1,
+			SuccessRate:            50,
+			AverageDurationSeconds: 180,
+		},
+	}, pds.Status.Codebases)
+}
+
+func TestCollectPipelineRuns_ShouldFailOnListError(t *testing.T) {
+	cbl := &codebaseApi.CodebaseList{}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, &codebaseApi.Codebase{}, cbl)
+
+	ch := CollectPipelineRuns{
+		client:            fake.NewFakeClient(),
+		pipelineRunClient: stubPipelineRunClient{err: errors.New("failed")},
+	}
+
+	pds := &v1alpha1.PerfDataSourceTekton{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace: fakeNamespace,
+		},
+	}
+
+	assert.Error(t, ch.ServeRequest(pds))
+	assert.Equal(t, "error", pds.Status.Status)
+}
+
+func TestCollectPipelineRuns_ShouldFailOnInvalidWindow(t *testing.T) {
+	ch := CollectPipelineRuns{}
+
+	pds := &v1alpha1.PerfDataSourceTekton{
+		Spec: v1alpha1.PerfDataSourceTektonSpec{
+			Config: v1alpha1.DataSourceTektonConfig{
+				Window: "week",
+			},
+		},
+	}
+
+	assert.Error(t, ch.ServeRequest(pds))
+}
@@ -0,0 +1,41 @@
+package chain
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf"
+	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfdatasourcetekton/chain/handler"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/tekton"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+var log = logf.Log.WithName("perf_data_source_tekton_handler")
+
+func CreateDefChain(client client.Client, scheme *runtime.Scheme, perfClient perf.PerfClient,
+	pipelineRunClient tekton.PipelineRunClient) handler.PerfDataSourceTektonHandler {
+	return PutOwnerReference{
+		client: client,
+		scheme: scheme,
+		next: CollectPipelineRuns{
+			client:            client,
+			pipelineRunClient: pipelineRunClient,
+			next: PutDataSource{
+				client:     client,
+				perfClient: perfClient,
+				next: PushMetrics{
+					client:     client,
+					perfClient: perfClient,
+				},
+			},
+		},
+	}
+}
+
+func nextServeOrNil(next handler.PerfDataSourceTektonHandler, ds *v1alpha1.PerfDataSourceTekton) error {
+	if next != nil {
+		return next.ServeRequest(ds)
+	}
+	log.Info("handling of perf Tekton data source has been finished", "name", ds.Name)
+	return nil
+}
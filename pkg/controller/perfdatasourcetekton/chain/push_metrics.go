@@ -0,0 +1,26 @@
+package chain
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf"
+	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfdatasourcetekton/chain/handler"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/datasource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type PushMetrics struct {
+	next       handler.PerfDataSourceTektonHandler
+	client     client.Client
+	perfClient perf.PerfClient
+}
+
+func (h PushMetrics) ServeRequest(ds *v1alpha1.PerfDataSourceTekton) error {
+	log.Info("start pushing Tekton metrics to PERF", "name", ds.Name)
+	if err := datasource.PushSourceMetrics(h.client, h.perfClient, getMetricsSource(ds), ds.Status.Codebases); err != nil {
+		setFailedStatus(ds)
+		return err
+	}
+	setSuccessStatus(ds)
+	log.Info("Tekton metrics have been pushed to PERF", "name", ds.Name)
+	return nextServeOrNil(h.next, ds)
+}
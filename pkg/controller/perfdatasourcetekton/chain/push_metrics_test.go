@@ -0,0 +1,53 @@
+package chain
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf/mock"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/dto"
+	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func TestPushMetrics_ShouldPushMetrics(t *testing.T) {
+	pds := createTektonDataSource()
+	ps := createPerfServer()
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, pds, ps)
+
+	mPerfCl := new(mock.MockPerfClient)
+	ch := PushMetrics{
+		client:     fake.NewFakeClient([]runtime.Object{pds, ps}...),
+		perfClient: mPerfCl,
+	}
+
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).
+		Return(&dto.DataSource{Id: 1, Type: tektonDsType}, nil)
+	mPerfCl.On("PushDataSourceMetrics", testifyMock.AnythingOfType("command.DataSourceMetricsCommand")).Return(nil)
+
+	assert.NoError(t, ch.ServeRequest(pds))
+	assert.Equal(t, "created", pds.Status.Status)
+}
+
+func TestPushMetrics_ShouldFailWhenDataSourceIsMissing(t *testing.T) {
+	pds := createTektonDataSource()
+	ps := createPerfServer()
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, pds, ps)
+
+	mPerfCl := new(mock.MockPerfClient)
+	ch := PushMetrics{
+		client:     fake.NewFakeClient([]runtime.Object{pds, ps}...),
+		perfClient: mPerfCl,
+	}
+
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).Return(nil, nil)
+
+	assert.Error(t, ch.ServeRequest(pds))
+	assert.Equal(t, "error", pds.Status.Status)
+}
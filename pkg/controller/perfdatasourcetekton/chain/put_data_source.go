@@ -0,0 +1,79 @@
+package chain
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf"
+	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfdatasourcetekton/chain/handler"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/command"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/dto"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/datasource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type PutDataSource struct {
+	next       handler.PerfDataSourceTektonHandler
+	client     client.Client
+	perfClient perf.PerfClient
+}
+
+func (h PutDataSource) ServeRequest(dataSource *v1alpha1.PerfDataSourceTekton) error {
+	log.Info("start creating/updating Tekton data source in PERF", "name", dataSource.Name)
+	if err := h.tryToPutDataSource(dataSource); err != nil {
+		setFailedStatus(dataSource)
+		return err
+	}
+	log.Info("PERF DataSourceTekton has been created.", "name", dataSource.Name)
+	return nextServeOrNil(h.next, dataSource)
+}
+
+func setFailedStatus(ds *v1alpha1.PerfDataSourceTekton) {
+	ds.Status.Status = "error"
+}
+
+func setSuccessStatus(ds *v1alpha1.PerfDataSourceTekton) {
+	ds.Status.Status = "created"
+}
+
+func (h PutDataSource) tryToPutDataSource(dsResource *v1alpha1.PerfDataSourceTekton) error {
+	codebases := getCodebases(dsResource)
+	return datasource.PutMetricsSource(h.client, h.perfClient, getMetricsSource(dsResource),
+		command.GetTektonDsCreateCommand(dsResource, codebases),
+		func(dsReq *dto.DataSource) (*command.DataSourceCommand, error) {
+			current, err := command.DecodeTektonConfig(dsReq)
+			if err != nil {
+				return nil, err
+			}
+			diff := datasource.GetMissingElementsInDataSource(codebases, current.Codebases)
+			if len(diff) == 0 {
+				return nil, nil
+			}
+			cmd := command.GetTektonDsUpdateCommand(dsReq, current, diff)
+			return &cmd, nil
+		})
+}
+
+func getCodebases(ds *v1alpha1.PerfDataSourceTekton) []string {
+	codebases := make([]string, 0, len(ds.Status.Codebases))
+	for _, m := range ds.Status.Codebases {
+		codebases = append(codebases, m.Codebase)
+	}
+	return codebases
+}
+
+func getMetricsSource(ds *v1alpha1.PerfDataSourceTekton) datasource.MetricsSource {
+	return datasource.MetricsSource{
+		Namespace:      ds.Namespace,
+		PerfServerKind: ds.Spec.PerfServerKind,
+		PerfServerName: ds.Spec.PerfServerName,
+		PerfNode:       ds.Spec.PerfNode,
+		CreatePerfNode: ds.Spec.CreatePerfNode,
+		Key:            getDataSourceKey(ds),
+	}
+}
+
+func getDataSourceKey(ds *v1alpha1.PerfDataSourceTekton) perf.DataSourceKey {
+	return perf.DataSourceKey{
+		Type: ds.Spec.Type,
+		Name: ds.Spec.Name,
+	}
+}
@@ -0,0 +1,128 @@
+package chain
+
+import (
+	"errors"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf/mock"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/command"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/dto"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+const tektonDsType = "CUSTOM"
+
+func createTektonDataSource() *v1alpha1.PerfDataSourceTekton {
+	return &v1alpha1.PerfDataSourceTekton{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Spec: v1alpha1.PerfDataSourceTektonSpec{
+			Type:           tektonDsType,
+			PerfServerName: fakeName,
+		},
+		Status: v1alpha1.PerfDataSourceTektonStatus{
+			Codebases: []v1alpha1.PipelineRunMetrics{
+				{Codebase: "cb1"},
+				{Codebase: "cb2"},
+			},
+		},
+	}
+}
+
+func createPerfServer() *v1alpha1.PerfServer {
+	return &v1alpha1.PerfServer{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Spec: v1alpha1.PerfServerSpec{
+			ProjectName: fakeName,
+		},
+		Status: v1alpha1.PerfServerStatus{
+			ProjectId: fakeProjectId,
+		},
+	}
+}
+
+func TestPutDataSource_ShouldCreateTektonDataSource(t *testing.T) {
+	pds := createTektonDataSource()
+	ps := createPerfServer()
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, pds, ps)
+
+	mPerfCl := new(mock.MockPerfClient)
+	ch := PutDataSource{
+		client:     fake.NewFakeClient([]runtime.Object{pds, ps}...),
+		perfClient: mPerfCl,
+	}
+
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).Return(nil, nil)
+	mPerfCl.On("CreateDataSource", fakeProjectId, command.DataSourceCommand{
+		Type: tektonDsType,
+		Config: command.DataSourceTektonConfig{
+			Codebases: []string{"cb1", "cb2"},
+		},
+	}).Return(nil)
+
+	assert.NoError(t, ch.ServeRequest(pds))
+}
+
+func TestPutDataSource_ShouldActivateAndUpdateTektonDataSource(t *testing.T) {
+	pds := createTektonDataSource()
+	ps := createPerfServer()
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, pds, ps)
+
+	mPerfCl := new(mock.MockPerfClient)
+	ch := PutDataSource{
+		client:     fake.NewFakeClient([]runtime.Object{pds, ps}...),
+		perfClient: mPerfCl,
+	}
+
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).
+		Return(&dto.DataSource{
+			Id:     1,
+			Active: false,
+			Type:   tektonDsType,
+			Config: map[string]interface{}{
+				"codebases": []interface{}{"cb1"},
+			},
+		}, nil)
+	mPerfCl.On("ActivateDataSource", 1).Return(nil)
+	mPerfCl.On("UpdateDataSource", command.DataSourceCommand{
+		Id:   1,
+		Type: tektonDsType,
+		Config: command.DataSourceTektonConfig{
+			Codebases: []string{"cb1", "cb2"},
+		},
+	}).Return(nil)
+
+	assert.NoError(t, ch.ServeRequest(pds))
+}
+
+func TestPutDataSource_ShouldFailOnPerfError(t *testing.T) {
+	pds := createTektonDataSource()
+	ps := createPerfServer()
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, pds, ps)
+
+	mPerfCl := new(mock.MockPerfClient)
+	ch := PutDataSource{
+		client:     fake.NewFakeClient([]runtime.Object{pds, ps}...),
+		perfClient: mPerfCl,
+	}
+
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pds)).Return(nil, errors.New("failed"))
+
+	assert.Error(t, ch.ServeRequest(pds))
+	assert.Equal(t, "error", pds.Status.Status)
+}
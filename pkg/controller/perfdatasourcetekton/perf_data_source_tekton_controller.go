@@ -0,0 +1,176 @@
+package perfdatasourcetekton
+
+import (
+	"context"
+	v1alpha12 "github.com/epmd-edp/codebase-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf"
+	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfdatasourcetekton/chain"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/cluster"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/tekton"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/watch"
+	"github.com/pkg/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"time"
+)
+
+const defaultCollectInterval = 15 * time.Minute
+
+var (
+	log = logf.Log.WithName("controller_perf_data_source_tekton")
+)
+
+func Add(mgr manager.Manager) error {
+	r, err := newReconciler(mgr)
+	if err != nil {
+		return err
+	}
+	return add(mgr, r)
+}
+
+func newReconciler(mgr manager.Manager) (reconcile.Reconciler, error) {
+	scheme := mgr.GetScheme()
+	addKnownTypes(scheme)
+	prc, err := tekton.NewPipelineRunClient(mgr.GetConfig())
+	if err != nil {
+		return nil, err
+	}
+	return &ReconcilePerfDataSourceTekton{
+		client:            mgr.GetClient(),
+		scheme:            scheme,
+		pipelineRunClient: prc,
+	}, nil
+}
+
+func addKnownTypes(scheme *runtime.Scheme) {
+	schemeGroupVersion := schema.GroupVersion{Group: "v2.edp.epam.com", Version: "v1alpha1"}
+	scheme.AddKnownTypes(schemeGroupVersion,
+		&v1alpha12.Codebase{},
+		&v1alpha12.CodebaseList{},
+	)
+	metav1.AddToGroupVersion(scheme, schemeGroupVersion)
+}
+
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	o, err := watch.GetControllerOptions(mgr, r)
+	if err != nil {
+		return err
+	}
+
+	c, err := controller.New("perfdatasourcetekton-controller", mgr, o)
+	if err != nil {
+		return err
+	}
+
+	p := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldSpec := e.ObjectOld.(*v1alpha1.PerfDataSourceTekton).Spec
+			newSpec := e.ObjectNew.(*v1alpha1.PerfDataSourceTekton).Spec
+			return !reflect.DeepEqual(oldSpec, newSpec)
+		},
+	}
+
+	if err = c.Watch(&source.Kind{Type: &v1alpha1.PerfDataSourceTekton{}}, &handler.EnqueueRequestForObject{}, p); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcilePerfDataSourceTekton{}
+
+type ReconcilePerfDataSourceTekton struct {
+	client            client.Client
+	scheme            *runtime.Scheme
+	pipelineRunClient tekton.PipelineRunClient
+}
+
+func (r *ReconcilePerfDataSourceTekton) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	rl := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	rl.V(2).Info("Reconciling PerfDataSourceTekton")
+
+	i := &v1alpha1.PerfDataSourceTekton{}
+	if err := r.client.Get(context.TODO(), request.NamespacedName, i); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	defer r.updateStatus(i)
+
+	interval, err := getCollectInterval(i)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	ps, err := cluster.GetPerfServerCr(r.client, i.Spec.PerfServerKind, i.Spec.PerfServerName, i.Namespace)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "couldn't get %v PerfServer from cluster", i.Spec.PerfServerName)
+	}
+
+	if !ps.Status.Available {
+		log.Info("Perf instance is unavailable. skip collecting Tekton metrics", "name", ps.Name)
+		return reconcile.Result{RequeueAfter: 2 * time.Minute}, nil
+	}
+
+	pc, err := r.newPerfRestClient(ps)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err := chain.CreateDefChain(r.client, r.scheme, pc, r.pipelineRunClient).ServeRequest(i); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	rl.Info("Reconciling PerfDataSourceTekton has been finished")
+	return reconcile.Result{RequeueAfter: interval}, nil
+}
+
+func getCollectInterval(ds *v1alpha1.PerfDataSourceTekton) (time.Duration, error) {
+	if ds.Spec.Config.Interval == "" {
+		return defaultCollectInterval, nil
+	}
+	d, err := time.ParseDuration(ds.Spec.Config.Interval)
+	if err != nil {
+		return 0, errors.Wrapf(err, "couldn't parse %v collect interval", ds.Spec.Config.Interval)
+	}
+	return d, nil
+}
+
+func (r ReconcilePerfDataSourceTekton) updateStatus(ds *v1alpha1.PerfDataSourceTekton) {
+	ds.Status.LastTimeUpdated = time.Now()
+	if err := r.client.Status().Update(context.TODO(), ds); err != nil {
+		_ = r.client.Update(context.TODO(), ds)
+	}
+}
+
+func (r ReconcilePerfDataSourceTekton) newPerfRestClient(ps *v1alpha1.PerfServer) (*perf.PerfClientAdapter, error) {
+	transport, err := perf.GetTransport(r.client, ps)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := perf.GetAuthProvider(r.client, ps, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	perfClient, err := perf.NewRestClient(ps.Spec.ApiUrl, provider, transport)
+	if err != nil {
+		return nil, err
+	}
+	return perfClient, nil
+}
@@ -0,0 +1,99 @@
+package chain
+
+import (
+	"context"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfdorametrics/chain/handler"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/dora"
+	"github.com/pkg/errors"
+	appsV1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"time"
+)
+
+type CalculateDoraMetrics struct {
+	next              handler.PerfDoraMetricsHandler
+	client            client.Client
+	applicationClient dora.ApplicationClient
+}
+
+const defaultMetricsWindow = 30 * 24 * time.Hour
+
+func (h CalculateDoraMetrics) ServeRequest(dm *v1alpha1.PerfDoraMetrics) error {
+	log.Info("start calculating DORA metrics", "name", dm.Name)
+	if err := h.calculate(dm); err != nil {
+		setFailedStatus(dm)
+		return err
+	}
+	log.Info("DORA metrics have been calculated", "name", dm.Name, "stages", len(dm.Status.Stages))
+	return nextServeOrNil(h.next, dm)
+}
+
+func (h CalculateDoraMetrics) calculate(dm *v1alpha1.PerfDoraMetrics) error {
+	window, err := getMetricsWindow(dm)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	since := now.Add(-window)
+	metrics := make([]v1alpha1.DoraStageMetrics, 0, len(dm.Spec.Stages))
+	for _, s := range dm.Spec.Stages {
+		dd, err := h.getDeployments(dm, s)
+		if err != nil {
+			return err
+		}
+		metrics = append(metrics, dora.Calculate(s.Name, dd, since, now))
+	}
+	dm.Status.Stages = metrics
+	return nil
+}
+
+func (h CalculateDoraMetrics) getDeployments(dm *v1alpha1.PerfDoraMetrics, stage v1alpha1.DoraStage) ([]dora.Deployment, error) {
+	if dm.Spec.ArgoCd {
+		ns := dm.Spec.ArgoCdNamespace
+		if ns == "" {
+			ns = dora.DefaultArgoCdNamespace
+		}
+		apps, err := h.applicationClient.ListApplications(ns)
+		if err != nil {
+			return nil, err
+		}
+		return dora.FromApplications(apps, stage.Namespace)
+	}
+
+	rss := &appsV1.ReplicaSetList{}
+	if err := h.client.List(context.TODO(), &client.ListOptions{Namespace: stage.Namespace}, rss); err != nil {
+		return nil, errors.Wrapf(err, "couldn't list ReplicaSets in %v namespace", stage.Namespace)
+	}
+	ds := &appsV1.DeploymentList{}
+	if err := h.client.List(context.TODO(), &client.ListOptions{Namespace: stage.Namespace}, ds); err != nil {
+		return nil, errors.Wrapf(err, "couldn't list Deployments in %v namespace", stage.Namespace)
+	}
+	return dora.FromReplicaSets(rss.Items, ds.Items, getCommitLabel(dm), getCommitTimeAnnotation(dm)), nil
+}
+
+func getCommitLabel(dm *v1alpha1.PerfDoraMetrics) string {
+	if dm.Spec.CommitLabel == "" {
+		return dora.DefaultCommitLabel
+	}
+	return dm.Spec.CommitLabel
+}
+
+func getCommitTimeAnnotation(dm *v1alpha1.PerfDoraMetrics) string {
+	if dm.Spec.CommitTimeAnnotation == "" {
+		return dora.DefaultCommitTimeAnnotation
+	}
+	return dm.Spec.CommitTimeAnnotation
+}
+
+func getMetricsWindow(dm *v1alpha1.PerfDoraMetrics) (time.Duration, error) {
+	if dm.Spec.Window == "" {
+		return defaultMetricsWindow, nil
+	}
+	d, err := time.ParseDuration(dm.Spec.Window)
+	if err != nil {
+		return 0, errors.Wrapf(err, "couldn't parse %v metrics window", dm.Spec.Window)
+	}
+	return d, nil
+}
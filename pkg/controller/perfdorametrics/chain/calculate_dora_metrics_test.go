@@ -0,0 +1,188 @@
+package chain
+
+import (
+	"errors"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	appsV1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+	"time"
+)
+
+type stubApplicationClient struct {
+	items []unstructured.Unstructured
+	err   error
+}
+
+func (c stubApplicationClient) ListApplications(namespace string) ([]unstructured.Unstructured, error) {
+	return c.items, c.err
+}
+
+func createReplicaSet(name string, created, committed time.Time, annotations map[string]string) *appsV1.ReplicaSet {
+	return &appsV1.ReplicaSet{
+		ObjectMeta: v1.ObjectMeta{
+			Name:              name,
+			Namespace:         "edp-dev",
+			CreationTimestamp: v1.NewTime(created),
+			Annotations:       annotations,
+			OwnerReferences: []v1.OwnerReference{
+				{Kind: "Deployment", Name: "app"},
+			},
+		},
+		Spec: appsV1.ReplicaSetSpec{
+			Template: coreV1.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					Labels: map[string]string{
+						"app.edp.epam.com/commit-sha": name,
+					},
+					Annotations: map[string]string{
+						"app.edp.epam.com/commit-timestamp": committed.Format(time.RFC3339),
+					},
+				},
+			},
+		},
+	}
+}
+
+func createRevision(revision string, history string) map[string]string {
+	a := map[string]string{"deployment.kubernetes.io/revision": revision}
+	if history != "" {
+		a["deployment.kubernetes.io/revision-history"] = history
+	}
+	return a
+}
+
+func createDeployment(revision string, progressed time.Time) *appsV1.Deployment {
+	return &appsV1.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "app",
+			Namespace:   "edp-dev",
+			Annotations: createRevision(revision, ""),
+		},
+		Status: appsV1.DeploymentStatus{
+			Conditions: []appsV1.DeploymentCondition{
+				{Type: appsV1.DeploymentProgressing, LastUpdateTime: v1.NewTime(progressed)},
+			},
+		},
+	}
+}
+
+func TestCalculateDoraMetrics_ShouldCalculateFromReplicaSets(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	// app-1 is deployed as revision 1, app-2 as revision 2 and app-1 is rolled back to as revision 3
+	objs := []runtime.Object{
+		createReplicaSet("app-1", now.Add(-3*time.Hour), now.Add(-4*time.Hour), createRevision("3", "1")),
+		createReplicaSet("app-2", now.Add(-2*time.Hour), now.Add(-4*time.Hour), createRevision("2", "")),
+		createDeployment("3", now.Add(-time.Hour)),
+	}
+
+	pdm := &v1alpha1.PerfDoraMetrics{
+		Spec: v1alpha1.PerfDoraMetricsSpec{
+			Stages: []v1alpha1.DoraStage{{Name: "dev", Namespace: "edp-dev"}},
+			Window: "24h",
+		},
+	}
+
+	ch := CalculateDoraMetrics{client: fake.NewFakeClient(objs...)}
+
+	assert.NoError(t, ch.ServeRequest(pdm))
+	assert.Equal(t, []v1alpha1.DoraStageMetrics{
+		{
+			Stage:                    "dev",
+			Deployments:              2,
+			DeploymentFrequency:      "2.00",
+			LeadTimeSeconds:          5400,
+			ChangeFailureRate:        50,
+			MeanTimeToRestoreSeconds: 3600,
+		},
+	}, pdm.Status.Stages)
+}
+
+func TestCalculateDoraMetrics_ShouldCountRollbacksOfUnknownTime(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	// app-1 is rolled back to as revision 3 and replaced with app-3 as revision 4, so the rollback time is unknown
+	objs := []runtime.Object{
+		createReplicaSet("app-1", now.Add(-4*time.Hour), now.Add(-5*time.Hour), createRevision("3", "1")),
+		createReplicaSet("app-2", now.Add(-3*time.Hour), now.Add(-5*time.Hour), createRevision("2", "")),
+		createReplicaSet("app-3", now.Add(-time.Hour), now.Add(-2*time.Hour), createRevision("4", "")),
+		createDeployment("4", now.Add(-time.Hour)),
+	}
+
+	pdm := &v1alpha1.PerfDoraMetrics{
+		Spec: v1alpha1.PerfDoraMetricsSpec{
+			Stages: []v1alpha1.DoraStage{{Name: "dev", Namespace: "edp-dev"}},
+			Window: "24h",
+		},
+	}
+
+	ch := CalculateDoraMetrics{client: fake.NewFakeClient(objs...)}
+
+	assert.NoError(t, ch.ServeRequest(pdm))
+	assert.Equal(t, 3, pdm.Status.Stages[0].Deployments)
+	assert.Equal(t, 33, pdm.Status.Stages[0].ChangeFailureRate)
+	assert.Equal(t, int64(0), pdm.Status.Stages[0].MeanTimeToRestoreSeconds)
+}
+
+func TestCalculateDoraMetrics_ShouldCalculateFromArgoCdHistory(t *testing.T) {
+	now := time.Now()
+	history := []interface{}{
+		map[string]interface{}{"revision": "a", "deployedAt": now.Add(-3 * time.Hour).Format(time.RFC3339)},
+		map[string]interface{}{"revision": "b", "deployedAt": now.Add(-2 * time.Hour).Format(time.RFC3339)},
+		map[string]interface{}{"revision": "a", "deployedAt": now.Add(-time.Hour).Format(time.RFC3339)},
+	}
+	app := unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "app"},
+			"spec": map[string]interface{}{
+				"destination": map[string]interface{}{"namespace": "edp-dev"},
+			},
+			"status": map[string]interface{}{"history": history},
+		},
+	}
+
+	pdm := &v1alpha1.PerfDoraMetrics{
+		Spec: v1alpha1.PerfDoraMetricsSpec{
+			Stages: []v1alpha1.DoraStage{{Name: "dev", Namespace: "edp-dev"}},
+			ArgoCd: true,
+			Window: "24h",
+		},
+	}
+
+	ch := CalculateDoraMetrics{applicationClient: stubApplicationClient{items: []unstructured.Unstructured{app}}}
+
+	assert.NoError(t, ch.ServeRequest(pdm))
+	assert.Equal(t, 2, pdm.Status.Stages[0].Deployments)
+	assert.Equal(t, 50, pdm.Status.Stages[0].ChangeFailureRate)
+	assert.Equal(t, int64(3600), pdm.Status.Stages[0].MeanTimeToRestoreSeconds)
+}
+
+func TestCalculateDoraMetrics_ShouldFailOnListError(t *testing.T) {
+	pdm := &v1alpha1.PerfDoraMetrics{
+		Spec: v1alpha1.PerfDoraMetricsSpec{
+			Stages: []v1alpha1.DoraStage{{Name: "dev", Namespace: "edp-dev"}},
+			ArgoCd: true,
+		},
+	}
+
+	ch := CalculateDoraMetrics{applicationClient: stubApplicationClient{err: errors.New("failed")}}
+
+	assert.Error(t, ch.ServeRequest(pdm))
+	assert.Equal(t, "error", pdm.Status.Status)
+}
+
+func TestCalculateDoraMetrics_ShouldFailOnInvalidWindow(t *testing.T) {
+	ch := CalculateDoraMetrics{}
+
+	pdm := &v1alpha1.PerfDoraMetrics{
+		Spec: v1alpha1.PerfDoraMetricsSpec{
+			Window: "month",
+		},
+	}
+
+	assert.Error(t, ch.ServeRequest(pdm))
+}
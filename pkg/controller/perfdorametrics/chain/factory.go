@@ -0,0 +1,43 @@
+package chain
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf"
+	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfdorametrics/chain/handler"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/dora"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+var log = logf.Log.WithName("perf_dora_metrics_handler")
+
+// CreateDefChain builds the chain for PerfDoraMetrics. stageClient is used to read ReplicaSets
+// from the stage namespaces, which are not watched by the manager's cache.
+func CreateDefChain(client client.Client, stageClient client.Client, scheme *runtime.Scheme, perfClient perf.PerfClient,
+	applicationClient dora.ApplicationClient) handler.PerfDoraMetricsHandler {
+	return PutOwnerReference{
+		client: client,
+		scheme: scheme,
+		next: CalculateDoraMetrics{
+			client:            stageClient,
+			applicationClient: applicationClient,
+			next: PutDataSource{
+				client:     client,
+				perfClient: perfClient,
+				next: PushMetrics{
+					client:     client,
+					perfClient: perfClient,
+				},
+			},
+		},
+	}
+}
+
+func nextServeOrNil(next handler.PerfDoraMetricsHandler, dm *v1alpha1.PerfDoraMetrics) error {
+	if next != nil {
+		return next.ServeRequest(dm)
+	}
+	log.Info("handling of perf DORA metrics has been finished", "name", dm.Name)
+	return nil
+}
@@ -0,0 +1,7 @@
+package handler
+
+import "github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+
+type PerfDoraMetricsHandler interface {
+	ServeRequest(metrics *v1alpha1.PerfDoraMetrics) error
+}
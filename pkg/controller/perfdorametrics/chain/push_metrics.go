@@ -0,0 +1,26 @@
+package chain
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf"
+	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfdorametrics/chain/handler"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/datasource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type PushMetrics struct {
+	next       handler.PerfDoraMetricsHandler
+	client     client.Client
+	perfClient perf.PerfClient
+}
+
+func (h PushMetrics) ServeRequest(dm *v1alpha1.PerfDoraMetrics) error {
+	log.Info("start pushing DORA metrics to PERF", "name", dm.Name)
+	if err := datasource.PushSourceMetrics(h.client, h.perfClient, getMetricsSource(dm), dm.Status.Stages); err != nil {
+		setFailedStatus(dm)
+		return err
+	}
+	setSuccessStatus(dm)
+	log.Info("DORA metrics have been pushed to PERF", "name", dm.Name)
+	return nextServeOrNil(h.next, dm)
+}
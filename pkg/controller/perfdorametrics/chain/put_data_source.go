@@ -0,0 +1,79 @@
+package chain
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf"
+	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfdorametrics/chain/handler"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/command"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/dto"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/datasource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type PutDataSource struct {
+	next       handler.PerfDoraMetricsHandler
+	client     client.Client
+	perfClient perf.PerfClient
+}
+
+func (h PutDataSource) ServeRequest(dm *v1alpha1.PerfDoraMetrics) error {
+	log.Info("start creating/updating DORA data source in PERF", "name", dm.Name)
+	if err := h.tryToPutDataSource(dm); err != nil {
+		setFailedStatus(dm)
+		return err
+	}
+	log.Info("PERF DORA data source has been created.", "name", dm.Name)
+	return nextServeOrNil(h.next, dm)
+}
+
+func setFailedStatus(dm *v1alpha1.PerfDoraMetrics) {
+	dm.Status.Status = "error"
+}
+
+func setSuccessStatus(dm *v1alpha1.PerfDoraMetrics) {
+	dm.Status.Status = "created"
+}
+
+func (h PutDataSource) tryToPutDataSource(dm *v1alpha1.PerfDoraMetrics) error {
+	stages := getStages(dm)
+	return datasource.PutMetricsSource(h.client, h.perfClient, getMetricsSource(dm),
+		command.GetDoraDsCreateCommand(dm, stages),
+		func(dsReq *dto.DataSource) (*command.DataSourceCommand, error) {
+			current, err := command.DecodeDoraConfig(dsReq)
+			if err != nil {
+				return nil, err
+			}
+			diff := datasource.GetMissingElementsInDataSource(stages, current.Stages)
+			if len(diff) == 0 {
+				return nil, nil
+			}
+			cmd := command.GetDoraDsUpdateCommand(dsReq, current, diff)
+			return &cmd, nil
+		})
+}
+
+func getStages(dm *v1alpha1.PerfDoraMetrics) []string {
+	stages := make([]string, 0, len(dm.Spec.Stages))
+	for _, s := range dm.Spec.Stages {
+		stages = append(stages, s.Name)
+	}
+	return stages
+}
+
+func getMetricsSource(dm *v1alpha1.PerfDoraMetrics) datasource.MetricsSource {
+	return datasource.MetricsSource{
+		Namespace:      dm.Namespace,
+		PerfServerKind: dm.Spec.PerfServerKind,
+		PerfServerName: dm.Spec.PerfServerName,
+		PerfNode:       dm.Spec.PerfNode,
+		CreatePerfNode: dm.Spec.CreatePerfNode,
+		Key:            getDataSourceKey(dm),
+	}
+}
+
+func getDataSourceKey(ds *v1alpha1.PerfDoraMetrics) perf.DataSourceKey {
+	return perf.DataSourceKey{
+		Type: ds.Spec.Type,
+		Name: ds.Spec.Name,
+	}
+}
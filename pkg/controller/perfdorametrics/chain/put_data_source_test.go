@@ -0,0 +1,126 @@
+package chain
+
+import (
+	"errors"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf/mock"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/command"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/dto"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+const doraDsType = "CUSTOM"
+
+func createDoraMetrics() *v1alpha1.PerfDoraMetrics {
+	return &v1alpha1.PerfDoraMetrics{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Spec: v1alpha1.PerfDoraMetricsSpec{
+			Type:           doraDsType,
+			PerfServerName: fakeName,
+			Stages: []v1alpha1.DoraStage{
+				{Name: "dev", Namespace: "edp-dev"},
+				{Name: "qa", Namespace: "edp-qa"},
+			},
+		},
+	}
+}
+
+func createPerfServer() *v1alpha1.PerfServer {
+	return &v1alpha1.PerfServer{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Spec: v1alpha1.PerfServerSpec{
+			ProjectName: fakeName,
+		},
+		Status: v1alpha1.PerfServerStatus{
+			ProjectId: fakeProjectId,
+		},
+	}
+}
+
+func TestPutDataSource_ShouldCreateDoraDataSource(t *testing.T) {
+	pdm := createDoraMetrics()
+	ps := createPerfServer()
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, pdm, ps)
+
+	mPerfCl := new(mock.MockPerfClient)
+	ch := PutDataSource{
+		client:     fake.NewFakeClient([]runtime.Object{pdm, ps}...),
+		perfClient: mPerfCl,
+	}
+
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pdm)).Return(nil, nil)
+	mPerfCl.On("CreateDataSource", fakeProjectId, command.DataSourceCommand{
+		Type: doraDsType,
+		Config: command.DataSourceDoraConfig{
+			Stages: []string{"dev", "qa"},
+		},
+	}).Return(nil)
+
+	assert.NoError(t, ch.ServeRequest(pdm))
+}
+
+func TestPutDataSource_ShouldActivateAndUpdateDoraDataSource(t *testing.T) {
+	pdm := createDoraMetrics()
+	ps := createPerfServer()
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, pdm, ps)
+
+	mPerfCl := new(mock.MockPerfClient)
+	ch := PutDataSource{
+		client:     fake.NewFakeClient([]runtime.Object{pdm, ps}...),
+		perfClient: mPerfCl,
+	}
+
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pdm)).
+		Return(&dto.DataSource{
+			Id:     1,
+			Active: false,
+			Type:   doraDsType,
+			Config: map[string]interface{}{
+				"stages": []interface{}{"dev"},
+			},
+		}, nil)
+	mPerfCl.On("ActivateDataSource", 1).Return(nil)
+	mPerfCl.On("UpdateDataSource", command.DataSourceCommand{
+		Id:   1,
+		Type: doraDsType,
+		Config: command.DataSourceDoraConfig{
+			Stages: []string{"dev", "qa"},
+		},
+	}).Return(nil)
+
+	assert.NoError(t, ch.ServeRequest(pdm))
+}
+
+func TestPutDataSource_ShouldFailOnPerfError(t *testing.T) {
+	pdm := createDoraMetrics()
+	ps := createPerfServer()
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, pdm, ps)
+
+	mPerfCl := new(mock.MockPerfClient)
+	ch := PutDataSource{
+		client:     fake.NewFakeClient([]runtime.Object{pdm, ps}...),
+		perfClient: mPerfCl,
+	}
+
+	mPerfCl.On("GetProjectDataSource", fakeProjectId, getDataSourceKey(pdm)).Return(nil, errors.New("failed"))
+
+	assert.Error(t, ch.ServeRequest(pdm))
+	assert.Equal(t, "error", pdm.Status.Status)
+}
@@ -0,0 +1,57 @@
+package chain
+
+import (
+	"context"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfdorametrics/chain/handler"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/cluster"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/consts"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+type PutOwnerReference struct {
+	client client.Client
+	scheme *runtime.Scheme
+	next   handler.PerfDoraMetricsHandler
+}
+
+func (h PutOwnerReference) ServeRequest(dm *v1alpha1.PerfDoraMetrics) error {
+	log.Info("put owner reference for DORA metrics", "name", dm.Name)
+	if err := h.setPerfOwnerRef(dm); err != nil {
+		return err
+	}
+	log.Info("owner ref for perf DORA metrics has been added", "name", dm.Name)
+	return nextServeOrNil(h.next, dm)
+}
+
+func (h PutOwnerReference) setPerfOwnerRef(dm *v1alpha1.PerfDoraMetrics) error {
+	log.Info("try to set owner ref for perf DORA metrics", "name", dm.Name)
+	// a ClusterPerfServer can't own the CRs of the namespaces it serves, they'd be deleted along with it
+	if dm.Spec.PerfServerKind == consts.ClusterPerfServerKind {
+		log.Info("PerfDoraMetrics refers to ClusterPerfServer, owner ref isn't set", "dora metrics", dm.Name)
+		return nil
+	}
+
+	if ow := cluster.GetOwnerReference(consts.PerfServerKind, dm.GetOwnerReferences()); ow != nil {
+		log.Info("PerfDoraMetrics already has owner ref",
+			"dora metrics", dm.Name, "owner name", ow.Name)
+		return nil
+	}
+
+	ps, err := cluster.GetPerfServerCr(h.client, dm.Spec.PerfServerKind, dm.Spec.PerfServerName, dm.Namespace)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't get %v PerfServer from cluster", dm.Spec.PerfServerName)
+	}
+
+	if err := controllerutil.SetControllerReference(ps, dm, h.scheme); err != nil {
+		return errors.Wrapf(err, "couldn't set owner ref for %v PerfDoraMetrics", dm.Name)
+	}
+
+	if err := h.client.Update(context.TODO(), dm); err != nil {
+		return errors.Wrapf(err, "an error has been occurred while updating perf DORA metrics' owner %v", dm.Name)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package chain
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+const (
+	fakeName      = "fake-name"
+	fakeNamespace = "fake-namespace"
+	fakeProjectId = 1
+)
+
+func TestPutOwnerReference_PerfDoraMetricsContainsPerfServerOwnerReference(t *testing.T) {
+	pdm := &v1alpha1.PerfDoraMetrics{
+		ObjectMeta: v1.ObjectMeta{
+			OwnerReferences: []v1.OwnerReference{
+				{
+					Kind: "PerfServer",
+				},
+			},
+		},
+	}
+	ch := PutOwnerReference{}
+	assert.NoError(t, ch.ServeRequest(pdm))
+}
+
+func TestPutOwnerReference_ShouldSetOwnerReference(t *testing.T) {
+	pdm := &v1alpha1.PerfDoraMetrics{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Spec: v1alpha1.PerfDoraMetricsSpec{
+			PerfServerName: fakeName,
+		},
+	}
+
+	ps := &v1alpha1.PerfServer{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+	}
+
+	objs := []runtime.Object{
+		pdm, ps,
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, pdm, ps)
+
+	ch := PutOwnerReference{
+		scheme: s,
+		client: fake.NewFakeClient(objs...),
+	}
+	assert.NoError(t, ch.ServeRequest(pdm))
+	assert.Equal(t, fakeName, pdm.OwnerReferences[0].Name)
+}
+
+func TestPutOwnerReference_PerfServerShouldNotBeFound(t *testing.T) {
+	pdm := &v1alpha1.PerfDoraMetrics{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace: fakeNamespace,
+		},
+		Spec: v1alpha1.PerfDoraMetricsSpec{
+			PerfServerName: fakeName,
+		},
+	}
+
+	objs := []runtime.Object{
+		pdm,
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, pdm)
+
+	ch := PutOwnerReference{
+		scheme: s,
+		client: fake.NewFakeClient(objs...),
+	}
+	assert.Error(t, ch.ServeRequest(pdm))
+}
@@ -0,0 +1,169 @@
+package perfdorametrics
+
+import (
+	"context"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf"
+	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfdorametrics/chain"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/cluster"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/dora"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/watch"
+	"github.com/pkg/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"time"
+)
+
+const defaultCalculateInterval = time.Hour
+
+var (
+	log = logf.Log.WithName("controller_perf_dora_metrics")
+)
+
+func Add(mgr manager.Manager) error {
+	r, err := newReconciler(mgr)
+	if err != nil {
+		return err
+	}
+	return add(mgr, r)
+}
+
+func newReconciler(mgr manager.Manager) (reconcile.Reconciler, error) {
+	// stage namespaces differ from the watched one, so ReplicaSets are read bypassing the manager's cache
+	sc, err := client.New(mgr.GetConfig(), client.Options{Scheme: mgr.GetScheme()})
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't create client for stage namespaces")
+	}
+	ac, err := dora.NewApplicationClient(mgr.GetConfig())
+	if err != nil {
+		return nil, err
+	}
+	return &ReconcilePerfDoraMetrics{
+		client:            mgr.GetClient(),
+		stageClient:       sc,
+		scheme:            mgr.GetScheme(),
+		applicationClient: ac,
+	}, nil
+}
+
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	o, err := watch.GetControllerOptions(mgr, r)
+	if err != nil {
+		return err
+	}
+
+	c, err := controller.New("perfdorametrics-controller", mgr, o)
+	if err != nil {
+		return err
+	}
+
+	p := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldSpec := e.ObjectOld.(*v1alpha1.PerfDoraMetrics).Spec
+			newSpec := e.ObjectNew.(*v1alpha1.PerfDoraMetrics).Spec
+			return !reflect.DeepEqual(oldSpec, newSpec)
+		},
+	}
+
+	if err = c.Watch(&source.Kind{Type: &v1alpha1.PerfDoraMetrics{}}, &handler.EnqueueRequestForObject{}, p); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcilePerfDoraMetrics{}
+
+type ReconcilePerfDoraMetrics struct {
+	client            client.Client
+	stageClient       client.Client
+	scheme            *runtime.Scheme
+	applicationClient dora.ApplicationClient
+}
+
+func (r *ReconcilePerfDoraMetrics) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	rl := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	rl.V(2).Info("Reconciling PerfDoraMetrics")
+
+	i := &v1alpha1.PerfDoraMetrics{}
+	if err := r.client.Get(context.TODO(), request.NamespacedName, i); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	defer r.updateStatus(i)
+
+	interval, err := getCalculateInterval(i)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	ps, err := cluster.GetPerfServerCr(r.client, i.Spec.PerfServerKind, i.Spec.PerfServerName, i.Namespace)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "couldn't get %v PerfServer from cluster", i.Spec.PerfServerName)
+	}
+
+	if !ps.Status.Available {
+		log.Info("Perf instance is unavailable. skip calculating DORA metrics", "name", ps.Name)
+		return reconcile.Result{RequeueAfter: 2 * time.Minute}, nil
+	}
+
+	pc, err := r.newPerfRestClient(ps)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err := chain.CreateDefChain(r.client, r.stageClient, r.scheme, pc, r.applicationClient).ServeRequest(i); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	rl.Info("Reconciling PerfDoraMetrics has been finished")
+	return reconcile.Result{RequeueAfter: interval}, nil
+}
+
+func getCalculateInterval(dm *v1alpha1.PerfDoraMetrics) (time.Duration, error) {
+	if dm.Spec.Interval == "" {
+		return defaultCalculateInterval, nil
+	}
+	d, err := time.ParseDuration(dm.Spec.Interval)
+	if err != nil {
+		return 0, errors.Wrapf(err, "couldn't parse %v calculate interval", dm.Spec.Interval)
+	}
+	return d, nil
+}
+
+func (r ReconcilePerfDoraMetrics) updateStatus(dm *v1alpha1.PerfDoraMetrics) {
+	dm.Status.LastTimeUpdated = time.Now()
+	if err := r.client.Status().Update(context.TODO(), dm); err != nil {
+		_ = r.client.Update(context.TODO(), dm)
+	}
+}
+
+func (r ReconcilePerfDoraMetrics) newPerfRestClient(ps *v1alpha1.PerfServer) (*perf.PerfClientAdapter, error) {
+	transport, err := perf.GetTransport(r.client, ps)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := perf.GetAuthProvider(r.client, ps, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	perfClient, err := perf.NewRestClient(ps.Spec.ApiUrl, provider, transport)
+	if err != nil {
+		return nil, err
+	}
+	return perfClient, nil
+}
@@ -0,0 +1,31 @@
+package chain
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf"
+	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfreport/chain/handler"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+var log = logf.Log.WithName("perf_report_handler")
+
+func CreateDefChain(client client.Client, scheme *runtime.Scheme, perfClient perf.PerfClient) handler.PerfReportHandler {
+	return PutOwnerReference{
+		client: client,
+		scheme: scheme,
+		next: TakeKpiSnapshot{
+			client:     client,
+			perfClient: perfClient,
+		},
+	}
+}
+
+func nextServeOrNil(next handler.PerfReportHandler, report *v1alpha1.PerfReport) error {
+	if next != nil {
+		return next.ServeRequest(report)
+	}
+	log.Info("handling of perf report has been finished", "name", report.Name)
+	return nil
+}
@@ -0,0 +1,57 @@
+package chain
+
+import (
+	"context"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfreport/chain/handler"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/cluster"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/consts"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+type PutOwnerReference struct {
+	client client.Client
+	scheme *runtime.Scheme
+	next   handler.PerfReportHandler
+}
+
+func (h PutOwnerReference) ServeRequest(r *v1alpha1.PerfReport) error {
+	log.Info("put owner reference for report", "name", r.Name)
+	if err := h.setPerfOwnerRef(r); err != nil {
+		return err
+	}
+	log.Info("owner ref for perf report has been added", "name", r.Name)
+	return nextServeOrNil(h.next, r)
+}
+
+func (h PutOwnerReference) setPerfOwnerRef(r *v1alpha1.PerfReport) error {
+	log.Info("try to set owner ref for perf report", "name", r.Name)
+	// a ClusterPerfServer can't own the CRs of the namespaces it serves, they'd be deleted along with it
+	if r.Spec.PerfServerKind == consts.ClusterPerfServerKind {
+		log.Info("PerfReport refers to ClusterPerfServer, owner ref isn't set", "report", r.Name)
+		return nil
+	}
+
+	if ow := cluster.GetOwnerReference(consts.PerfServerKind, r.GetOwnerReferences()); ow != nil {
+		log.Info("PerfReport already has owner ref",
+			"report", r.Name, "owner name", ow.Name)
+		return nil
+	}
+
+	ps, err := cluster.GetPerfServerCr(h.client, r.Spec.PerfServerKind, r.Spec.PerfServerName, r.Namespace)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't get %v PerfServer from cluster", r.Spec.PerfServerName)
+	}
+
+	if err := controllerutil.SetControllerReference(ps, r, h.scheme); err != nil {
+		return errors.Wrapf(err, "couldn't set owner ref for %v PerfReport", r.Name)
+	}
+
+	if err := h.client.Update(context.TODO(), r); err != nil {
+		return errors.Wrapf(err, "an error has been occurred while updating perf report owner %v", r.Name)
+	}
+	return nil
+}
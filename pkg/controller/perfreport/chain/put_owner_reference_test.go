@@ -0,0 +1,87 @@
+package chain
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+const (
+	fakeName      = "fake-name"
+	fakeNamespace = "fake-namespace"
+)
+
+func TestPutOwnerReference_PerfReportContainsPerfServerOwnerReference(t *testing.T) {
+	pr := &v1alpha1.PerfReport{
+		ObjectMeta: v1.ObjectMeta{
+			OwnerReferences: []v1.OwnerReference{
+				{
+					Kind: "PerfServer",
+				},
+			},
+		},
+	}
+	ch := PutOwnerReference{}
+	assert.NoError(t, ch.ServeRequest(pr))
+}
+
+func TestPutOwnerReference_ShouldSetOwnerReference(t *testing.T) {
+	pr := &v1alpha1.PerfReport{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Spec: v1alpha1.PerfReportSpec{
+			PerfServerName: fakeName,
+		},
+	}
+
+	ps := &v1alpha1.PerfServer{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+	}
+
+	objs := []runtime.Object{
+		pr, ps,
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, pr, ps)
+
+	ch := PutOwnerReference{
+		scheme: s,
+		client: fake.NewFakeClient(objs...),
+	}
+	assert.NoError(t, ch.ServeRequest(pr))
+	assert.Equal(t, fakeName, pr.OwnerReferences[0].Name)
+}
+
+func TestPutOwnerReference_PerfServerShouldNotBeFound(t *testing.T) {
+	pr := &v1alpha1.PerfReport{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace: fakeNamespace,
+		},
+		Spec: v1alpha1.PerfReportSpec{
+			PerfServerName: fakeName,
+		},
+	}
+
+	objs := []runtime.Object{
+		pr,
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, pr)
+
+	ch := PutOwnerReference{
+		scheme: s,
+		client: fake.NewFakeClient(objs...),
+	}
+	assert.Error(t, ch.ServeRequest(pr))
+}
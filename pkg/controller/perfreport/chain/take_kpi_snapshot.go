@@ -0,0 +1,94 @@
+package chain
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf"
+	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfreport/chain/handler"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/dto"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/cluster"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"strconv"
+	"strings"
+)
+
+type TakeKpiSnapshot struct {
+	next       handler.PerfReportHandler
+	client     client.Client
+	perfClient perf.PerfClient
+}
+
+func (h TakeKpiSnapshot) ServeRequest(r *v1alpha1.PerfReport) error {
+	log.Info("start taking snapshot of PERF KPIs", "name", r.Name)
+	if err := h.takeSnapshot(r); err != nil {
+		setFailedStatus(r)
+		return err
+	}
+	setSuccessStatus(r)
+	log.Info("snapshot of PERF KPIs has been taken", "name", r.Name, "kpis", len(r.Status.Kpis))
+	return nextServeOrNil(h.next, r)
+}
+
+func setFailedStatus(r *v1alpha1.PerfReport) {
+	r.Status.Status = "error"
+}
+
+func setSuccessStatus(r *v1alpha1.PerfReport) {
+	r.Status.Status = "created"
+}
+
+func (h TakeKpiSnapshot) takeSnapshot(r *v1alpha1.PerfReport) error {
+	nodeId, err := h.getNodeId(r)
+	if err != nil {
+		return err
+	}
+
+	kpis, err := h.perfClient.GetNodeKpis(nodeId)
+	if err != nil {
+		return err
+	}
+
+	r.Status.NodeId = nodeId
+	r.Status.Kpis = filterKpis(kpis, r.Spec.Metrics)
+	return nil
+}
+
+func (h TakeKpiSnapshot) getNodeId(r *v1alpha1.PerfReport) (int, error) {
+	if r.Spec.NodeName == "" {
+		ps, err := cluster.GetPerfServerCr(h.client, r.Spec.PerfServerKind, r.Spec.PerfServerName, r.Namespace)
+		if err != nil {
+			return 0, err
+		}
+		return cluster.GetPerfProjectId(ps)
+	}
+
+	node, err := perf.GetNode(h.perfClient, r.Spec.NodeName)
+	if err != nil {
+		return 0, err
+	}
+	if node == nil {
+		return 0, errors.Errorf("PERF node %v wasn't found", r.Spec.NodeName)
+	}
+	return node.Id, nil
+}
+
+func filterKpis(kpis []dto.Kpi, metrics []string) []v1alpha1.PerfKpi {
+	wanted := make(map[string]struct{}, len(metrics))
+	for _, m := range metrics {
+		wanted[strings.ToLower(m)] = struct{}{}
+	}
+
+	res := make([]v1alpha1.PerfKpi, 0, len(kpis))
+	for _, k := range kpis {
+		if _, ok := wanted[strings.ToLower(k.Name)]; len(wanted) > 0 && !ok {
+			continue
+		}
+		res = append(res, v1alpha1.PerfKpi{
+			Name:   k.Name,
+			Value:  strconv.FormatFloat(k.Value, 'f', -1, 64),
+			Unit:   k.Unit,
+			Health: k.Status,
+		})
+	}
+	return res
+}
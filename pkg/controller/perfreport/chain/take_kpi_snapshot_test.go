@@ -0,0 +1,127 @@
+package chain
+
+import (
+	"errors"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf/mock"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/dto"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func createPerfReport(nodeName string, metrics ...string) *v1alpha1.PerfReport {
+	return &v1alpha1.PerfReport{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Spec: v1alpha1.PerfReportSpec{
+			PerfServerName: fakeName,
+			NodeName:       nodeName,
+			Metrics:        metrics,
+		},
+	}
+}
+
+func TestTakeKpiSnapshot_ShouldTakeSelectedKpisOfPerfServerProject(t *testing.T) {
+	pr := createPerfReport("", "Build Success Rate")
+	ps := &v1alpha1.PerfServer{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Spec: v1alpha1.PerfServerSpec{
+			ProjectName: "project",
+		},
+		Status: v1alpha1.PerfServerStatus{
+			ProjectId: 5,
+		},
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, pr, ps)
+
+	mPerfCl := new(mock.MockPerfClient)
+	ch := TakeKpiSnapshot{
+		client:     fake.NewFakeClient([]runtime.Object{pr, ps}...),
+		perfClient: mPerfCl,
+	}
+
+	mPerfCl.On("GetNodeKpis", 5).Return([]dto.Kpi{
+		{Id: 1, Name: "Build Success Rate", Value: 97.5, Unit: "%", Status: "GREEN"},
+		{Id: 2, Name: "Code Coverage", Value: 40, Unit: "%", Status: "RED"},
+	}, nil)
+
+	assert.NoError(t, ch.ServeRequest(pr))
+	assert.Equal(t, "created", pr.Status.Status)
+	assert.Equal(t, 5, pr.Status.NodeId)
+	assert.Equal(t, []v1alpha1.PerfKpi{
+		{Name: "Build Success Rate", Value: "97.5", Unit: "%", Health: "GREEN"},
+	}, pr.Status.Kpis)
+}
+
+func TestTakeKpiSnapshot_ShouldTakeAllKpisOfChildNode(t *testing.T) {
+	pr := createPerfReport("child")
+
+	mPerfCl := new(mock.MockPerfClient)
+	ch := TakeKpiSnapshot{
+		perfClient: mPerfCl,
+	}
+
+	mPerfCl.On("GetProjectsByName", "child").Return([]dto.PerfProject{{Id: 7, Name: "child"}}, nil)
+	mPerfCl.On("GetNodeKpis", 7).Return([]dto.Kpi{
+		{Id: 1, Name: "Build Success Rate", Value: 90},
+		{Id: 2, Name: "Code Coverage", Value: 40},
+	}, nil)
+
+	assert.NoError(t, ch.ServeRequest(pr))
+	assert.Len(t, pr.Status.Kpis, 2)
+}
+
+func TestTakeKpiSnapshot_ShouldTakeKpisOfNodeByPath(t *testing.T) {
+	pr := createPerfReport("EPAM/Delivery/Backend")
+
+	mPerfCl := new(mock.MockPerfClient)
+	ch := TakeKpiSnapshot{
+		perfClient: mPerfCl,
+	}
+
+	mPerfCl.On("GetProjectByPath", "EPAM/Delivery/Backend").Return(&dto.PerfProject{Id: 9, Name: "Backend"}, nil)
+	mPerfCl.On("GetNodeKpis", 9).Return([]dto.Kpi{{Id: 1, Name: "Build Success Rate", Value: 90}}, nil)
+
+	assert.NoError(t, ch.ServeRequest(pr))
+	assert.Equal(t, 9, pr.Status.NodeId)
+}
+
+func TestTakeKpiSnapshot_ShouldFailWhenNodeIsMissing(t *testing.T) {
+	pr := createPerfReport("child")
+
+	mPerfCl := new(mock.MockPerfClient)
+	ch := TakeKpiSnapshot{
+		perfClient: mPerfCl,
+	}
+
+	mPerfCl.On("GetProjectsByName", "child").Return(nil, nil)
+
+	assert.Error(t, ch.ServeRequest(pr))
+	assert.Equal(t, "error", pr.Status.Status)
+}
+
+func TestTakeKpiSnapshot_ShouldFailOnPerfError(t *testing.T) {
+	pr := createPerfReport("child")
+
+	mPerfCl := new(mock.MockPerfClient)
+	ch := TakeKpiSnapshot{
+		perfClient: mPerfCl,
+	}
+
+	mPerfCl.On("GetProjectsByName", "child").Return([]dto.PerfProject{{Id: 7}}, nil)
+	mPerfCl.On("GetNodeKpis", 7).Return(nil, errors.New("failed"))
+
+	assert.Error(t, ch.ServeRequest(pr))
+	assert.Equal(t, "error", pr.Status.Status)
+}
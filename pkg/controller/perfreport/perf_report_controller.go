@@ -0,0 +1,151 @@
+package perfreport
+
+import (
+	"context"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf"
+	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfreport/chain"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/cluster"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/watch"
+	"github.com/pkg/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"time"
+)
+
+const defaultRefreshInterval = time.Hour
+
+var (
+	log = logf.Log.WithName("controller_perf_report")
+)
+
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcilePerfReport{
+		client: mgr.GetClient(),
+		scheme: mgr.GetScheme(),
+	}
+}
+
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	o, err := watch.GetControllerOptions(mgr, r)
+	if err != nil {
+		return err
+	}
+
+	c, err := controller.New("perfreport-controller", mgr, o)
+	if err != nil {
+		return err
+	}
+
+	p := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldSpec := e.ObjectOld.(*v1alpha1.PerfReport).Spec
+			newSpec := e.ObjectNew.(*v1alpha1.PerfReport).Spec
+			return !reflect.DeepEqual(oldSpec, newSpec)
+		},
+	}
+
+	if err = c.Watch(&source.Kind{Type: &v1alpha1.PerfReport{}}, &handler.EnqueueRequestForObject{}, p); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcilePerfReport{}
+
+type ReconcilePerfReport struct {
+	client client.Client
+	scheme *runtime.Scheme
+}
+
+func (r *ReconcilePerfReport) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	rl := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	rl.V(2).Info("Reconciling PerfReport")
+
+	i := &v1alpha1.PerfReport{}
+	if err := r.client.Get(context.TODO(), request.NamespacedName, i); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	defer r.updateStatus(i)
+
+	interval, err := getRefreshInterval(i)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	ps, err := cluster.GetPerfServerCr(r.client, i.Spec.PerfServerKind, i.Spec.PerfServerName, i.Namespace)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "couldn't get %v PerfServer from cluster", i.Spec.PerfServerName)
+	}
+
+	if !ps.Status.Available {
+		log.Info("Perf instance is unavailable. skip taking PERF KPIs snapshot", "name", ps.Name)
+		return reconcile.Result{RequeueAfter: 2 * time.Minute}, nil
+	}
+
+	pc, err := r.newPerfRestClient(ps)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err := chain.CreateDefChain(r.client, r.scheme, pc).ServeRequest(i); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	rl.Info("Reconciling PerfReport has been finished")
+	return reconcile.Result{RequeueAfter: interval}, nil
+}
+
+func getRefreshInterval(report *v1alpha1.PerfReport) (time.Duration, error) {
+	if report.Spec.Interval == "" {
+		return defaultRefreshInterval, nil
+	}
+	d, err := time.ParseDuration(report.Spec.Interval)
+	if err != nil {
+		return 0, errors.Wrapf(err, "couldn't parse %v refresh interval", report.Spec.Interval)
+	}
+	return d, nil
+}
+
+func (r ReconcilePerfReport) updateStatus(report *v1alpha1.PerfReport) {
+	report.Status.LastTimeUpdated = time.Now()
+	if err := r.client.Status().Update(context.TODO(), report); err != nil {
+		_ = r.client.Update(context.TODO(), report)
+	}
+}
+
+func (r ReconcilePerfReport) newPerfRestClient(ps *v1alpha1.PerfServer) (*perf.PerfClientAdapter, error) {
+	transport, err := perf.GetTransport(r.client, ps)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := perf.GetAuthProvider(r.client, ps, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	perfClient, err := perf.NewRestClient(ps.Spec.ApiUrl, provider, transport)
+	if err != nil {
+		return nil, err
+	}
+	return perfClient, nil
+}
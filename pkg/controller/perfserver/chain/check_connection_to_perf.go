@@ -29,7 +29,10 @@ func (h CheckConnectionToPerf) ServeRequest(server *v1alpha1.PerfServer) error {
 	server.Status.Available = connected
 	server.Status.DetailedMessage = "connected"
 
-	h.updateStatus(server)
+	// the status of a ClusterPerfServer is saved by its controller, there is no client to save it here
+	if h.client != nil {
+		h.updateStatus(server)
+	}
 
 	log.Info("connection to PERF has been established", "url", server.Spec.RootUrl)
 	return nextServeOrNil(h.next, server)
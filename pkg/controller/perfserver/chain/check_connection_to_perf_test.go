@@ -74,3 +74,18 @@ func TestCheckConnectionToPerf_ShouldNotBeUpdated(t *testing.T) {
 	err := perf.ServeRequest(psr)
 	assert.NoError(t, err)
 }
+
+func TestCheckConnectionToPerf_ShouldSetStatusWithoutClient(t *testing.T) {
+	mPerfCl := new(mock.MockPerfClient)
+	perf := CheckConnectionToPerf{
+		perfClient: mPerfCl,
+	}
+
+	mPerfCl.On("Connected").Return(true, nil)
+
+	psr := &v1alpha1.PerfServer{}
+	err := perf.ServeRequest(psr)
+	assert.NoError(t, err)
+	assert.True(t, psr.Status.Available)
+	assert.Equal(t, "connected", psr.Status.DetailedMessage)
+}
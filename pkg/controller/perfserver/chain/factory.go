@@ -12,15 +12,26 @@ import (
 var log = logf.Log.WithName("perf_server_handler")
 
 func CreateDefChain(client client.Client, scheme *runtime.Scheme, perfClient perf.PerfClient) handler.PerfServerHandler {
+	return PutEdpComponent{
+		next: CheckConnectionToPerf{
+			next: PutPerfProject{
+				perfClient: perfClient,
+			},
+			client:     client,
+			perfClient: perfClient,
+		},
+		client: client,
+		scheme: scheme,
+	}
+}
+
+// CreateClusterChain returns the chain for a ClusterPerfServer converted to a PerfServer. The PerfServer isn't stored
+// in the cluster, so the chain doesn't update it and doesn't create the namespaced EDPComponent.
+func CreateClusterChain(perfClient perf.PerfClient) handler.PerfServerHandler {
 	return CheckConnectionToPerf{
 		next: PutPerfProject{
-			next: PutEdpComponent{
-				client: client,
-				scheme: scheme,
-			},
 			perfClient: perfClient,
 		},
-		client:     client,
 		perfClient: perfClient,
 	}
 }
@@ -6,17 +6,24 @@ import (
 	"encoding/base64"
 	edpCompApi "github.com/epmd-edp/edp-component-operator/pkg/apis/v1/v1alpha1"
 	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfserver/chain/handler"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/cluster"
+	"github.com/pkg/errors"
 	"io/ioutil"
-	"k8s.io/apimachinery/pkg/api/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"os"
+	"reflect"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
+// PutEdpComponent creates or updates the EDPComponent of the server after the rest of the chain is handled,
+// so its visibility reflects the PERF availability even if the connection check fails.
 type PutEdpComponent struct {
+	next   handler.PerfServerHandler
 	client client.Client
 	scheme *runtime.Scheme
 }
@@ -24,50 +31,67 @@ type PutEdpComponent struct {
 const (
 	perfEdpComponentType = "perf"
 	perfIconPath         = "/usr/local/configs/img/perf.svg"
+	perfIconKey          = "perf.svg"
 )
 
 func (h PutEdpComponent) ServeRequest(server *v1alpha1.PerfServer) error {
-	log.Info("start creating EDP component", "name", server.Name)
+	chainErr := nextServeOrNil(h.next, server)
+
+	log.Info("start putting EDP component", "name", server.Name)
 	if err := h.putEdpComponent(server); err != nil {
+		if chainErr != nil {
+			log.Error(err, "couldn't put EDP component", "name", server.Name)
+			return chainErr
+		}
 		return err
 	}
-	log.Info("EDP component was created", "name", server.Name)
-	return nil
+	log.Info("EDP component has been put", "name", server.Name)
+	return chainErr
 }
 
 func (h PutEdpComponent) putEdpComponent(server *v1alpha1.PerfServer) error {
+	icon, err := h.getIcon(server)
+	if err != nil {
+		return err
+	}
+	spec := edpCompApi.EDPComponentSpec{
+		Type:    perfEdpComponentType,
+		Url:     server.Spec.RootUrl,
+		Icon:    icon,
+		Visible: isVisible(server),
+	}
+
 	comp := &edpCompApi.EDPComponent{}
-	err := h.client.Get(context.TODO(), types.NamespacedName{
+	err = h.client.Get(context.TODO(), types.NamespacedName{
 		Name:      server.Name,
 		Namespace: server.Namespace,
 	}, comp)
 	if err != nil {
-		if errors.IsNotFound(err) {
-			return h.createEdpComponent(server)
+		if k8serrors.IsNotFound(err) {
+			return h.createEdpComponent(server, spec)
 		}
 		return err
 	}
-	log.Info("EDP component already exists. skip creating...", "name", server.Name)
-	return nil
-}
 
-func (h PutEdpComponent) createEdpComponent(server *v1alpha1.PerfServer) error {
-	icon, err := getIcon()
-	if err != nil {
-		return err
+	if reflect.DeepEqual(comp.Spec, spec) {
+		log.Info("EDP component is up to date", "name", server.Name)
+		return nil
 	}
+	comp.Spec = spec
+	if err := h.client.Update(context.TODO(), comp); err != nil {
+		return errors.Wrapf(err, "couldn't update %v EDP component", server.Name)
+	}
+	log.Info("EDP component has been updated", "name", server.Name)
+	return nil
+}
 
+func (h PutEdpComponent) createEdpComponent(server *v1alpha1.PerfServer, spec edpCompApi.EDPComponentSpec) error {
 	comp := &edpCompApi.EDPComponent{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      server.Name,
 			Namespace: server.Namespace,
 		},
-		Spec: edpCompApi.EDPComponentSpec{
-			Type:    perfEdpComponentType,
-			Url:     server.Spec.RootUrl,
-			Icon:    *icon,
-			Visible: true,
-		},
+		Spec: spec,
 	}
 
 	if err := controllerutil.SetControllerReference(server, comp, h.scheme); err != nil {
@@ -81,18 +105,54 @@ func (h PutEdpComponent) createEdpComponent(server *v1alpha1.PerfServer) error {
 	return nil
 }
 
-func getIcon() (*string, error) {
+func isVisible(server *v1alpha1.PerfServer) bool {
+	if server.Spec.EdpComponent == nil {
+		return true
+	}
+	switch server.Spec.EdpComponent.Visibility {
+	case v1alpha1.EdpComponentHidden:
+		return false
+	case v1alpha1.EdpComponentAvailable:
+		return server.Status.Available
+	default:
+		return true
+	}
+}
+
+func (h PutEdpComponent) getIcon(server *v1alpha1.PerfServer) (string, error) {
+	ec := server.Spec.EdpComponent
+	if ec == nil || ec.IconConfigMapName == "" {
+		return getDefaultIcon()
+	}
+
+	key := ec.IconKey
+	if key == "" {
+		key = perfIconKey
+	}
+	cm, err := cluster.GetConfigMap(h.client, ec.IconConfigMapName, server.Namespace)
+	if err != nil {
+		return "", errors.Wrapf(err, "couldn't get %v icon ConfigMap", ec.IconConfigMapName)
+	}
+	if content, ok := cm.BinaryData[key]; ok {
+		return base64.StdEncoding.EncodeToString(content), nil
+	}
+	if content, ok := cm.Data[key]; ok {
+		return base64.StdEncoding.EncodeToString([]byte(content)), nil
+	}
+	return "", errors.Errorf("%v icon ConfigMap doesn't have %v key", ec.IconConfigMapName, key)
+}
+
+func getDefaultIcon() (string, error) {
 	f, err := os.Open(perfIconPath)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 	defer f.Close()
 
 	reader := bufio.NewReader(f)
 	content, err := ioutil.ReadAll(reader)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
-	encoded := base64.StdEncoding.EncodeToString(content)
-	return &encoded, nil
+	return base64.StdEncoding.EncodeToString(content), nil
 }
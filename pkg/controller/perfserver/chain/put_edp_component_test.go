@@ -1,49 +1,127 @@
 package chain
 
 import (
+	"context"
+	"encoding/base64"
+	"errors"
 	edpApi "github.com/epmd-edp/edp-component-operator/pkg/apis/v1/v1alpha1"
 	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
 	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"testing"
 )
 
 const (
 	fakeNamespace = "fake-namespace"
-	dirPath       = "/usr/local/configs/img"
-	fileName      = "perf.svg"
 )
 
-func TestPutEdpComponent_EdpComponentAlreadyExists(t *testing.T) {
+type stubHandler struct {
+	available bool
+	err       error
+}
+
+func (h stubHandler) ServeRequest(server *v1alpha1.PerfServer) error {
+	server.Status.Available = h.available
+	return h.err
+}
+
+func createIconObjects() []runtime.Object {
 	edpComp := &edpApi.EDPComponent{
 		ObjectMeta: v1.ObjectMeta{
 			Name:      fakeName,
 			Namespace: fakeNamespace,
 		},
+		Spec: edpApi.EDPComponentSpec{
+			Type:    perfEdpComponentType,
+			Url:     "https://old-perf.example.com",
+			Visible: false,
+		},
 	}
-
-	objs := []runtime.Object{
-		edpComp,
-	}
-	s := scheme.Scheme
-	s.AddKnownTypes(v1.SchemeGroupVersion, edpComp)
-
-	ch := PutEdpComponent{
-		scheme: s,
-		client: fake.NewFakeClient(objs...),
+	icon := &coreV1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "perf-icon",
+			Namespace: fakeNamespace,
+		},
+		Data: map[string]string{
+			"icon.svg": "<svg/>",
+		},
 	}
+	scheme.Scheme.AddKnownTypes(v1.SchemeGroupVersion, edpComp)
+	return []runtime.Object{edpComp, icon}
+}
 
-	psr := &v1alpha1.PerfServer{
+func createIconPerfServer(visibility string) *v1alpha1.PerfServer {
+	return &v1alpha1.PerfServer{
 		ObjectMeta: v1.ObjectMeta{
 			Name:      fakeName,
 			Namespace: fakeNamespace,
 		},
+		Spec: v1alpha1.PerfServerSpec{
+			RootUrl: "https://perf.example.com",
+			EdpComponent: &v1alpha1.PerfServerEdpComponent{
+				Visibility:        visibility,
+				IconConfigMapName: "perf-icon",
+				IconKey:           "icon.svg",
+			},
+		},
+	}
+}
+
+func getEdpComponent(t *testing.T, c client.Client) *edpApi.EDPComponent {
+	comp := &edpApi.EDPComponent{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: fakeName, Namespace: fakeNamespace}, comp))
+	return comp
+}
+
+func TestPutEdpComponent_ShouldUpdateExistingEdpComponent(t *testing.T) {
+	c := fake.NewFakeClient(createIconObjects()...)
+	ch := PutEdpComponent{
+		scheme: scheme.Scheme,
+		client: c,
+	}
+
+	assert.NoError(t, ch.ServeRequest(createIconPerfServer("")))
+
+	comp := getEdpComponent(t, c)
+	assert.Equal(t, "https://perf.example.com", comp.Spec.Url)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("<svg/>")), comp.Spec.Icon)
+	assert.Equal(t, perfEdpComponentType, comp.Spec.Type)
+	assert.True(t, comp.Spec.Visible)
+}
+
+func TestPutEdpComponent_ShouldHideEdpComponentIfPerfIsUnavailable(t *testing.T) {
+	c := fake.NewFakeClient(createIconObjects()...)
+	ch := PutEdpComponent{
+		next:   stubHandler{available: false, err: errors.New("connection refused")},
+		scheme: scheme.Scheme,
+		client: c,
+	}
+
+	assert.Error(t, ch.ServeRequest(createIconPerfServer(v1alpha1.EdpComponentAvailable)))
+	assert.False(t, getEdpComponent(t, c).Spec.Visible)
+
+	ch.next = stubHandler{available: true}
+	assert.NoError(t, ch.ServeRequest(createIconPerfServer(v1alpha1.EdpComponentAvailable)))
+	assert.True(t, getEdpComponent(t, c).Spec.Visible)
+}
+
+func TestPutEdpComponent_ShouldFailOnMissingIconKey(t *testing.T) {
+	ch := PutEdpComponent{
+		scheme: scheme.Scheme,
+		client: fake.NewFakeClient(createIconObjects()...),
 	}
+	ps := createIconPerfServer(v1alpha1.EdpComponentHidden)
+	ps.Spec.EdpComponent.IconKey = ""
 
-	assert.NoError(t, ch.ServeRequest(psr))
+	err := ch.ServeRequest(ps)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "perf-icon icon ConfigMap doesn't have perf.svg key")
 }
 
 func TestPutEdpComponent_SchemeDoesntContainEdpComponent(t *testing.T) {
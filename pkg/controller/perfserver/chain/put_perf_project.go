@@ -1,10 +1,13 @@
 package chain
 
 import (
+	"fmt"
 	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
 	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf"
 	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfserver/chain/handler"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/dto"
 	"github.com/pkg/errors"
+	"strings"
 )
 
 type PutPerfProject struct {
@@ -13,22 +16,104 @@ type PutPerfProject struct {
 }
 
 func (h PutPerfProject) ServeRequest(server *v1alpha1.PerfServer) error {
-	log.Info("put PERF project", "name", server.Spec.ProjectName)
-	if err := h.tryToCreatePerfProject(server); err != nil {
+	log.Info("put PERF project", "project", getProjectRef(server))
+	if err := h.tryToResolvePerfProject(server); err != nil {
 		return err
 	}
-	log.Info("PERF project has been created ", "name", server.Spec.ProjectName)
+	log.Info("PERF project has been resolved", "id", server.Status.ProjectId, "path", server.Status.ProjectPath)
 	return nextServeOrNil(h.next, server)
 }
 
-func (h PutPerfProject) tryToCreatePerfProject(ps *v1alpha1.PerfServer) error {
-	exists, err := h.perfClient.ProjectExists(ps.Spec.ProjectName)
+// tryToResolvePerfProject stores the id of the project node in the status. A pinned spec.projectId always wins.
+// Otherwise the previously resolved node is kept while it matches the spec; on drift the spec name or path
+// is looked up again, and the node is still kept if it was only renamed or moved in PERF.
+// Both switching to another node and keeping a node that no longer matches the spec are reported
+// in the detailed message.
+func (h PutPerfProject) tryToResolvePerfProject(ps *v1alpha1.PerfServer) error {
+	if ps.Spec.ProjectId != 0 {
+		return h.resolveById(ps, ps.Spec.ProjectId)
+	}
+
+	var (
+		pinned     *dto.PerfProject
+		pinnedPath string
+	)
+	if ps.Status.ProjectId != 0 {
+		var err error
+		pinned, pinnedPath, err = h.perfClient.GetProjectById(ps.Status.ProjectId)
+		if err != nil {
+			return err
+		}
+		if pinned != nil && matchesSpec(ps, pinned, pinnedPath) {
+			setProjectStatus(ps, pinned.Id, pinnedPath)
+			return nil
+		}
+	}
+
+	project, err := h.findBySpec(ps)
 	if err != nil {
 		return err
 	}
-	if exists {
-		log.Info("PERF project already exists. skip creating", "name", ps.Spec.ProjectName)
+	if project != nil {
+		_, path, err := h.perfClient.GetProjectById(project.Id)
+		if err != nil {
+			return err
+		}
+		if pinned != nil && pinned.Id != project.Id {
+			log.Info("PERF project has drifted to another node", "old id", pinned.Id, "new id", project.Id)
+			ps.Status.DetailedMessage = fmt.Sprintf("%v project has been switched from %v node (%v) to %v node (%v)",
+				getProjectRef(ps), pinned.Id, pinnedPath, project.Id, path)
+		}
+		setProjectStatus(ps, project.Id, path)
+		return nil
+	}
+
+	if pinned != nil {
+		log.Info("PERF project doesn't match the spec anymore. keep the resolved node",
+			"project", getProjectRef(ps), "id", pinned.Id, "path", pinnedPath)
+		ps.Status.DetailedMessage = fmt.Sprintf("%v project doesn't match %v node (%v) of status.project_id, "+
+			"the node is kept. Set spec.projectId or point the spec to an existing node to switch it",
+			getProjectRef(ps), pinned.Id, pinnedPath)
+		setProjectStatus(ps, pinned.Id, pinnedPath)
 		return nil
 	}
-	return errors.Errorf("%v project wasn't replicated from UPSA to PERF", ps.Spec.ProjectName)
+	return errors.Errorf("%v project wasn't replicated from UPSA to PERF", getProjectRef(ps))
+}
+
+func (h PutPerfProject) resolveById(ps *v1alpha1.PerfServer, id int) error {
+	project, path, err := h.perfClient.GetProjectById(id)
+	if err != nil {
+		return err
+	}
+	if project == nil {
+		return errors.Errorf("PERF project with %v id wasn't found", id)
+	}
+	setProjectStatus(ps, project.Id, path)
+	return nil
+}
+
+func (h PutPerfProject) findBySpec(ps *v1alpha1.PerfServer) (*dto.PerfProject, error) {
+	if ps.Spec.ProjectPath != "" {
+		return h.perfClient.GetProjectByPath(ps.Spec.ProjectPath)
+	}
+	return h.perfClient.GetProject(ps.Spec.ProjectName)
+}
+
+func matchesSpec(ps *v1alpha1.PerfServer, project *dto.PerfProject, path string) bool {
+	if ps.Spec.ProjectPath != "" {
+		return perf.EqualProjectPaths(ps.Spec.ProjectPath, path)
+	}
+	return strings.EqualFold(ps.Spec.ProjectName, project.Name)
+}
+
+func setProjectStatus(ps *v1alpha1.PerfServer, id int, path string) {
+	ps.Status.ProjectId = id
+	ps.Status.ProjectPath = path
+}
+
+func getProjectRef(ps *v1alpha1.PerfServer) string {
+	if ps.Spec.ProjectPath != "" {
+		return ps.Spec.ProjectPath
+	}
+	return ps.Spec.ProjectName
 }
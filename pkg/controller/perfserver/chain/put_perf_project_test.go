@@ -4,6 +4,7 @@ import (
 	"errors"
 	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
 	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf/mock"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/dto"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -16,7 +17,8 @@ func TestPutPerfProject_ProjectExistsShouldBeExecutedSuccessfully(t *testing.T)
 		perfClient: mPerfCl,
 	}
 
-	mPerfCl.On("ProjectExists", fakeName).Return(true, nil)
+	mPerfCl.On("GetProject", fakeName).Return(&dto.PerfProject{Id: 2, Name: fakeName}, nil)
+	mPerfCl.On("GetProjectById", 2).Return(&dto.PerfProject{Id: 2, Name: fakeName}, "EPAM/fake-name", nil)
 
 	psr := &v1alpha1.PerfServer{
 		Spec: v1alpha1.PerfServerSpec{
@@ -24,6 +26,8 @@ func TestPutPerfProject_ProjectExistsShouldBeExecutedSuccessfully(t *testing.T)
 		},
 	}
 	assert.NoError(t, project.ServeRequest(psr))
+	assert.Equal(t, 2, psr.Status.ProjectId)
+	assert.Equal(t, "EPAM/fake-name", psr.Status.ProjectPath)
 }
 
 func TestPutPerfProject_ProjectDoesntExistShouldBeExecutedSuccessfully(t *testing.T) {
@@ -32,7 +36,7 @@ func TestPutPerfProject_ProjectDoesntExistShouldBeExecutedSuccessfully(t *testin
 		perfClient: mPerfCl,
 	}
 
-	mPerfCl.On("ProjectExists", fakeName).Return(false, nil)
+	mPerfCl.On("GetProject", fakeName).Return(nil, nil)
 
 	psr := &v1alpha1.PerfServer{
 		Spec: v1alpha1.PerfServerSpec{
@@ -48,7 +52,7 @@ func TestPutPerfProject_ThrowErrorDuringProjectExistsCall(t *testing.T) {
 		perfClient: mPerfCl,
 	}
 
-	mPerfCl.On("ProjectExists", fakeName).Return(false, errors.New("failed"))
+	mPerfCl.On("GetProject", fakeName).Return(nil, errors.New("failed"))
 
 	psr := &v1alpha1.PerfServer{
 		Spec: v1alpha1.PerfServerSpec{
@@ -57,3 +61,89 @@ func TestPutPerfProject_ThrowErrorDuringProjectExistsCall(t *testing.T) {
 	}
 	assert.Error(t, project.ServeRequest(psr))
 }
+
+func TestPutPerfProject_ShouldResolveProjectByPath(t *testing.T) {
+	mPerfCl := new(mock.MockPerfClient)
+	project := PutPerfProject{
+		perfClient: mPerfCl,
+	}
+
+	mPerfCl.On("GetProjectByPath", "EPAM/Delivery/Backend").Return(&dto.PerfProject{Id: 7, Name: "Backend"}, nil)
+	mPerfCl.On("GetProjectById", 7).Return(&dto.PerfProject{Id: 7, Name: "Backend"}, "EPAM/Delivery/Backend", nil)
+
+	psr := &v1alpha1.PerfServer{
+		Spec: v1alpha1.PerfServerSpec{
+			ProjectPath: "EPAM/Delivery/Backend",
+		},
+	}
+	assert.NoError(t, project.ServeRequest(psr))
+	assert.Equal(t, 7, psr.Status.ProjectId)
+}
+
+func TestPutPerfProject_ShouldUsePinnedProjectId(t *testing.T) {
+	mPerfCl := new(mock.MockPerfClient)
+	project := PutPerfProject{
+		perfClient: mPerfCl,
+	}
+
+	mPerfCl.On("GetProjectById", 9).Return(&dto.PerfProject{Id: 9, Name: "Renamed"}, "EPAM/Renamed", nil)
+
+	psr := &v1alpha1.PerfServer{
+		Spec: v1alpha1.PerfServerSpec{
+			ProjectName: fakeName,
+			ProjectId:   9,
+		},
+	}
+	assert.NoError(t, project.ServeRequest(psr))
+	assert.Equal(t, 9, psr.Status.ProjectId)
+	assert.Equal(t, "EPAM/Renamed", psr.Status.ProjectPath)
+	mPerfCl.AssertNotCalled(t, "GetProject", fakeName)
+}
+
+func TestPutPerfProject_ShouldKeepRenamedProject(t *testing.T) {
+	mPerfCl := new(mock.MockPerfClient)
+	project := PutPerfProject{
+		perfClient: mPerfCl,
+	}
+
+	mPerfCl.On("GetProjectById", 5).Return(&dto.PerfProject{Id: 5, Name: "Backend-New"}, "EPAM/Backend-New", nil)
+	mPerfCl.On("GetProjectByPath", "EPAM/Backend").Return(nil, nil)
+
+	psr := &v1alpha1.PerfServer{
+		Spec: v1alpha1.PerfServerSpec{
+			ProjectPath: "EPAM/Backend",
+		},
+		Status: v1alpha1.PerfServerStatus{
+			ProjectId:   5,
+			ProjectPath: "EPAM/Backend",
+		},
+	}
+	assert.NoError(t, project.ServeRequest(psr))
+	assert.Equal(t, 5, psr.Status.ProjectId)
+	assert.Equal(t, "EPAM/Backend-New", psr.Status.ProjectPath)
+	assert.Contains(t, psr.Status.DetailedMessage, "doesn't match 5 node (EPAM/Backend-New)")
+}
+
+func TestPutPerfProject_ShouldFollowChangedPath(t *testing.T) {
+	mPerfCl := new(mock.MockPerfClient)
+	project := PutPerfProject{
+		perfClient: mPerfCl,
+	}
+
+	mPerfCl.On("GetProjectById", 5).Return(&dto.PerfProject{Id: 5, Name: "Backend"}, "EPAM/A/Backend", nil)
+	mPerfCl.On("GetProjectByPath", "EPAM/B/Backend").Return(&dto.PerfProject{Id: 6, Name: "Backend"}, nil)
+	mPerfCl.On("GetProjectById", 6).Return(&dto.PerfProject{Id: 6, Name: "Backend"}, "EPAM/B/Backend", nil)
+
+	psr := &v1alpha1.PerfServer{
+		Spec: v1alpha1.PerfServerSpec{
+			ProjectPath: "EPAM/B/Backend",
+		},
+		Status: v1alpha1.PerfServerStatus{
+			ProjectId: 5,
+		},
+	}
+	assert.NoError(t, project.ServeRequest(psr))
+	assert.Equal(t, 6, psr.Status.ProjectId)
+	assert.Equal(t, "EPAM/B/Backend project has been switched from 5 node (EPAM/A/Backend) to 6 node (EPAM/B/Backend)",
+		psr.Status.DetailedMessage)
+}
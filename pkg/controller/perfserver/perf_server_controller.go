@@ -2,11 +2,17 @@ package perfserver
 
 import (
 	"context"
+	edpCompApi "github.com/epmd-edp/edp-component-operator/pkg/apis/v1/v1alpha1"
 	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/luminate"
 	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf"
 	"github.com/epmd-edp/perf-operator/v2/pkg/controller/perfserver/chain"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/watch"
+	coreV1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"reflect"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -31,7 +37,12 @@ func newReconciler(mgr manager.Manager) reconcile.Reconciler {
 }
 
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
-	c, err := controller.New("perfserver-controller", mgr, controller.Options{Reconciler: r})
+	o, err := watch.GetControllerOptions(mgr, r)
+	if err != nil {
+		return err
+	}
+
+	c, err := controller.New("perfserver-controller", mgr, o)
 	if err != nil {
 		return err
 	}
@@ -40,7 +51,7 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		UpdateFunc: func(e event.UpdateEvent) bool {
 			oldObject := e.ObjectOld.(*v1alpha1.PerfServer)
 			newObject := e.ObjectNew.(*v1alpha1.PerfServer)
-			if oldObject.Spec != newObject.Spec {
+			if !reflect.DeepEqual(oldObject.Spec, newObject.Spec) {
 				return true
 			}
 			return false
@@ -51,9 +62,85 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	cl := mgr.GetClient()
+	if err = c.Watch(&source.Kind{Type: &coreV1.ConfigMap{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(func(o handler.MapObject) []reconcile.Request {
+			return append(getDependentPerfServers(cl, o.Meta.GetNamespace(), func(d perf.AuthDependencies) []string {
+				return d.ConfigMaps
+			}, o.Meta.GetName()), getIconPerfServers(cl, o.Meta.GetNamespace(), o.Meta.GetName())...)
+		}),
+	}); err != nil {
+		return err
+	}
+
+	if err = c.Watch(&source.Kind{Type: &coreV1.Secret{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(func(o handler.MapObject) []reconcile.Request {
+			return getDependentPerfServers(cl, o.Meta.GetNamespace(), func(d perf.AuthDependencies) []string {
+				return d.Secrets
+			}, o.Meta.GetName())
+		}),
+	}); err != nil {
+		return err
+	}
+
+	if err = c.Watch(&source.Kind{Type: &edpCompApi.EDPComponent{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &v1alpha1.PerfServer{},
+	}); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// getIconPerfServers returns requests for the PerfServers whose EDPComponent icon is taken from the named ConfigMap.
+func getIconPerfServers(c client.Client, namespace, name string) []reconcile.Request {
+	list := &v1alpha1.PerfServerList{}
+	if err := c.List(context.TODO(), &client.ListOptions{Namespace: namespace}, list); err != nil {
+		log.Error(err, "couldn't list PerfServers", "namespace", namespace)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, ps := range list.Items {
+		if ps.Spec.EdpComponent != nil && ps.Spec.EdpComponent.IconConfigMapName == name {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+				Namespace: ps.Namespace,
+				Name:      ps.Name,
+			}})
+		}
+	}
+	return requests
+}
+
+// getDependentPerfServers returns requests for the PerfServers whose authentication is built from the named object.
+func getDependentPerfServers(c client.Client, namespace string, deps func(d perf.AuthDependencies) []string,
+	name string) []reconcile.Request {
+	list := &v1alpha1.PerfServerList{}
+	if err := c.List(context.TODO(), &client.ListOptions{Namespace: namespace}, list); err != nil {
+		log.Error(err, "couldn't list PerfServers", "namespace", namespace)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range list.Items {
+		ps := &list.Items[i]
+		for _, n := range deps(perf.GetAuthDependencies(c, ps)) {
+			if n == name {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+					Namespace: ps.Namespace,
+					Name:      ps.Name,
+				}})
+				break
+			}
+		}
+	}
+	return requests
+}
+
+// availabilityCheckInterval is how often PERF availability is checked to keep the EDPComponent visibility up to date.
+const availabilityCheckInterval = 5 * time.Minute
+
 var (
 	_   reconcile.Reconciler = &ReconcilePerfServer{}
 	log                      = logf.Log.WithName("controller_perf_server")
@@ -71,13 +158,15 @@ func (r *ReconcilePerfServer) Reconcile(request reconcile.Request) (reconcile.Re
 	i := &v1alpha1.PerfServer{}
 	if err := r.client.Get(context.TODO(), request.NamespacedName, i); err != nil {
 		if k8serrors.IsNotFound(err) {
+			perf.EvictTransport(request.Namespace, request.Name)
+			luminate.EvictTokenSource(request.Namespace, request.Name)
 			return reconcile.Result{}, nil
 		}
 		return reconcile.Result{}, err
 	}
 	defer r.updateStatus(i)
 
-	pc, err := r.newPerfRestClient(i.Spec.ApiUrl, i.Spec.CredentialName, i.Namespace)
+	pc, err := r.newPerfRestClient(i)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -89,6 +178,9 @@ func (r *ReconcilePerfServer) Reconcile(request reconcile.Request) (reconcile.Re
 	}
 
 	rl.Info("Reconciling PerfServer has been finished")
+	if ec := i.Spec.EdpComponent; ec != nil && ec.Visibility == v1alpha1.EdpComponentAvailable {
+		return reconcile.Result{RequeueAfter: availabilityCheckInterval}, nil
+	}
 	return reconcile.Result{}, nil
 }
 
@@ -99,13 +191,18 @@ func (r ReconcilePerfServer) updateStatus(server *v1alpha1.PerfServer) {
 	}
 }
 
-func (r ReconcilePerfServer) newPerfRestClient(url, secretName, namespace string) (*perf.PerfClientAdapter, error) {
-	credentials, err := perf.GetPerfCredentials(r.client, secretName, namespace)
+func (r ReconcilePerfServer) newPerfRestClient(ps *v1alpha1.PerfServer) (*perf.PerfClientAdapter, error) {
+	transport, err := perf.GetTransport(r.client, ps)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := perf.GetAuthProvider(r.client, ps, transport)
 	if err != nil {
 		return nil, err
 	}
 
-	perfClient, err := perf.NewRestClient(url, credentials.Username, credentials.Password, credentials.LuminateToken)
+	perfClient, err := perf.NewRestClient(ps.Spec.ApiUrl, provider, transport)
 	if err != nil {
 		return nil, err
 	}
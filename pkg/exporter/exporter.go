@@ -0,0 +1,309 @@
+package exporter
+
+import (
+	"context"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/dto"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/cluster"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/watch"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"os"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	enabledEnv      = "PERF_EXPORTER_ENABLED"
+	intervalEnv     = "PERF_EXPORTER_INTERVAL"
+	defaultInterval = 5 * time.Minute
+)
+
+var (
+	log = logf.Log.WithName("perf_exporter")
+
+	kpiDesc = prometheus.NewDesc("perf_kpi_value",
+		"Last known value of a PERF KPI.",
+		[]string{"perf_server_namespace", "perf_server", "node", "kpi", "unit", "health"}, nil)
+	upDesc = prometheus.NewDesc("perf_exporter_up",
+		"Whether the last pull of PERF KPIs for the PerfServer was successful.",
+		[]string{"perf_server_namespace", "perf_server"}, nil)
+	lastPullDesc = prometheus.NewDesc("perf_exporter_last_pull_timestamp_seconds",
+		"Time of the last successful pull of PERF KPIs for the PerfServer.",
+		[]string{"perf_server_namespace", "perf_server"}, nil)
+)
+
+type perfClientFactory func(ps *v1alpha1.PerfServer) (perf.PerfClient, error)
+
+type kpiSample struct {
+	node string
+	kpi  string
+	unit string
+	// health is the PERF rating of the value, e.g. GREEN, AMBER or RED.
+	health string
+	value  float64
+}
+
+type serverSnapshot struct {
+	up       bool
+	lastPull time.Time
+	samples  []kpiSample
+}
+
+// Exporter periodically pulls PERF KPIs of every PerfServer in the served namespaces and of every ClusterPerfServer,
+// and serves the cached values to Prometheus, so a scrape never calls PERF synchronously.
+type Exporter struct {
+	client     client.Client
+	namespace  string
+	namespaces *watch.Filter
+	// operatorNamespace is where the Secrets and ConfigMaps of ClusterPerfServers are taken from.
+	operatorNamespace string
+	interval          time.Duration
+	newPerfClient     perfClientFactory
+
+	mu        sync.RWMutex
+	snapshots map[types.NamespacedName]serverSnapshot
+}
+
+// Add registers the exporter in the manager's metrics registry if it's enabled with PERF_EXPORTER_ENABLED.
+func Add(mgr manager.Manager, namespace string) error {
+	enabled, _ := strconv.ParseBool(os.Getenv(enabledEnv))
+	if !enabled {
+		log.Info("PERF exporter is disabled")
+		return nil
+	}
+
+	interval := defaultInterval
+	if v := os.Getenv(intervalEnv); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.Wrapf(err, "couldn't parse %v exporter interval", v)
+		}
+		interval = d
+	}
+
+	namespaces, err := watch.GetFilter(mgr.GetClient())
+	if err != nil {
+		return err
+	}
+
+	operatorNamespace, err := cluster.GetOperatorNamespace()
+	if err != nil {
+		return err
+	}
+
+	e := newExporter(mgr.GetClient(), namespace, namespaces, interval, newPerfRestClient(mgr.GetClient()))
+	e.operatorNamespace = operatorNamespace
+	if err := metrics.Registry.Register(e); err != nil {
+		return errors.Wrap(err, "couldn't register PERF exporter")
+	}
+	return mgr.Add(e)
+}
+
+func newExporter(client client.Client, namespace string, namespaces *watch.Filter, interval time.Duration,
+	factory perfClientFactory) *Exporter {
+	return &Exporter{
+		client:        client,
+		namespace:     namespace,
+		namespaces:    namespaces,
+		interval:      interval,
+		newPerfClient: factory,
+		snapshots:     make(map[types.NamespacedName]serverSnapshot),
+	}
+}
+
+func newPerfRestClient(c client.Client) perfClientFactory {
+	return func(ps *v1alpha1.PerfServer) (perf.PerfClient, error) {
+		transport, err := perf.GetTransport(c, ps)
+		if err != nil {
+			return nil, err
+		}
+		provider, err := perf.GetAuthProvider(c, ps, transport)
+		if err != nil {
+			return nil, err
+		}
+		return perf.NewRestClient(ps.Spec.ApiUrl, provider, transport)
+	}
+}
+
+// Start pulls KPIs every interval until the stop channel is closed.
+func (e *Exporter) Start(stop <-chan struct{}) error {
+	log.Info("starting PERF exporter", "interval", e.interval)
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		e.pull()
+		select {
+		case <-stop:
+			log.Info("PERF exporter has been stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// exportedServer is a PerfServer whose KPIs are pulled, a ClusterPerfServer is converted to a PerfServer
+// in the operator namespace and is exported with an empty namespace.
+type exportedServer struct {
+	key    types.NamespacedName
+	server *v1alpha1.PerfServer
+}
+
+func (e *Exporter) listServers() ([]exportedServer, error) {
+	list := &v1alpha1.PerfServerList{}
+	if err := e.client.List(context.TODO(), &client.ListOptions{Namespace: e.namespace}, list); err != nil {
+		return nil, errors.Wrapf(err, "couldn't list PerfServers in %v namespace", e.namespace)
+	}
+
+	var servers []exportedServer
+	for i := range list.Items {
+		ps := &list.Items[i]
+		if served, err := e.namespaces.Serves(ps.Namespace); err != nil || !served {
+			continue
+		}
+		servers = append(servers, exportedServer{
+			key:    types.NamespacedName{Namespace: ps.Namespace, Name: ps.Name},
+			server: ps,
+		})
+	}
+
+	clusterList := &v1alpha1.ClusterPerfServerList{}
+	if err := e.client.List(context.TODO(), &client.ListOptions{}, clusterList); err != nil {
+		return nil, errors.Wrap(err, "couldn't list ClusterPerfServers")
+	}
+	for i := range clusterList.Items {
+		cps := &clusterList.Items[i]
+		servers = append(servers, exportedServer{
+			key:    types.NamespacedName{Name: cps.Name},
+			server: cluster.ToPerfServer(cps, e.operatorNamespace),
+		})
+	}
+	return servers, nil
+}
+
+func (e *Exporter) pull() {
+	servers, err := e.listServers()
+	if err != nil {
+		log.Error(err, "couldn't list PERF servers to export")
+		return
+	}
+
+	snapshots := make(map[types.NamespacedName]serverSnapshot, len(servers))
+	for _, s := range servers {
+		ps, key := s.server, s.key
+		samples, err := e.pullServer(ps)
+		if err != nil {
+			log.Error(err, "couldn't pull PERF KPIs", "namespace", ps.Namespace, "perf server", ps.Name)
+			// keep serving the previous values, marking them as stale
+			s := e.getSnapshot(key)
+			s.up = false
+			snapshots[key] = s
+			continue
+		}
+		snapshots[key] = serverSnapshot{
+			up:       true,
+			lastPull: time.Now(),
+			samples:  samples,
+		}
+	}
+
+	e.mu.Lock()
+	e.snapshots = snapshots
+	e.mu.Unlock()
+}
+
+func (e *Exporter) pullServer(ps *v1alpha1.PerfServer) ([]kpiSample, error) {
+	if !ps.Status.Available {
+		return nil, errors.Errorf("PERF %v is unavailable", ps.Name)
+	}
+
+	pc, err := e.newPerfClient(ps)
+	if err != nil {
+		return nil, err
+	}
+
+	projectId, err := cluster.GetPerfProjectId(ps)
+	if err != nil {
+		return nil, err
+	}
+	project, _, err := pc.GetProjectById(projectId)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, errors.Errorf("PERF project with %v id wasn't found", projectId)
+	}
+
+	nodes := []*dto.PerfProject{project}
+	seen := map[int]bool{project.Id: true}
+	for _, name := range ps.Spec.ExporterNodes {
+		node, err := perf.GetNode(pc, name)
+		if err != nil {
+			return nil, err
+		}
+		if node == nil {
+			return nil, errors.Errorf("PERF node %v wasn't found", name)
+		}
+		if !seen[node.Id] {
+			seen[node.Id] = true
+			nodes = append(nodes, node)
+		}
+	}
+
+	var samples []kpiSample
+	for _, node := range nodes {
+		kpis, err := pc.GetNodeKpis(node.Id)
+		if err != nil {
+			return nil, err
+		}
+		for _, k := range kpis {
+			samples = append(samples, kpiSample{
+				node:   node.Name,
+				kpi:    k.Name,
+				unit:   k.Unit,
+				health: k.Status,
+				value:  k.Value,
+			})
+		}
+	}
+	return samples, nil
+}
+
+func (e *Exporter) getSnapshot(server types.NamespacedName) serverSnapshot {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.snapshots[server]
+}
+
+func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- kpiDesc
+	ch <- upDesc
+	ch <- lastPullDesc
+}
+
+func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	for server, s := range e.snapshots {
+		up := 0.0
+		if s.up {
+			up = 1
+		}
+		ch <- prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, up, server.Namespace, server.Name)
+		if !s.lastPull.IsZero() {
+			ch <- prometheus.MustNewConstMetric(lastPullDesc, prometheus.GaugeValue, float64(s.lastPull.Unix()),
+				server.Namespace, server.Name)
+		}
+		for _, k := range s.samples {
+			ch <- prometheus.MustNewConstMetric(kpiDesc, prometheus.GaugeValue, k.value,
+				server.Namespace, server.Name, k.node, k.kpi, k.unit, k.health)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package exporter
+
+import (
+	"errors"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf/mock"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/dto"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/watch"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	fakeName      = "fake-name"
+	fakeNamespace = "fake-namespace"
+)
+
+func createPerfServer(available bool) *v1alpha1.PerfServer {
+	return &v1alpha1.PerfServer{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Spec: v1alpha1.PerfServerSpec{
+			ProjectName:   "project",
+			ExporterNodes: []string{"child"},
+		},
+		Status: v1alpha1.PerfServerStatus{
+			Available: available,
+			ProjectId: 1,
+		},
+	}
+}
+
+func createClusterPerfServer() *v1alpha1.ClusterPerfServer {
+	return &v1alpha1.ClusterPerfServer{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "fake-cluster-name",
+		},
+		Spec: v1alpha1.ClusterPerfServerSpec{
+			PerfServerSpec: v1alpha1.PerfServerSpec{
+				ProjectName: "cluster-project",
+			},
+		},
+		Status: v1alpha1.PerfServerStatus{
+			Available: true,
+			ProjectId: 3,
+		},
+	}
+}
+
+func createExporter(ps *v1alpha1.PerfServer, pc perf.PerfClient, objs ...runtime.Object) *Exporter {
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, ps, &v1alpha1.PerfServerList{},
+		&v1alpha1.ClusterPerfServer{}, &v1alpha1.ClusterPerfServerList{})
+
+	namespaces := watch.NewFilter(nil, []string{fakeNamespace}, nil)
+	e := newExporter(fake.NewFakeClient(append([]runtime.Object{ps}, objs...)...), fakeNamespace, namespaces, time.Minute,
+		func(ps *v1alpha1.PerfServer) (perf.PerfClient, error) {
+			return pc, nil
+		})
+	e.operatorNamespace = "operator-namespace"
+	return e
+}
+
+func TestExporter_ShouldExposeCachedKpis(t *testing.T) {
+	mPerfCl := new(mock.MockPerfClient)
+	mPerfCl.On("GetProjectById", 1).Return(&dto.PerfProject{Id: 1, Name: "project"}, "project", nil)
+	mPerfCl.On("GetProjectsByName", "child").Return([]dto.PerfProject{{Id: 2, Name: "child"}}, nil)
+	mPerfCl.On("GetNodeKpis", 1).Return([]dto.Kpi{{Name: "Code Coverage", Value: 80.5, Unit: "%", Status: "GREEN"}}, nil)
+	mPerfCl.On("GetNodeKpis", 2).Return([]dto.Kpi{{Name: "Code Coverage", Value: 40, Unit: "%", Status: "RED"}}, nil)
+
+	e := createExporter(createPerfServer(true), mPerfCl)
+	e.pull()
+
+	expected := `
+# HELP perf_kpi_value Last known value of a PERF KPI.
+# TYPE perf_kpi_value gauge
+perf_kpi_value{health="GREEN",kpi="Code Coverage",node="project",perf_server="fake-name",perf_server_namespace="fake-namespace",unit="%"} 80.5
+perf_kpi_value{health="RED",kpi="Code Coverage",node="child",perf_server="fake-name",perf_server_namespace="fake-namespace",unit="%"} 40
+# HELP perf_exporter_up Whether the last pull of PERF KPIs for the PerfServer was successful.
+# TYPE perf_exporter_up gauge
+perf_exporter_up{perf_server="fake-name",perf_server_namespace="fake-namespace"} 1
+`
+	assert.NoError(t, testutil.CollectAndCompare(e, strings.NewReader(expected), "perf_kpi_value", "perf_exporter_up"))
+}
+
+func TestExporter_ShouldKeepStaleKpisOnPerfError(t *testing.T) {
+	mPerfCl := new(mock.MockPerfClient)
+	mPerfCl.On("GetProjectById", 1).Return(&dto.PerfProject{Id: 1, Name: "project"}, "project", nil).Once()
+	mPerfCl.On("GetProjectsByName", "child").Return([]dto.PerfProject{{Id: 2, Name: "child"}}, nil).Once()
+	mPerfCl.On("GetNodeKpis", 1).Return([]dto.Kpi{{Name: "Code Coverage", Value: 80}}, nil).Once()
+	mPerfCl.On("GetNodeKpis", 2).Return([]dto.Kpi{}, nil).Once()
+	mPerfCl.On("GetProjectById", 1).Return(nil, "", errors.New("failed"))
+
+	e := createExporter(createPerfServer(true), mPerfCl)
+	e.pull()
+	e.pull()
+
+	expected := `
+# HELP perf_kpi_value Last known value of a PERF KPI.
+# TYPE perf_kpi_value gauge
+perf_kpi_value{health="",kpi="Code Coverage",node="project",perf_server="fake-name",perf_server_namespace="fake-namespace",unit=""} 80
+# HELP perf_exporter_up Whether the last pull of PERF KPIs for the PerfServer was successful.
+# TYPE perf_exporter_up gauge
+perf_exporter_up{perf_server="fake-name",perf_server_namespace="fake-namespace"} 0
+`
+	assert.NoError(t, testutil.CollectAndCompare(e, strings.NewReader(expected), "perf_kpi_value", "perf_exporter_up"))
+}
+
+func TestExporter_ShouldExposeClusterPerfServerKpis(t *testing.T) {
+	mPerfCl := new(mock.MockPerfClient)
+	mPerfCl.On("GetProjectById", 3).Return(&dto.PerfProject{Id: 3, Name: "cluster-project"}, "cluster-project", nil)
+	mPerfCl.On("GetNodeKpis", 3).Return([]dto.Kpi{{Name: "Code Coverage", Value: 60, Unit: "%", Status: "YELLOW"}}, nil)
+
+	var pulled *v1alpha1.PerfServer
+	e := createExporter(createPerfServer(false), mPerfCl, createClusterPerfServer())
+	e.newPerfClient = func(ps *v1alpha1.PerfServer) (perf.PerfClient, error) {
+		pulled = ps
+		return mPerfCl, nil
+	}
+	e.pull()
+
+	expected := `
+# HELP perf_kpi_value Last known value of a PERF KPI.
+# TYPE perf_kpi_value gauge
+perf_kpi_value{health="YELLOW",kpi="Code Coverage",node="cluster-project",perf_server="fake-cluster-name",perf_server_namespace="",unit="%"} 60
+# HELP perf_exporter_up Whether the last pull of PERF KPIs for the PerfServer was successful.
+# TYPE perf_exporter_up gauge
+perf_exporter_up{perf_server="fake-cluster-name",perf_server_namespace=""} 1
+perf_exporter_up{perf_server="fake-name",perf_server_namespace="fake-namespace"} 0
+`
+	assert.NoError(t, testutil.CollectAndCompare(e, strings.NewReader(expected), "perf_kpi_value", "perf_exporter_up"))
+	assert.Equal(t, "operator-namespace", pulled.Namespace)
+}
+
+func TestExporter_ShouldSkipUnavailablePerfServer(t *testing.T) {
+	e := createExporter(createPerfServer(false), new(mock.MockPerfClient))
+	e.pull()
+
+	assert.Equal(t, float64(0), testutil.ToFloat64(e))
+}
+
+func TestExporter_ShouldSkipNotServedNamespaces(t *testing.T) {
+	e := createExporter(createPerfServer(true), new(mock.MockPerfClient))
+	e.namespace = ""
+	e.namespaces = watch.NewFilter(nil, []string{"other-namespace"}, nil)
+	e.pull()
+
+	assert.Empty(t, e.snapshots)
+}
@@ -0,0 +1,138 @@
+package command
+
+import (
+	"encoding/json"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/dto"
+	"github.com/pkg/errors"
+	"reflect"
+	"strings"
+)
+
+func (c DataSourceJenkinsConfig) MarshalJSON() ([]byte, error) {
+	type config DataSourceJenkinsConfig
+	return marshalConfig(config(c), c.Extra)
+}
+
+func (c DataSourceSonarConfig) MarshalJSON() ([]byte, error) {
+	type config DataSourceSonarConfig
+	return marshalConfig(config(c), c.Extra)
+}
+
+func (c DataSourceGitlabConfig) MarshalJSON() ([]byte, error) {
+	type config DataSourceGitlabConfig
+	return marshalConfig(config(c), c.Extra)
+}
+
+func (c DataSourceBitbucketConfig) MarshalJSON() ([]byte, error) {
+	type config DataSourceBitbucketConfig
+	return marshalConfig(config(c), c.Extra)
+}
+
+func (c DataSourceAzureDevOpsConfig) MarshalJSON() ([]byte, error) {
+	type config DataSourceAzureDevOpsConfig
+	return marshalConfig(config(c), c.Extra)
+}
+
+func (c DataSourceTektonConfig) MarshalJSON() ([]byte, error) {
+	type config DataSourceTektonConfig
+	return marshalConfig(config(c), c.Extra)
+}
+
+func (c DataSourceDoraConfig) MarshalJSON() ([]byte, error) {
+	type config DataSourceDoraConfig
+	return marshalConfig(config(c), c.Extra)
+}
+
+func DecodeJenkinsConfig(ds *dto.DataSource) (conf DataSourceJenkinsConfig, err error) {
+	conf.Extra, err = decodeConfig(ds, &conf)
+	return conf, err
+}
+
+func DecodeSonarConfig(ds *dto.DataSource) (conf DataSourceSonarConfig, err error) {
+	conf.Extra, err = decodeConfig(ds, &conf)
+	return conf, err
+}
+
+func DecodeGitLabConfig(ds *dto.DataSource) (conf DataSourceGitlabConfig, err error) {
+	conf.Extra, err = decodeConfig(ds, &conf)
+	return conf, err
+}
+
+func DecodeBitbucketConfig(ds *dto.DataSource) (conf DataSourceBitbucketConfig, err error) {
+	conf.Extra, err = decodeConfig(ds, &conf)
+	return conf, err
+}
+
+func DecodeAzureDevOpsConfig(ds *dto.DataSource) (conf DataSourceAzureDevOpsConfig, err error) {
+	conf.Extra, err = decodeConfig(ds, &conf)
+	return conf, err
+}
+
+func DecodeTektonConfig(ds *dto.DataSource) (conf DataSourceTektonConfig, err error) {
+	conf.Extra, err = decodeConfig(ds, &conf)
+	return conf, err
+}
+
+func DecodeDoraConfig(ds *dto.DataSource) (conf DataSourceDoraConfig, err error) {
+	conf.Extra, err = decodeConfig(ds, &conf)
+	return conf, err
+}
+
+// decodeConfig decodes the config of the PERF data source into the typed config
+// and returns the fields the typed config doesn't have. Null values are decoded as zero values.
+func decodeConfig(ds *dto.DataSource, conf interface{}) (map[string]interface{}, error) {
+	raw, err := json.Marshal(ds.Config)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't encode config of %v datasource", ds.Name)
+	}
+	if err := json.Unmarshal(raw, conf); err != nil {
+		return nil, errors.Wrapf(err, "couldn't decode config of %v datasource", ds.Name)
+	}
+
+	known := getJsonFields(reflect.TypeOf(conf).Elem())
+	var extra map[string]interface{}
+	for k, v := range ds.Config {
+		if known[k] {
+			continue
+		}
+		if extra == nil {
+			extra = map[string]interface{}{}
+		}
+		extra[k] = v
+	}
+	return extra, nil
+}
+
+// marshalConfig encodes the typed config adding the fields that the operator doesn't model.
+// The typed fields take precedence over the extra ones.
+func marshalConfig(conf interface{}, extra map[string]interface{}) ([]byte, error) {
+	if len(extra) == 0 {
+		return json.Marshal(conf)
+	}
+
+	raw, err := json.Marshal(conf)
+	if err != nil {
+		return nil, err
+	}
+	res := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &res); err != nil {
+		return nil, err
+	}
+	for k, v := range extra {
+		if _, ok := res[k]; !ok {
+			res[k] = v
+		}
+	}
+	return json.Marshal(res)
+}
+
+func getJsonFields(t reflect.Type) map[string]bool {
+	fields := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			fields[name] = true
+		}
+	}
+	return fields
+}
@@ -0,0 +1,134 @@
+package command
+
+import (
+	"encoding/json"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/dto"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func createPerfDataSource(config map[string]interface{}) *dto.DataSource {
+	return &dto.DataSource{
+		Id:     1,
+		Name:   "fake-name",
+		Type:   "jenkins",
+		Active: true,
+		Config: config,
+	}
+}
+
+func marshalCommandConfig(t *testing.T, cmd DataSourceCommand) map[string]interface{} {
+	raw, err := json.Marshal(cmd)
+	assert.NoError(t, err)
+	var res struct {
+		Config map[string]interface{} `json:"config"`
+	}
+	assert.NoError(t, json.Unmarshal(raw, &res))
+	return res.Config
+}
+
+func TestDecodeJenkinsConfig_ShouldKeepExtraFieldsOnUpdate(t *testing.T) {
+	ds := createPerfDataSource(map[string]interface{}{
+		"jobNames":  []interface{}{"/fake-name/MASTER-Build-fake-name"},
+		"url":       "https://jenkins.old",
+		"username":  "old",
+		"password":  "old",
+		"timeout":   float64(30),
+		"folders":   map[string]interface{}{"depth": float64(2)},
+		"analytics": nil,
+	})
+
+	current, err := DecodeJenkinsConfig(ds)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/fake-name/MASTER-Build-fake-name"}, current.JobNames)
+	assert.Equal(t, map[string]interface{}{
+		"timeout":   float64(30),
+		"folders":   map[string]interface{}{"depth": float64(2)},
+		"analytics": nil,
+	}, current.Extra)
+
+	cmd := GetJenkinsDsUpdateCommand(ds, current, DataSourceConfigDto{
+		ApiUrl:     "https://jenkins.new",
+		Username:   "fake",
+		Password:   "fake",
+		Parameters: []string{"/fake-name/CODE-REVIEW-fake-name"},
+	})
+
+	assert.Equal(t, map[string]interface{}{
+		"jobNames":  []interface{}{"/fake-name/MASTER-Build-fake-name", "/fake-name/CODE-REVIEW-fake-name"},
+		"url":       "https://jenkins.new",
+		"username":  "fake",
+		"password":  "fake",
+		"timeout":   float64(30),
+		"folders":   map[string]interface{}{"depth": float64(2)},
+		"analytics": nil,
+	}, marshalCommandConfig(t, cmd))
+}
+
+func TestDecodeJenkinsConfig_ShouldDecodeNullAsZeroValue(t *testing.T) {
+	current, err := DecodeJenkinsConfig(createPerfDataSource(map[string]interface{}{
+		"jobNames": nil,
+		"url":      "https://jenkins.example.com",
+	}))
+
+	assert.NoError(t, err)
+	assert.Nil(t, current.JobNames)
+	assert.Nil(t, current.Extra)
+}
+
+func TestDecodeJenkinsConfig_ShouldFailOnMalformedConfig(t *testing.T) {
+	_, err := DecodeJenkinsConfig(createPerfDataSource(map[string]interface{}{
+		"jobNames": "/fake-name/MASTER-Build-fake-name",
+	}))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "couldn't decode config of fake-name datasource")
+}
+
+func TestMarshalConfig_TypedFieldsShouldWinOverExtra(t *testing.T) {
+	raw, err := json.Marshal(DataSourceJenkinsConfig{
+		JobNames: []string{"/fake-name/MASTER-Build-fake-name"},
+		Url:      "https://jenkins.new",
+		Extra: map[string]interface{}{
+			"url":     "https://jenkins.old",
+			"timeout": float64(30),
+		},
+	})
+	assert.NoError(t, err)
+
+	var res map[string]interface{}
+	assert.NoError(t, json.Unmarshal(raw, &res))
+	assert.Equal(t, "https://jenkins.new", res["url"])
+	assert.Equal(t, float64(30), res["timeout"])
+	assert.NotContains(t, res, "Extra")
+}
+
+func TestMarshalConfig_ShouldKeepGitLabOptionsOmittedInUpdate(t *testing.T) {
+	ds := createPerfDataSource(map[string]interface{}{
+		"repositories":   []interface{}{"edp/backend"},
+		"url":            "https://gitlab.example.com",
+		"instanceId":     "gitlab",
+		"withMembership": true,
+		"allPublic":      false,
+		"allBranches":    false,
+		"branches":       []interface{}{"master"},
+		"projectFilter":  "edp",
+	})
+	ds.Type = "gitlab"
+
+	current, err := DecodeGitLabConfig(ds)
+	assert.NoError(t, err)
+
+	allPublic := true
+	conf := marshalCommandConfig(t, GetGitLabDsUpdateCommand(ds, current, DataSourceGitLabConfigDto{
+		ApiUrl:    "https://gitlab.example.com",
+		AllPublic: &allPublic,
+		Username:  "fake",
+		Password:  "fake",
+	}))
+
+	assert.Equal(t, "gitlab", conf["instanceId"])
+	assert.Equal(t, true, conf["withMembership"])
+	assert.Equal(t, true, conf["allPublic"])
+	assert.Equal(t, "edp", conf["projectFilter"])
+}
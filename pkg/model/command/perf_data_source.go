@@ -3,7 +3,6 @@ package command
 import (
 	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
 	"github.com/epmd-edp/perf-operator/v2/pkg/model/dto"
-	"github.com/epmd-edp/perf-operator/v2/pkg/util/common"
 	"strings"
 )
 
@@ -25,25 +24,49 @@ type DataSourceJenkinsConfig struct {
 	Url      string   `json:"url"`
 	Username string   `json:"username"`
 	Password string   `json:"password"`
+	// Extra holds the config fields the operator doesn't model, they're sent back to PERF on update.
+	Extra map[string]interface{} `json:"-"`
 }
 
 type DataSourceSonarConfig struct {
-	ProjectKeys []string `json:"projectKeys"`
-	Url         string   `json:"url"`
-	Username    string   `json:"username"`
-	Password    string   `json:"password"`
+	ProjectKeys []string               `json:"projectKeys"`
+	Url         string                 `json:"url"`
+	Username    string                 `json:"username"`
+	Password    string                 `json:"password"`
+	Extra       map[string]interface{} `json:"-"`
 }
 
 type DataSourceGitlabConfig struct {
-	Repositories   []string `json:"repositories"`
-	Url            string   `json:"url"`
-	InstanceId     string   `json:"instanceId"`
-	WithMembership bool     `json:"withMembership"`
-	AllPublic      bool     `json:"allPublic"`
-	AllBranches    bool     `json:"allBranches"`
-	Branches       []string `json:"branches"`
-	Username       string   `json:"username"`
-	Password       string   `json:"password"`
+	Repositories   []string               `json:"repositories"`
+	Url            string                 `json:"url"`
+	InstanceId     string                 `json:"instanceId"`
+	WithMembership bool                   `json:"withMembership"`
+	AllPublic      bool                   `json:"allPublic"`
+	AllBranches    bool                   `json:"allBranches"`
+	Branches       []string               `json:"branches"`
+	Username       string                 `json:"username"`
+	Password       string                 `json:"password"`
+	Extra          map[string]interface{} `json:"-"`
+}
+
+type DataSourceBitbucketConfig struct {
+	Workspace    string                 `json:"workspace"`
+	Repositories []string               `json:"repositories"`
+	Url          string                 `json:"url"`
+	Branches     []string               `json:"branches"`
+	Username     string                 `json:"username"`
+	Token        string                 `json:"token"`
+	Extra        map[string]interface{} `json:"-"`
+}
+
+type DataSourceAzureDevOpsConfig struct {
+	Project      string                 `json:"project"`
+	Repositories []string               `json:"repositories"`
+	Url          string                 `json:"url"`
+	Branches     []string               `json:"branches"`
+	Username     string                 `json:"username"`
+	Token        string                 `json:"token"`
+	Extra        map[string]interface{} `json:"-"`
 }
 
 type DataSourceConfigDto struct {
@@ -54,102 +77,276 @@ type DataSourceConfigDto struct {
 	Parameters []string
 }
 
+// DataSourceGitLabConfigDto holds the GitLab config to put to PERF. The options that are empty or nil
+// keep the current PERF values.
 type DataSourceGitLabConfigDto struct {
+	Type           string
+	ApiUrl         string
+	InstanceId     string
+	WithMembership *bool
+	AllPublic      *bool
+	AllBranches    *bool
+	Username       string
+	Password       string
+	Repositories   []string
+	Branches       []string
+}
+
+type DataSourceRepositoryConfigDto struct {
 	Type         string
 	ApiUrl       string
+	Scope        string
 	Username     string
-	Password     string
+	Token        string
 	Repositories []string
 	Branches     []string
 }
 
-func GetSonarDsCreateCommand(ds *v1alpha1.PerfDataSourceSonar, username, password string) DataSourceCommand {
+func GetSonarDsCreateCommand(ds *v1alpha1.PerfDataSourceSonar, projectKeys []string, username, password string) DataSourceCommand {
 	return DataSourceCommand{
 		Name: ds.Spec.Name,
 		Type: DataSourceType(strings.ToUpper(ds.Spec.Type)),
 		Config: DataSourceSonarConfig{
-			ProjectKeys: ds.Spec.Config.ProjectKeys,
-			Url:         ds.Spec.Config.Url,
+			ProjectKeys: projectKeys,
+			Url:         GetSonarUrl(ds),
 			Username:    username,
 			Password:    password,
 		},
 	}
 }
 
-func GetSonarDsUpdateCommand(dsReq *dto.DataSource, conf DataSourceConfigDto) DataSourceCommand {
+// GetSonarUrl returns the url of the spec or, if it's empty, the one resolved from the EDPComponent.
+func GetSonarUrl(ds *v1alpha1.PerfDataSourceSonar) string {
+	if ds.Spec.Config.Url != "" {
+		return ds.Spec.Config.Url
+	}
+	return ds.Status.Url
+}
+
+func GetSonarDsUpdateCommand(dsReq *dto.DataSource, current DataSourceSonarConfig, conf DataSourceConfigDto) DataSourceCommand {
+	current.ProjectKeys = append(current.ProjectKeys, conf.Parameters...)
+	current.Url = conf.ApiUrl
+	current.Username = conf.Username
+	current.Password = conf.Password
 	return DataSourceCommand{
-		Id:   dsReq.Id,
-		Name: dsReq.Name,
-		Type: DataSourceType(strings.ToUpper(dsReq.Type)),
-		Config: DataSourceSonarConfig{
-			ProjectKeys: append(common.ConvertToStringArray(dsReq.Config["projectKeys"]), conf.Parameters...),
-			Url:         conf.ApiUrl,
-			Username:    conf.Username,
-			Password:    conf.Password,
-		},
+		Id:     dsReq.Id,
+		Name:   dsReq.Name,
+		Type:   DataSourceType(strings.ToUpper(dsReq.Type)),
+		Config: current,
 	}
 }
 
-func GetJenkinsDsCreateCommand(ds *v1alpha1.PerfDataSourceJenkins, username, password string) DataSourceCommand {
+func GetJenkinsDsCreateCommand(ds *v1alpha1.PerfDataSourceJenkins, jobNames []string, username, password string) DataSourceCommand {
 	return DataSourceCommand{
 		Name: ds.Spec.Name,
 		Type: DataSourceType(strings.ToUpper(ds.Spec.Type)),
 		Config: DataSourceJenkinsConfig{
-			JobNames: ds.Spec.Config.JobNames,
-			Url:      ds.Spec.Config.Url,
+			JobNames: jobNames,
+			Url:      GetJenkinsUrl(ds),
 			Username: username,
 			Password: password,
 		},
 	}
 }
 
-func GetJenkinsDsUpdateCommand(dsReq *dto.DataSource, conf DataSourceConfigDto) DataSourceCommand {
+// GetJenkinsUrl returns the url of the spec or, if it's empty, the one resolved from the EDPComponent.
+func GetJenkinsUrl(ds *v1alpha1.PerfDataSourceJenkins) string {
+	if ds.Spec.Config.Url != "" {
+		return ds.Spec.Config.Url
+	}
+	return ds.Status.Url
+}
+
+func GetJenkinsDsUpdateCommand(dsReq *dto.DataSource, current DataSourceJenkinsConfig, conf DataSourceConfigDto) DataSourceCommand {
+	current.JobNames = append(current.JobNames, conf.Parameters...)
+	current.Url = conf.ApiUrl
+	current.Username = conf.Username
+	current.Password = conf.Password
 	return DataSourceCommand{
-		Id:   dsReq.Id,
-		Name: dsReq.Name,
-		Type: DataSourceType(strings.ToUpper(dsReq.Type)),
-		Config: DataSourceJenkinsConfig{
-			JobNames: append(common.ConvertToStringArray(dsReq.Config["jobNames"]), conf.Parameters...),
-			Url:      conf.ApiUrl,
-			Username: conf.Username,
-			Password: conf.Password,
-		},
+		Id:     dsReq.Id,
+		Name:   dsReq.Name,
+		Type:   DataSourceType(strings.ToUpper(dsReq.Type)),
+		Config: current,
 	}
 }
 
-func GetGitLabDsCreateCommand(ds *v1alpha1.PerfDataSourceGitLab, username, password string) DataSourceCommand {
+func GetGitLabDsCreateCommand(ds *v1alpha1.PerfDataSourceGitLab, repositories, branches []string, username, password string) DataSourceCommand {
 	return DataSourceCommand{
 		Name: ds.Spec.Name,
 		Type: DataSourceType(strings.ToUpper(ds.Spec.Type)),
 		Config: DataSourceGitlabConfig{
-			Repositories:   ds.Spec.Config.Repositories,
-			Url:            ds.Spec.Config.Url,
-			InstanceId:     ds.Spec.Config.Url,
-			WithMembership: false,
-			AllPublic:      false,
-			AllBranches:    false,
-			Branches:       ds.Spec.Config.Branches,
+			Repositories:   repositories,
+			Url:            GetGitLabUrl(ds),
+			InstanceId:     GetGitLabInstanceId(ds),
+			WithMembership: flagValue(ds.Spec.Config.WithMembership),
+			AllPublic:      flagValue(ds.Spec.Config.AllPublic),
+			AllBranches:    flagValue(ds.Spec.Config.AllBranches),
+			Branches:       branches,
 			Username:       username,
 			Password:       password,
 		},
 	}
 }
 
-func GetGitLabDsUpdateCommand(dsReq *dto.DataSource, conf DataSourceGitLabConfigDto) DataSourceCommand {
+// GetGitLabUrl returns the url of the spec or, if it's empty, the one resolved from the EDPComponent.
+func GetGitLabUrl(ds *v1alpha1.PerfDataSourceGitLab) string {
+	if ds.Spec.Config.Url != "" {
+		return ds.Spec.Config.Url
+	}
+	return ds.Status.Url
+}
+
+// GetGitLabInstanceId returns the explicit instance id of the GitLab data source or its url.
+func GetGitLabInstanceId(ds *v1alpha1.PerfDataSourceGitLab) string {
+	if ds.Spec.Config.InstanceId != "" {
+		return ds.Spec.Config.InstanceId
+	}
+	return GetGitLabUrl(ds)
+}
+
+// flagValue returns the value of an optional flag, false if it's omitted.
+func flagValue(flag *bool) bool {
+	return flag != nil && *flag
+}
+
+func GetGitLabDsUpdateCommand(dsReq *dto.DataSource, current DataSourceGitlabConfig, conf DataSourceGitLabConfigDto) DataSourceCommand {
+	current.Repositories = append(current.Repositories, conf.Repositories...)
+	current.Branches = append(current.Branches, conf.Branches...)
+	current.Url = conf.ApiUrl
+	if conf.InstanceId != "" {
+		current.InstanceId = conf.InstanceId
+	}
+	if conf.WithMembership != nil {
+		current.WithMembership = *conf.WithMembership
+	}
+	if conf.AllPublic != nil {
+		current.AllPublic = *conf.AllPublic
+	}
+	if conf.AllBranches != nil {
+		current.AllBranches = *conf.AllBranches
+	}
+	current.Username = conf.Username
+	current.Password = conf.Password
 	return DataSourceCommand{
-		Id:   dsReq.Id,
-		Name: dsReq.Name,
-		Type: DataSourceType(strings.ToUpper(dsReq.Type)),
-		Config: DataSourceGitlabConfig{
-			Repositories:   append(common.ConvertToStringArray(dsReq.Config["repositories"]), conf.Repositories...),
-			Branches:       append(common.ConvertToStringArray(dsReq.Config["branches"]), conf.Branches...),
-			Url:            conf.ApiUrl,
-			InstanceId:     conf.ApiUrl,
-			WithMembership: false,
-			AllPublic:      false,
-			AllBranches:    false,
-			Username:       conf.Username,
-			Password:       conf.Password,
+		Id:     dsReq.Id,
+		Name:   dsReq.Name,
+		Type:   DataSourceType(strings.ToUpper(dsReq.Type)),
+		Config: current,
+	}
+}
+
+func GetBitbucketDsCreateCommand(ds *v1alpha1.PerfDataSourceBitbucket, username, token string) DataSourceCommand {
+	return DataSourceCommand{
+		Name: ds.Spec.Name,
+		Type: DataSourceType(strings.ToUpper(ds.Spec.Type)),
+		Config: DataSourceBitbucketConfig{
+			Workspace:    ds.Spec.Config.Workspace,
+			Repositories: ds.Spec.Config.Repositories,
+			Url:          ds.Spec.Config.Url,
+			Branches:     ds.Spec.Config.Branches,
+			Username:     username,
+			Token:        token,
+		},
+	}
+}
+
+func GetBitbucketDsUpdateCommand(dsReq *dto.DataSource, current DataSourceBitbucketConfig, conf DataSourceRepositoryConfigDto) DataSourceCommand {
+	current.Workspace = conf.Scope
+	current.Repositories = append(current.Repositories, conf.Repositories...)
+	current.Branches = append(current.Branches, conf.Branches...)
+	current.Url = conf.ApiUrl
+	current.Username = conf.Username
+	current.Token = conf.Token
+	return DataSourceCommand{
+		Id:     dsReq.Id,
+		Name:   dsReq.Name,
+		Type:   DataSourceType(strings.ToUpper(dsReq.Type)),
+		Config: current,
+	}
+}
+
+func GetAzureDevOpsDsCreateCommand(ds *v1alpha1.PerfDataSourceAzureDevOps, username, token string) DataSourceCommand {
+	return DataSourceCommand{
+		Name: ds.Spec.Name,
+		Type: DataSourceType(strings.ToUpper(ds.Spec.Type)),
+		Config: DataSourceAzureDevOpsConfig{
+			Project:      ds.Spec.Config.Project,
+			Repositories: ds.Spec.Config.Repositories,
+			Url:          ds.Spec.Config.Url,
+			Branches:     ds.Spec.Config.Branches,
+			Username:     username,
+			Token:        token,
+		},
+	}
+}
+
+func GetAzureDevOpsDsUpdateCommand(dsReq *dto.DataSource, current DataSourceAzureDevOpsConfig, conf DataSourceRepositoryConfigDto) DataSourceCommand {
+	current.Project = conf.Scope
+	current.Repositories = append(current.Repositories, conf.Repositories...)
+	current.Branches = append(current.Branches, conf.Branches...)
+	current.Url = conf.ApiUrl
+	current.Username = conf.Username
+	current.Token = conf.Token
+	return DataSourceCommand{
+		Id:     dsReq.Id,
+		Name:   dsReq.Name,
+		Type:   DataSourceType(strings.ToUpper(dsReq.Type)),
+		Config: current,
+	}
+}
+
+type DataSourceTektonConfig struct {
+	Codebases []string               `json:"codebases"`
+	Extra     map[string]interface{} `json:"-"`
+}
+
+type DataSourceMetricsCommand struct {
+	DataSourceId int         `json:"dataSourceId"`
+	Timestamp    int64       `json:"timestamp"`
+	Metrics      interface{} `json:"metrics"`
+}
+
+func GetTektonDsCreateCommand(ds *v1alpha1.PerfDataSourceTekton, codebases []string) DataSourceCommand {
+	return DataSourceCommand{
+		Name: ds.Spec.Name,
+		Type: DataSourceType(strings.ToUpper(ds.Spec.Type)),
+		Config: DataSourceTektonConfig{
+			Codebases: codebases,
 		},
 	}
 }
+
+func GetTektonDsUpdateCommand(dsReq *dto.DataSource, current DataSourceTektonConfig, codebases []string) DataSourceCommand {
+	current.Codebases = append(current.Codebases, codebases...)
+	return DataSourceCommand{
+		Id:     dsReq.Id,
+		Name:   dsReq.Name,
+		Type:   DataSourceType(strings.ToUpper(dsReq.Type)),
+		Config: current,
+	}
+}
+
+type DataSourceDoraConfig struct {
+	Stages []string               `json:"stages"`
+	Extra  map[string]interface{} `json:"-"`
+}
+
+func GetDoraDsCreateCommand(dm *v1alpha1.PerfDoraMetrics, stages []string) DataSourceCommand {
+	return DataSourceCommand{
+		Name: dm.Spec.Name,
+		Type: DataSourceType(strings.ToUpper(dm.Spec.Type)),
+		Config: DataSourceDoraConfig{
+			Stages: stages,
+		},
+	}
+}
+
+func GetDoraDsUpdateCommand(dsReq *dto.DataSource, current DataSourceDoraConfig, stages []string) DataSourceCommand {
+	current.Stages = append(current.Stages, stages...)
+	return DataSourceCommand{
+		Id:     dsReq.Id,
+		Name:   dsReq.Name,
+		Type:   DataSourceType(strings.ToUpper(dsReq.Type)),
+		Config: current,
+	}
+}
@@ -15,8 +15,10 @@ type DataSource struct {
 	Config map[string]interface{} `json:"config"`
 }
 
-type PerfCredentials struct {
-	Username      string
-	Password      string
-	LuminateToken string
+type Kpi struct {
+	Id     int     `json:"id"`
+	Name   string  `json:"name"`
+	Value  float64 `json:"value"`
+	Unit   string  `json:"unit"`
+	Status string  `json:"status"`
 }
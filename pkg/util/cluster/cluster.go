@@ -3,14 +3,21 @@ package cluster
 import (
 	"context"
 	codebaseApi "github.com/epmd-edp/codebase-operator/v2/pkg/apis/edp/v1alpha1"
+	edpCompApi "github.com/epmd-edp/edp-component-operator/pkg/apis/v1/v1alpha1"
 	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/consts"
+	"github.com/pkg/errors"
 	coreV1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"os"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const operatorNamespaceEnv = "POD_NAMESPACE"
+
 func GetSecret(client client.Client, name, namespace string) (*coreV1.Secret, error) {
 	s := &coreV1.Secret{}
 	err := client.Get(context.TODO(), types.NamespacedName{
@@ -35,7 +42,14 @@ func GetOwnerReference(ownerKind string, ors []metav1.OwnerReference) *metav1.Ow
 	return nil
 }
 
-func GetPerfServerCr(c client.Client, name, namespace string) (*v1alpha1.PerfServer, error) {
+// GetPerfServerCr returns the PerfServer of the kind a CR in the namespace refers to. A ClusterPerfServer is returned
+// as a PerfServer in the operator namespace, so its Secrets and ConfigMaps are read from there, and only if
+// the namespace is allowed to use it.
+func GetPerfServerCr(c client.Client, kind, name, namespace string) (*v1alpha1.PerfServer, error) {
+	if kind == consts.ClusterPerfServerKind {
+		return getAllowedClusterPerfServer(c, name, namespace)
+	}
+
 	ps := &v1alpha1.PerfServer{}
 	if err := c.Get(context.TODO(), types.NamespacedName{
 		Namespace: namespace,
@@ -46,6 +60,79 @@ func GetPerfServerCr(c client.Client, name, namespace string) (*v1alpha1.PerfSer
 	return ps, nil
 }
 
+func GetClusterPerfServerCr(c client.Client, name string) (*v1alpha1.ClusterPerfServer, error) {
+	cps := &v1alpha1.ClusterPerfServer{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: name}, cps); err != nil {
+		return nil, err
+	}
+	return cps, nil
+}
+
+func getAllowedClusterPerfServer(c client.Client, name, namespace string) (*v1alpha1.PerfServer, error) {
+	cps, err := GetClusterPerfServerCr(c, name)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, err := IsNamespaceAllowed(c, cps, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, errors.Errorf("%v namespace isn't allowed to use %v ClusterPerfServer", namespace, name)
+	}
+
+	operatorNamespace, err := GetOperatorNamespace()
+	if err != nil {
+		return nil, err
+	}
+	return ToPerfServer(cps, operatorNamespace), nil
+}
+
+// IsNamespaceAllowed reports if the namespace labels match the allowed namespaces of the ClusterPerfServer.
+func IsNamespaceAllowed(c client.Client, cps *v1alpha1.ClusterPerfServer, namespace string) (bool, error) {
+	selector, err := metav1.LabelSelectorAsSelector(cps.Spec.AllowedNamespaces)
+	if err != nil {
+		return false, errors.Wrapf(err, "couldn't parse allowed namespaces of %v ClusterPerfServer", cps.Name)
+	}
+
+	ns := &v1.Namespace{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: namespace}, ns); err != nil {
+		return false, errors.Wrapf(err, "couldn't get %v namespace", namespace)
+	}
+	return selector.Matches(labels.Set(ns.Labels)), nil
+}
+
+// ToPerfServer returns the ClusterPerfServer as a PerfServer in the namespace its Secrets and ConfigMaps are taken from.
+// The PerfServer isn't stored in the cluster, its status is the status of the ClusterPerfServer.
+func ToPerfServer(cps *v1alpha1.ClusterPerfServer, namespace string) *v1alpha1.PerfServer {
+	return &v1alpha1.PerfServer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cps.Name,
+			Namespace: namespace,
+		},
+		Spec:   *cps.Spec.PerfServerSpec.DeepCopy(),
+		Status: cps.Status,
+	}
+}
+
+// GetOperatorNamespace returns the namespace the operator runs in, it's where ClusterPerfServer credentials live.
+func GetOperatorNamespace() (string, error) {
+	ns, found := os.LookupEnv(operatorNamespaceEnv)
+	if !found || ns == "" {
+		return "", errors.Errorf("%v must be set", operatorNamespaceEnv)
+	}
+	return ns, nil
+}
+
+// GetPerfProjectId returns the id of the PERF project node resolved by the PerfServer controller.
+func GetPerfProjectId(ps *v1alpha1.PerfServer) (int, error) {
+	if ps.Status.ProjectId == 0 {
+		return 0, errors.Errorf("PERF project of %v PerfServer hasn't been resolved yet", ps.Name)
+	}
+	return ps.Status.ProjectId, nil
+}
+
 func GetConfigMap(client client.Client, name, namespace string) (*v1.ConfigMap, error) {
 	cm := &v1.ConfigMap{}
 	err := client.Get(context.TODO(), types.NamespacedName{
@@ -68,3 +155,29 @@ func GetCodebase(client client.Client, name, namespace string) (*codebaseApi.Cod
 	}
 	return i, nil
 }
+
+func GetCodebases(c client.Client, namespace string, selector labels.Selector) ([]codebaseApi.Codebase, error) {
+	l := &codebaseApi.CodebaseList{}
+	if err := c.List(context.TODO(), &client.ListOptions{
+		Namespace:     namespace,
+		LabelSelector: selector,
+	}, l); err != nil {
+		return nil, err
+	}
+	return l.Items, nil
+}
+
+// GetEdpComponentUrl returns the url published by the EDPComponent.
+func GetEdpComponentUrl(c client.Client, name, namespace string) (string, error) {
+	comp := &edpCompApi.EDPComponent{}
+	if err := c.Get(context.TODO(), types.NamespacedName{
+		Namespace: namespace,
+		Name:      name,
+	}, comp); err != nil {
+		return "", errors.Wrapf(err, "couldn't get %v EDP component", name)
+	}
+	if comp.Spec.Url == "" {
+		return "", errors.Errorf("EDP component %v doesn't have url", name)
+	}
+	return comp.Spec.Url, nil
+}
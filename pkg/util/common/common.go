@@ -11,12 +11,3 @@ func SortArray(array []string) {
 		return array[i] < array[j]
 	})
 }
-
-func ConvertToStringArray(conf interface{}) []string {
-	aInterface := conf.([]interface{})
-	aString := make([]string, len(aInterface))
-	for i, v := range aInterface {
-		aString[i] = v.(string)
-	}
-	return aString
-}
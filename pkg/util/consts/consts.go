@@ -1,6 +1,7 @@
 package consts
 
 const (
-	PerfServerKind = "PerfServer"
-	CodebaseKind   = "Codebase"
+	PerfServerKind        = "PerfServer"
+	ClusterPerfServerKind = "ClusterPerfServer"
+	CodebaseKind          = "Codebase"
 )
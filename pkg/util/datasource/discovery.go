@@ -0,0 +1,105 @@
+package datasource
+
+import (
+	"github.com/pkg/errors"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	defaultDiscoveryInterval = 15 * time.Minute
+	regexPatternPrefix       = "regex:"
+)
+
+// GetDiscoveryInterval parses the discovery interval of the data source, 15m is used if it's empty.
+func GetDiscoveryInterval(interval string) (time.Duration, error) {
+	if interval == "" {
+		return defaultDiscoveryInterval, nil
+	}
+	d, err := time.ParseDuration(interval)
+	if err != nil {
+		return 0, errors.Wrapf(err, "couldn't parse %v discovery interval", interval)
+	}
+	return d, nil
+}
+
+// MatchPatterns reports if the name matches any of the patterns. A pattern is a glob unless it has the regex: prefix.
+func MatchPatterns(patterns []string, name string) (bool, error) {
+	for _, p := range patterns {
+		matched, err := matchPattern(p, name)
+		if err != nil {
+			return false, err
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ValidatePattern checks that the glob or regex: pattern can be parsed.
+func ValidatePattern(pattern string) error {
+	_, err := matchPattern(pattern, "")
+	return err
+}
+
+func matchPattern(pattern, name string) (bool, error) {
+	if strings.HasPrefix(pattern, regexPatternPrefix) {
+		matched, err := regexp.MatchString(strings.TrimPrefix(pattern, regexPatternPrefix), name)
+		if err != nil {
+			return false, errors.Wrapf(err, "couldn't parse %v pattern", pattern)
+		}
+		return matched, nil
+	}
+	matched, err := path.Match(pattern, name)
+	if err != nil {
+		return false, errors.Wrapf(err, "couldn't parse %v pattern", pattern)
+	}
+	return matched, nil
+}
+
+// FilterEntries leaves the entries matching any of the include patterns and none of the exclude ones.
+// All entries are included if there are no include patterns.
+func FilterEntries(entries, include, exclude []string) ([]string, error) {
+	var res []string
+	for _, e := range entries {
+		if len(include) > 0 {
+			matched, err := MatchPatterns(include, e)
+			if err != nil {
+				return nil, err
+			}
+			if !matched {
+				continue
+			}
+		}
+		matched, err := MatchPatterns(exclude, e)
+		if err != nil {
+			return nil, err
+		}
+		if !matched {
+			res = append(res, e)
+		}
+	}
+	return res, nil
+}
+
+// MergeEntries appends the entries of b missing in a keeping the order.
+func MergeEntries(a, b []string) []string {
+	if len(b) == 0 {
+		return a
+	}
+	res := append([]string{}, a...)
+	seen := make(map[string]bool, len(a)+len(b))
+	for _, x := range a {
+		seen[x] = true
+	}
+	for _, x := range b {
+		if !seen[x] {
+			seen[x] = true
+			res = append(res, x)
+		}
+	}
+	return res
+}
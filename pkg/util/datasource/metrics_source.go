@@ -0,0 +1,97 @@
+package datasource
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/command"
+	"github.com/epmd-edp/perf-operator/v2/pkg/model/dto"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/cluster"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"time"
+)
+
+var log = logf.Log.WithName("metrics_source")
+
+// MetricsSource is a PERF data source whose metrics are calculated by the operator and pushed to PERF,
+// e.g. the one of a PerfDataSourceTekton or PerfDoraMetrics.
+type MetricsSource struct {
+	Namespace      string
+	PerfServerKind string
+	PerfServerName string
+	PerfNode       string
+	CreatePerfNode bool
+	Key            perf.DataSourceKey
+}
+
+// UpdateCommandFunc returns the command to update the existing PERF data source, nil if there is nothing to update.
+type UpdateCommandFunc func(dsReq *dto.DataSource) (*command.DataSourceCommand, error)
+
+// PutMetricsSource creates the data source in PERF, or activates and updates the existing one.
+func PutMetricsSource(c client.Client, perfClient perf.PerfClient, s MetricsSource,
+	create command.DataSourceCommand, update UpdateCommandFunc) error {
+	nodeId, err := resolveMetricsSourceNode(c, perfClient, s)
+	if err != nil {
+		return err
+	}
+
+	dsReq, err := perfClient.GetProjectDataSource(nodeId, s.Key)
+	if err != nil {
+		return err
+	}
+	if dsReq == nil {
+		return perfClient.CreateDataSource(nodeId, create)
+	}
+
+	log.Info("PERF data source already exists. try to update.", "type", s.Key.Type, "name", dsReq.Name)
+	if !dsReq.Active {
+		if err := perfClient.ActivateDataSource(dsReq.Id); err != nil {
+			return err
+		}
+	}
+	cmd, err := update(dsReq)
+	if err != nil {
+		return err
+	}
+	if cmd == nil {
+		log.Info("nothing to update in PERF data source", "name", dsReq.Name)
+		return nil
+	}
+	return perfClient.UpdateDataSource(*cmd)
+}
+
+// PushSourceMetrics pushes the metrics to the PERF data source, which has to exist.
+func PushSourceMetrics(c client.Client, perfClient perf.PerfClient, s MetricsSource, metrics interface{}) error {
+	nodeId, err := resolveMetricsSourceNode(c, perfClient, s)
+	if err != nil {
+		return err
+	}
+
+	dsReq, err := perfClient.GetProjectDataSource(nodeId, s.Key)
+	if err != nil {
+		return err
+	}
+	if dsReq == nil {
+		return errors.Errorf("PERF %v data source wasn't found", s.Key.Type)
+	}
+
+	return perfClient.PushDataSourceMetrics(command.DataSourceMetricsCommand{
+		DataSourceId: dsReq.Id,
+		Timestamp:    time.Now().Unix(),
+		Metrics:      metrics,
+	})
+}
+
+func resolveMetricsSourceNode(c client.Client, perfClient perf.PerfClient, s MetricsSource) (int, error) {
+	ps, err := cluster.GetPerfServerCr(c, s.PerfServerKind, s.PerfServerName, s.Namespace)
+	if err != nil {
+		return 0, err
+	}
+
+	projectId, err := cluster.GetPerfProjectId(ps)
+	if err != nil {
+		return 0, err
+	}
+
+	return perf.ResolveNode(perfClient, projectId, s.PerfNode, s.CreatePerfNode)
+}
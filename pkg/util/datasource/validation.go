@@ -0,0 +1,120 @@
+package datasource
+
+import (
+	"fmt"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/pkg/errors"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"strings"
+	"time"
+)
+
+// ValidationResult is the result of checking the data source entries against the source system.
+type ValidationResult struct {
+	ConditionType string
+	// Entries names the checked entries in messages, e.g. SonarQube project keys.
+	Entries string
+	Unknown []string
+	// Err is set if the source system couldn't be checked.
+	Err error
+}
+
+const (
+	ReasonEntriesFound     = "EntriesFound"
+	ReasonUnknownEntries   = "UnknownEntries"
+	ReasonValidationFailed = "ValidationFailed"
+)
+
+// ReportValidation records the result in the conditions and, if some entries are unknown or weren't checked,
+// in a warning Event of the data source.
+func ReportValidation(recorder record.EventRecorder, obj runtime.Object,
+	conds []v1alpha1.DataSourceCondition, r ValidationResult) []v1alpha1.DataSourceCondition {
+	c := v1alpha1.DataSourceCondition{
+		Type:    r.ConditionType,
+		Status:  v1alpha1.ConditionTrue,
+		Reason:  ReasonEntriesFound,
+		Message: fmt.Sprintf("all %v have been found", r.Entries),
+	}
+	switch {
+	case r.Err != nil:
+		c.Status = v1alpha1.ConditionUnknown
+		c.Reason = ReasonValidationFailed
+		c.Message = fmt.Sprintf("couldn't check %v: %v", r.Entries, r.Err)
+	case len(r.Unknown) > 0:
+		c.Status = v1alpha1.ConditionFalse
+		c.Reason = ReasonUnknownEntries
+		c.Message = fmt.Sprintf("unknown %v: %v", r.Entries, strings.Join(r.Unknown, ", "))
+	}
+	if c.Status != v1alpha1.ConditionTrue {
+		recorder.Event(obj, coreV1.EventTypeWarning, c.Reason, c.Message)
+	}
+	return SetCondition(conds, c)
+}
+
+// EnforceValidation returns an error with the block policy if some entries are unknown or weren't checked.
+func EnforceValidation(policy string, results ...ValidationResult) error {
+	if policy != v1alpha1.ValidationBlock {
+		return nil
+	}
+	var msgs []string
+	for _, r := range results {
+		if r.Err != nil {
+			msgs = append(msgs, fmt.Sprintf("couldn't check %v: %v", r.Entries, r.Err))
+		} else if len(r.Unknown) > 0 {
+			msgs = append(msgs, fmt.Sprintf("unknown %v: %v", r.Entries, strings.Join(r.Unknown, ", ")))
+		}
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	return errors.Errorf("data source update is blocked by validation. %v", strings.Join(msgs, "; "))
+}
+
+// GetValidEntries leaves out the unknown entries with the skip policy.
+func GetValidEntries(policy string, entries, unknown []string) []string {
+	if policy != v1alpha1.ValidationSkip || len(unknown) == 0 {
+		return entries
+	}
+	return GetMissingElementsInDataSource(entries, unknown)
+}
+
+// SetCondition adds the condition or replaces the one of the same type.
+// The transition time is kept if the status doesn't change.
+func SetCondition(conds []v1alpha1.DataSourceCondition, c v1alpha1.DataSourceCondition) []v1alpha1.DataSourceCondition {
+	for i := range conds {
+		if conds[i].Type != c.Type {
+			continue
+		}
+		c.LastTransitionTime = conds[i].LastTransitionTime
+		if conds[i].Status != c.Status {
+			c.LastTransitionTime = time.Now()
+		}
+		conds[i] = c
+		return conds
+	}
+	c.LastTransitionTime = time.Now()
+	return append(conds, c)
+}
+
+// RemoveCondition removes the condition of the given type.
+func RemoveCondition(conds []v1alpha1.DataSourceCondition, condType string) []v1alpha1.DataSourceCondition {
+	var res []v1alpha1.DataSourceCondition
+	for _, c := range conds {
+		if c.Type != condType {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// HasFailedCondition reports if the condition of the given type isn't True.
+func HasFailedCondition(conds []v1alpha1.DataSourceCondition, condType string) bool {
+	for _, c := range conds {
+		if c.Type == condType {
+			return c.Status != v1alpha1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package dora
+
+import (
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"time"
+)
+
+const DefaultArgoCdNamespace = "argocd"
+
+var ApplicationResource = schema.GroupVersionResource{
+	Group:    "argoproj.io",
+	Version:  "v1alpha1",
+	Resource: "applications",
+}
+
+type ApplicationClient interface {
+	ListApplications(namespace string) ([]unstructured.Unstructured, error)
+}
+
+type ApplicationClientAdapter struct {
+	client dynamic.Interface
+}
+
+func NewApplicationClient(config *rest.Config) (*ApplicationClientAdapter, error) {
+	cl, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't create dynamic client for Argo CD resources")
+	}
+	return &ApplicationClientAdapter{client: cl}, nil
+}
+
+func (c ApplicationClientAdapter) ListApplications(namespace string) ([]unstructured.Unstructured, error) {
+	list, err := c.client.Resource(ApplicationResource).Namespace(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't list Argo CD Applications in %v namespace", namespace)
+	}
+	return list.Items, nil
+}
+
+// FromApplications converts the sync history of Argo CD Applications deployed to the given namespace
+// into deployment events. A sync to a revision that had already been deployed before the previous one
+// is treated as a rollback.
+func FromApplications(apps []unstructured.Unstructured, namespace string) ([]Deployment, error) {
+	var dd []Deployment
+	for _, app := range apps {
+		dest, _, err := unstructured.NestedString(app.Object, "spec", "destination", "namespace")
+		if err != nil {
+			return nil, errors.Wrapf(err, "couldn't read destination of %v Application", app.GetName())
+		}
+		if dest != namespace {
+			continue
+		}
+
+		history, _, err := unstructured.NestedSlice(app.Object, "status", "history")
+		if err != nil {
+			return nil, errors.Wrapf(err, "couldn't read history of %v Application", app.GetName())
+		}
+
+		seen := make(map[string]bool)
+		prev := ""
+		for _, h := range history {
+			item, ok := h.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			revision, _, _ := unstructured.NestedString(item, "revision")
+			deployedAt, _, _ := unstructured.NestedString(item, "deployedAt")
+			t, err := time.Parse(time.RFC3339, deployedAt)
+			if err != nil {
+				continue
+			}
+			dd = append(dd, Deployment{
+				Workload:   app.GetName(),
+				Commit:     revision,
+				DeployedAt: t,
+				Rollback:   revision != prev && seen[revision],
+			})
+			seen[revision] = true
+			prev = revision
+		}
+	}
+	return dd, nil
+}
@@ -0,0 +1,85 @@
+package dora
+
+import (
+	"fmt"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"sort"
+	"time"
+)
+
+// Deployment is a single rollout of a workload to a stage.
+type Deployment struct {
+	Workload   string
+	Commit     string
+	CommitTime time.Time
+	DeployedAt time.Time
+	// Rollback marks a deployment that restored a previously deployed revision.
+	Rollback bool
+	// Revision orders the deployments of a workload if it's set, DeployedAt orders them otherwise.
+	Revision int64
+	// TimeUnknown marks a deployment whose DeployedAt is only the time of the previous one,
+	// it isn't used for the time to restore.
+	TimeUnknown bool
+}
+
+// Calculate returns rolling DORA metrics of a stage for deployments made between since and now.
+// A deployment that was followed by a rollback of the same workload is considered a failed change,
+// and the rollback time, if it's known, is used to calculate the time to restore.
+func Calculate(stage string, deployments []Deployment, since, now time.Time) v1alpha1.DoraStageMetrics {
+	byWorkload := make(map[string][]Deployment)
+	for _, d := range deployments {
+		if d.DeployedAt.IsZero() {
+			continue
+		}
+		byWorkload[d.Workload] = append(byWorkload[d.Workload], d)
+	}
+
+	var (
+		changes, failures, leadTimes, restores int
+		leadTime, restoreTime                  time.Duration
+	)
+	for _, dd := range byWorkload {
+		sort.Slice(dd, func(i, j int) bool {
+			if dd[i].Revision != 0 && dd[j].Revision != 0 {
+				return dd[i].Revision < dd[j].Revision
+			}
+			return dd[i].DeployedAt.Before(dd[j].DeployedAt)
+		})
+		for i, d := range dd {
+			if d.DeployedAt.Before(since) {
+				continue
+			}
+			if d.Rollback {
+				if i > 0 && !dd[i-1].Rollback {
+					failures++
+					if !d.TimeUnknown {
+						restores++
+						restoreTime += d.DeployedAt.Sub(dd[i-1].DeployedAt)
+					}
+				}
+				continue
+			}
+			changes++
+			if !d.CommitTime.IsZero() {
+				leadTimes++
+				leadTime += d.DeployedAt.Sub(d.CommitTime)
+			}
+		}
+	}
+
+	m := v1alpha1.DoraStageMetrics{
+		Stage:               stage,
+		Deployments:         changes,
+		DeploymentFrequency: fmt.Sprintf("%.2f", float64(changes)/now.Sub(since).Hours()*24),
+	}
+	if leadTimes > 0 {
+		m.LeadTimeSeconds = int64(leadTime.Seconds()) / int64(leadTimes)
+	}
+	if changes > 0 {
+		m.ChangeFailureRate = failures * 100 / changes
+	}
+	if restores > 0 {
+		m.MeanTimeToRestoreSeconds = int64(restoreTime.Seconds()) / int64(restores)
+	}
+	return m
+}
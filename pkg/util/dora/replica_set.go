@@ -0,0 +1,136 @@
+package dora
+
+import (
+	appsV1 "k8s.io/api/apps/v1"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultCommitLabel          = "app.edp.epam.com/commit-sha"
+	DefaultCommitTimeAnnotation = "app.edp.epam.com/commit-timestamp"
+
+	revisionAnnotation        = "deployment.kubernetes.io/revision"
+	revisionHistoryAnnotation = "deployment.kubernetes.io/revision-history"
+	deploymentKind            = "Deployment"
+)
+
+// FromReplicaSets converts ReplicaSets owned by Deployments into deployment events ordered by revision.
+// The commit SHA is read from the pod template labels or annotations, as it is usually
+// propagated there from the image labels by the CD pipeline.
+// A ReplicaSet is deployed at its creation with the first of its revisions. A rollback re-promotes an old ReplicaSet
+// with the next revision of the Deployment and moves its previous revisions to the revision history, so each later
+// revision of a ReplicaSet is a rollback. The rollback time isn't recorded in the ReplicaSet: the rollback to
+// the current revision of a Deployment is taken from its Progressing condition, an older one is only known to follow
+// the previous revision and is marked with TimeUnknown.
+func FromReplicaSets(rss []appsV1.ReplicaSet, deployments []appsV1.Deployment,
+	commitLabel, commitTimeAnnotation string) []Deployment {
+	byWorkload := make(map[string][]Deployment)
+	for _, rs := range rss {
+		owner := getDeploymentOwner(rs)
+		if owner == "" {
+			continue
+		}
+		meta := rs.Spec.Template.ObjectMeta
+		commit := meta.Labels[commitLabel]
+		if commit == "" {
+			commit = meta.Annotations[commitLabel]
+		}
+		d := Deployment{
+			Workload:   owner,
+			Commit:     commit,
+			DeployedAt: rs.CreationTimestamp.Time,
+		}
+		if ct, err := time.Parse(time.RFC3339, meta.Annotations[commitTimeAnnotation]); err == nil {
+			d.CommitTime = ct
+		}
+
+		revisions := getRevisions(rs)
+		if len(revisions) == 0 {
+			// the ReplicaSet isn't managed by the Deployment controller, only its creation is known
+			_, d.Rollback = rs.Annotations[revisionHistoryAnnotation]
+			byWorkload[owner] = append(byWorkload[owner], d)
+			continue
+		}
+		for i, r := range revisions {
+			d.Revision = r
+			d.Rollback = i > 0
+			byWorkload[owner] = append(byWorkload[owner], d)
+		}
+	}
+
+	var dd []Deployment
+	for workload, events := range byWorkload {
+		sort.SliceStable(events, func(i, j int) bool {
+			return events[i].Revision < events[j].Revision
+		})
+		current, rolledOutAt := getRollout(deployments, workload)
+		for i := range events {
+			if !events[i].Rollback || events[i].Revision == 0 {
+				continue
+			}
+			if events[i].Revision == current && !rolledOutAt.IsZero() {
+				events[i].DeployedAt = rolledOutAt
+			} else {
+				events[i].TimeUnknown = true
+			}
+			// an unknown rollback time is the time of the previous revision
+			if i > 0 && (events[i].TimeUnknown || events[i].DeployedAt.Before(events[i-1].DeployedAt)) {
+				events[i].DeployedAt = events[i-1].DeployedAt
+			}
+		}
+		dd = append(dd, events...)
+	}
+	return dd
+}
+
+// getRevisions returns the revisions of the ReplicaSet in ascending order: the ones of its history and the current one.
+func getRevisions(rs appsV1.ReplicaSet) []int64 {
+	current, err := strconv.ParseInt(rs.Annotations[revisionAnnotation], 10, 64)
+	if err != nil {
+		return nil
+	}
+	revisions := []int64{current}
+	if h := rs.Annotations[revisionHistoryAnnotation]; h != "" {
+		for _, v := range strings.Split(h, ",") {
+			if r, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64); err == nil {
+				revisions = append(revisions, r)
+			}
+		}
+	}
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i] < revisions[j]
+	})
+	return revisions
+}
+
+// getRollout returns the current revision of the Deployment and the last time its rollout progressed.
+func getRollout(deployments []appsV1.Deployment, name string) (int64, time.Time) {
+	for _, d := range deployments {
+		if d.Name != name {
+			continue
+		}
+		revision, err := strconv.ParseInt(d.Annotations[revisionAnnotation], 10, 64)
+		if err != nil {
+			return 0, time.Time{}
+		}
+		for _, c := range d.Status.Conditions {
+			if c.Type == appsV1.DeploymentProgressing {
+				return revision, c.LastUpdateTime.Time
+			}
+		}
+		return revision, time.Time{}
+	}
+	return 0, time.Time{}
+}
+
+func getDeploymentOwner(rs appsV1.ReplicaSet) string {
+	for _, o := range rs.OwnerReferences {
+		if o.Kind == deploymentKind {
+			return o.Name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,35 @@
+package tekton
+
+import (
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+)
+
+type PipelineRunClient interface {
+	ListPipelineRuns(namespace, selector string) ([]unstructured.Unstructured, error)
+}
+
+type PipelineRunClientAdapter struct {
+	client dynamic.Interface
+}
+
+func NewPipelineRunClient(config *rest.Config) (*PipelineRunClientAdapter, error) {
+	cl, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't create dynamic client for Tekton resources")
+	}
+	return &PipelineRunClientAdapter{client: cl}, nil
+}
+
+func (c PipelineRunClientAdapter) ListPipelineRuns(namespace, selector string) ([]unstructured.Unstructured, error) {
+	list, err := c.client.Resource(PipelineRunResource).Namespace(namespace).List(metav1.ListOptions{
+		LabelSelector: selector,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't list PipelineRuns in %v namespace", namespace)
+	}
+	return list.Items, nil
+}
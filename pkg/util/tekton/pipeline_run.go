@@ -0,0 +1,109 @@
+package tekton
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sort"
+	"time"
+)
+
+const (
+	DefaultCodebaseLabel = "app.edp.epam.com/codebase"
+
+	succeededCondition = "Succeeded"
+)
+
+var PipelineRunResource = schema.GroupVersionResource{
+	Group:    "tekton.dev",
+	Version:  "v1beta1",
+	Resource: "pipelineruns",
+}
+
+type PipelineRun struct {
+	Name           string
+	Codebase       string
+	Finished       bool
+	Succeeded      bool
+	StartTime      time.Time
+	CompletionTime time.Time
+}
+
+// ConvertPipelineRun reads the fields used for metrics from a PipelineRun object.
+func ConvertPipelineRun(u unstructured.Unstructured, codebaseLabel string) (*PipelineRun, error) {
+	pr := &PipelineRun{
+		Name:     u.GetName(),
+		Codebase: u.GetLabels()[codebaseLabel],
+	}
+
+	conditions, _, err := unstructured.NestedSlice(u.Object, "status", "conditions")
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't read conditions of %v PipelineRun", pr.Name)
+	}
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok || cond["type"] != succeededCondition {
+			continue
+		}
+		switch cond["status"] {
+		case "True":
+			pr.Finished, pr.Succeeded = true, true
+		case "False":
+			pr.Finished = true
+		}
+	}
+
+	if pr.StartTime, err = getTime(u, "startTime"); err != nil {
+		return nil, errors.Wrapf(err, "couldn't read start time of %v PipelineRun", pr.Name)
+	}
+	if pr.CompletionTime, err = getTime(u, "completionTime"); err != nil {
+		return nil, errors.Wrapf(err, "couldn't read completion time of %v PipelineRun", pr.Name)
+	}
+	return pr, nil
+}
+
+func getTime(u unstructured.Unstructured, field string) (time.Time, error) {
+	val, found, err := unstructured.NestedString(u.Object, "status", field)
+	if err != nil || !found || val == "" {
+		return time.Time{}, err
+	}
+	return time.Parse(time.RFC3339, val)
+}
+
+// CalculateMetrics groups finished PipelineRuns started after since by codebase
+// and calculates build count, success rate and average duration for each of them.
+func CalculateMetrics(runs []PipelineRun, since time.Time) []v1alpha1.PipelineRunMetrics {
+	byCodebase := make(map[string]*v1alpha1.PipelineRunMetrics)
+	durations := make(map[string]time.Duration)
+	for _, r := range runs {
+		if !r.Finished || r.Codebase == "" || r.StartTime.Before(since) {
+			continue
+		}
+		m, ok := byCodebase[r.Codebase]
+		if !ok {
+			m = &v1alpha1.PipelineRunMetrics{Codebase: r.Codebase}
+			byCodebase[r.Codebase] = m
+		}
+		m.Total++
+		if r.Succeeded {
+			m.Succeeded++
+		} else {
+			m.Failed++
+		}
+		if !r.CompletionTime.IsZero() {
+			durations[r.Codebase] += r.CompletionTime.Sub(r.StartTime)
+		}
+	}
+
+	metrics := make([]v1alpha1.PipelineRunMetrics, 0, len(byCodebase))
+	for name, m := range byCodebase {
+		m.SuccessRate = m.Succeeded * 100 / m.Total
+		m.AverageDurationSeconds = int64(durations[name].Seconds()) / int64(m.Total)
+		metrics = append(metrics, *m)
+	}
+	sort.Slice(metrics, func(i, j int) bool {
+		return metrics[i].Codebase < metrics[j].Codebase
+	})
+	return metrics
+}
@@ -0,0 +1,49 @@
+package tool
+
+import (
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+)
+
+var (
+	JenkinsResource = schema.GroupVersionResource{
+		Group:    "v2.edp.epam.com",
+		Version:  "v1alpha1",
+		Resource: "jenkins",
+	}
+	SonarResource = schema.GroupVersionResource{
+		Group:    "v2.edp.epam.com",
+		Version:  "v1alpha1",
+		Resource: "sonars",
+	}
+)
+
+// Client reads the CRs of the EDP Jenkins and Sonar operators as unstructured objects,
+// so the perf operator doesn't depend on their APIs.
+type Client interface {
+	GetTool(resource schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error)
+}
+
+type ClientAdapter struct {
+	client dynamic.Interface
+}
+
+func NewClient(config *rest.Config) (*ClientAdapter, error) {
+	cl, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't create dynamic client for Jenkins and Sonar resources")
+	}
+	return &ClientAdapter{client: cl}, nil
+}
+
+func (c ClientAdapter) GetTool(resource schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
+	t, err := c.client.Resource(resource).Namespace(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't get %v %v in %v namespace", name, resource.Resource, namespace)
+	}
+	return t, nil
+}
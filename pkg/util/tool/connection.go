@@ -0,0 +1,67 @@
+package tool
+
+import (
+	"context"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/cluster"
+	"github.com/pkg/errors"
+	coreV1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// JenkinsSecretSuffix names the admin API token Secret the Jenkins operator creates for a Jenkins CR.
+	JenkinsSecretSuffix = "-admin-token"
+	// PasswordSecretSuffix names the admin password Secret the Sonar operator creates for a Sonar CR,
+	// GitLab credentials follow the same convention.
+	PasswordSecretSuffix = "-admin-password"
+)
+
+// Connection is the url of a tool and the Secret with the credentials of its PERF data source.
+type Connection struct {
+	Url            string
+	CredentialName string
+}
+
+// GetOperatorConnection returns the connection of a Jenkins or Sonar CR. The url is the status.externalUrl of the CR
+// or, if it's empty, the url of the EDPComponent the operator publishes under the CR name. The credentials are taken
+// from the Secret the operator creates for the CR, named after it with the suffix.
+func GetOperatorConnection(tc Client, c client.Client, resource schema.GroupVersionResource,
+	namespace, name, secretSuffix string) (Connection, error) {
+	t, err := tc.GetTool(resource, namespace, name)
+	if err != nil {
+		return Connection{}, err
+	}
+
+	conn := Connection{CredentialName: name + secretSuffix}
+	conn.Url, _, _ = unstructured.NestedString(t.Object, "status", "externalUrl")
+	if conn.Url == "" {
+		if conn.Url, err = cluster.GetEdpComponentUrl(c, name, namespace); err != nil {
+			return Connection{}, err
+		}
+	}
+	return conn, nil
+}
+
+// GetComponentConnection returns the connection of an EDPComponent. The credentials are taken from the Secret
+// named after the component with the suffix if it exists, and from the default Secret otherwise.
+func GetComponentConnection(c client.Client, namespace, name, secretSuffix, defaultSecret string) (Connection, error) {
+	u, err := cluster.GetEdpComponentUrl(c, name, namespace)
+	if err != nil {
+		return Connection{}, err
+	}
+
+	conn := Connection{Url: u, CredentialName: defaultSecret}
+	s := &coreV1.Secret{}
+	err = c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name + secretSuffix}, s)
+	switch {
+	case err == nil:
+		conn.CredentialName = s.Name
+	case !k8serrors.IsNotFound(err):
+		return Connection{}, errors.Wrapf(err, "couldn't get %v secret", name+secretSuffix)
+	}
+	return conn, nil
+}
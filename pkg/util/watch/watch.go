@@ -0,0 +1,230 @@
+package watch
+
+import (
+	"context"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/cluster"
+	"github.com/pkg/errors"
+	coreV1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"os"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	watchNamespaceEnv          = "WATCH_NAMESPACE"
+	namespaceSelectorEnv       = "WATCH_NAMESPACE_SELECTOR"
+	maxConcurrentReconcilesEnv = "PERF_MAX_CONCURRENT_RECONCILES"
+	namespaceConcurrencyEnv    = "PERF_NAMESPACE_CONCURRENCY"
+
+	defaultMaxConcurrentReconciles = 4
+	defaultNamespaceConcurrency    = 1
+	// busyNamespaceDelay is the delay a request is put back with while its namespace has no free reconciliation slot.
+	busyNamespaceDelay = 2 * time.Second
+)
+
+// GetCacheNamespace returns the namespace the manager's cache is restricted to. The cache holds all namespaces
+// if WATCH_NAMESPACE is empty or lists several namespaces, if WATCH_NAMESPACE_SELECTOR is set, or if the watched
+// namespace isn't the operator one, which ClusterPerfServer Secrets and ConfigMaps are read from.
+func GetCacheNamespace() (string, error) {
+	multi, err := IsMultiNamespace()
+	if err != nil || multi {
+		return "", err
+	}
+	names, err := getWatchNamespaces()
+	if err != nil {
+		return "", err
+	}
+	if ns, err := cluster.GetOperatorNamespace(); err == nil && ns != names[0] {
+		return "", nil
+	}
+	return names[0], nil
+}
+
+// IsMultiNamespace reports if the operator serves more than one namespace.
+func IsMultiNamespace() (bool, error) {
+	names, err := getWatchNamespaces()
+	if err != nil {
+		return false, err
+	}
+	return len(names) != 1 || os.Getenv(namespaceSelectorEnv) != "", nil
+}
+
+func getWatchNamespaces() ([]string, error) {
+	v, found := os.LookupEnv(watchNamespaceEnv)
+	if !found {
+		return nil, errors.Errorf("%v must be set", watchNamespaceEnv)
+	}
+	var names []string
+	for _, n := range strings.Split(v, ",") {
+		if n = strings.TrimSpace(n); n != "" {
+			names = append(names, n)
+		}
+	}
+	return names, nil
+}
+
+// Filter decides which namespaces the operator serves: the ones listed in WATCH_NAMESPACE (all if it's empty)
+// whose labels match WATCH_NAMESPACE_SELECTOR (any if it's empty). Cluster-scoped objects are always served.
+type Filter struct {
+	client   client.Client
+	names    map[string]bool
+	selector labels.Selector
+}
+
+func NewFilter(c client.Client, names []string, selector labels.Selector) *Filter {
+	f := &Filter{client: c, selector: selector}
+	if len(names) > 0 {
+		f.names = make(map[string]bool, len(names))
+		for _, n := range names {
+			f.names[n] = true
+		}
+	}
+	return f
+}
+
+// GetFilter returns the filter configured with WATCH_NAMESPACE and WATCH_NAMESPACE_SELECTOR.
+func GetFilter(c client.Client) (*Filter, error) {
+	names, err := getWatchNamespaces()
+	if err != nil {
+		return nil, err
+	}
+
+	var selector labels.Selector
+	if v := os.Getenv(namespaceSelectorEnv); v != "" {
+		selector, err = labels.Parse(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "couldn't parse %v namespace selector", v)
+		}
+	}
+	return NewFilter(c, names, selector), nil
+}
+
+// Serves reports if the namespace is served by the operator.
+func (f *Filter) Serves(namespace string) (bool, error) {
+	if namespace == "" {
+		return true, nil
+	}
+	if f.names != nil && !f.names[namespace] {
+		return false, nil
+	}
+	if f.selector == nil {
+		return true, nil
+	}
+
+	ns := &coreV1.Namespace{}
+	if err := f.client.Get(context.TODO(), types.NamespacedName{Name: namespace}, ns); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "couldn't get %v namespace", namespace)
+	}
+	return f.selector.Matches(labels.Set(ns.Labels)), nil
+}
+
+// Reconciler serves the requests of the operator namespaces only and runs at most limit reconciliations
+// of a namespace at once, so a namespace with many CRs doesn't hold up the others.
+type Reconciler struct {
+	reconcile.Reconciler
+	filter *Filter
+	limit  int
+
+	mu      sync.Mutex
+	running map[string]int
+}
+
+func NewReconciler(r reconcile.Reconciler, filter *Filter, limit int) *Reconciler {
+	return &Reconciler{
+		Reconciler: r,
+		filter:     filter,
+		limit:      limit,
+		running:    make(map[string]int),
+	}
+}
+
+func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	served, err := r.filter.Serves(request.Namespace)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !served {
+		return reconcile.Result{}, nil
+	}
+
+	if !r.acquire(request.Namespace) {
+		return reconcile.Result{RequeueAfter: busyNamespaceDelay}, nil
+	}
+	defer r.release(request.Namespace)
+	return r.Reconciler.Reconcile(request)
+}
+
+func (r *Reconciler) acquire(namespace string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.running[namespace] >= r.limit {
+		return false
+	}
+	r.running[namespace]++
+	return true
+}
+
+func (r *Reconciler) release(namespace string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.running[namespace]--
+	if r.running[namespace] <= 0 {
+		delete(r.running, namespace)
+	}
+}
+
+// GetControllerOptions returns the options of a perf controller. The reconciler is wrapped to serve the operator
+// namespaces with PERF_NAMESPACE_CONCURRENCY (1 by default) reconciliations per namespace. The controller runs
+// PERF_MAX_CONCURRENT_RECONCILES reconciliations in total, 4 by default if several namespaces are served
+// and 1 otherwise.
+func GetControllerOptions(mgr manager.Manager, r reconcile.Reconciler) (controller.Options, error) {
+	filter, err := GetFilter(mgr.GetClient())
+	if err != nil {
+		return controller.Options{}, err
+	}
+
+	multi, err := IsMultiNamespace()
+	if err != nil {
+		return controller.Options{}, err
+	}
+	total := 1
+	if multi {
+		total = defaultMaxConcurrentReconciles
+	}
+	if total, err = getPositiveInt(maxConcurrentReconcilesEnv, total); err != nil {
+		return controller.Options{}, err
+	}
+	limit, err := getPositiveInt(namespaceConcurrencyEnv, defaultNamespaceConcurrency)
+	if err != nil {
+		return controller.Options{}, err
+	}
+
+	return controller.Options{
+		Reconciler:              NewReconciler(r, filter, limit),
+		MaxConcurrentReconciles: total,
+	}, nil
+}
+
+func getPositiveInt(env string, defaultValue int) (int, error) {
+	v := os.Getenv(env)
+	if v == "" {
+		return defaultValue, nil
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil || i < 1 {
+		return 0, errors.Errorf("%v must be a positive number, got %v", env, v)
+	}
+	return i, nil
+}
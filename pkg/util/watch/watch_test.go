@@ -0,0 +1,180 @@
+package watch
+
+import (
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"os"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"testing"
+)
+
+const (
+	fakeNamespace      = "edp-team-a"
+	fakeOtherNamespace = "edp-team-b"
+)
+
+type countingReconciler struct {
+	calls int
+}
+
+func (r *countingReconciler) Reconcile(reconcile.Request) (reconcile.Result, error) {
+	r.calls++
+	return reconcile.Result{}, nil
+}
+
+func createNamespace(name string, l map[string]string) *coreV1.Namespace {
+	return &coreV1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: l}}
+}
+
+func createRequest(namespace string) reconcile.Request {
+	return reconcile.Request{NamespacedName: types.NamespacedName{Name: "fake-name", Namespace: namespace}}
+}
+
+func setEnv(t *testing.T, env map[string]string) {
+	for _, k := range []string{watchNamespaceEnv, namespaceSelectorEnv, "POD_NAMESPACE"} {
+		assert.NoError(t, os.Unsetenv(k))
+	}
+	for k, v := range env {
+		assert.NoError(t, os.Setenv(k, v))
+	}
+}
+
+func TestFilter_Serves_ShouldServeListedNamespacesOnly(t *testing.T) {
+	f := NewFilter(nil, []string{fakeNamespace}, nil)
+
+	served, err := f.Serves(fakeNamespace)
+	assert.NoError(t, err)
+	assert.True(t, served)
+
+	served, err = f.Serves(fakeOtherNamespace)
+	assert.NoError(t, err)
+	assert.False(t, served)
+}
+
+func TestFilter_Serves_ShouldServeAllNamespacesAndClusterScopeWithoutList(t *testing.T) {
+	f := NewFilter(nil, nil, nil)
+
+	for _, ns := range []string{fakeNamespace, fakeOtherNamespace, ""} {
+		served, err := f.Serves(ns)
+		assert.NoError(t, err)
+		assert.True(t, served)
+	}
+}
+
+func TestFilter_Serves_ShouldMatchNamespaceLabels(t *testing.T) {
+	selector, err := labels.Parse("app.edp.epam.com/perf=true")
+	assert.NoError(t, err)
+	c := fake.NewFakeClient(
+		createNamespace(fakeNamespace, map[string]string{"app.edp.epam.com/perf": "true"}),
+		createNamespace(fakeOtherNamespace, nil))
+	f := NewFilter(c, nil, selector)
+
+	served, err := f.Serves(fakeNamespace)
+	assert.NoError(t, err)
+	assert.True(t, served)
+
+	served, err = f.Serves(fakeOtherNamespace)
+	assert.NoError(t, err)
+	assert.False(t, served)
+
+	served, err = f.Serves("missing")
+	assert.NoError(t, err)
+	assert.False(t, served)
+}
+
+func TestFilter_Serves_ShouldCheckListBeforeSelector(t *testing.T) {
+	selector, err := labels.Parse("app.edp.epam.com/perf=true")
+	assert.NoError(t, err)
+	c := fake.NewFakeClient(createNamespace(fakeOtherNamespace, map[string]string{"app.edp.epam.com/perf": "true"}))
+	f := NewFilter(c, []string{fakeNamespace}, selector)
+
+	served, err := f.Serves(fakeOtherNamespace)
+	assert.NoError(t, err)
+	assert.False(t, served)
+}
+
+func TestReconciler_ShouldLimitReconciliationsPerNamespace(t *testing.T) {
+	r := NewReconciler(&countingReconciler{}, NewFilter(nil, nil, nil), 2)
+
+	assert.True(t, r.acquire(fakeNamespace))
+	assert.True(t, r.acquire(fakeNamespace))
+	assert.False(t, r.acquire(fakeNamespace))
+	assert.True(t, r.acquire(fakeOtherNamespace))
+
+	r.release(fakeNamespace)
+	assert.True(t, r.acquire(fakeNamespace))
+
+	r.release(fakeNamespace)
+	r.release(fakeNamespace)
+	r.release(fakeOtherNamespace)
+	assert.Empty(t, r.running)
+}
+
+func TestReconciler_ShouldRequeueBusyNamespace(t *testing.T) {
+	cr := &countingReconciler{}
+	r := NewReconciler(cr, NewFilter(nil, nil, nil), 1)
+	assert.True(t, r.acquire(fakeNamespace))
+
+	res, err := r.Reconcile(createRequest(fakeNamespace))
+	assert.NoError(t, err)
+	assert.Equal(t, busyNamespaceDelay, res.RequeueAfter)
+	assert.Equal(t, 0, cr.calls)
+
+	_, err = r.Reconcile(createRequest(fakeOtherNamespace))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, cr.calls)
+	assert.Equal(t, 1, r.running[fakeNamespace])
+	assert.NotContains(t, r.running, fakeOtherNamespace)
+}
+
+func TestReconciler_ShouldDropRequestsOfNotServedNamespaces(t *testing.T) {
+	cr := &countingReconciler{}
+	r := NewReconciler(cr, NewFilter(nil, []string{fakeNamespace}, nil), 1)
+
+	res, err := r.Reconcile(createRequest(fakeOtherNamespace))
+	assert.NoError(t, err)
+	assert.Equal(t, reconcile.Result{}, res)
+	assert.Equal(t, 0, cr.calls)
+
+	_, err = r.Reconcile(createRequest(fakeNamespace))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, cr.calls)
+}
+
+func TestGetCacheNamespace(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected string
+	}{
+		{"operator namespace", map[string]string{watchNamespaceEnv: fakeNamespace, "POD_NAMESPACE": fakeNamespace},
+			fakeNamespace},
+		{"no operator namespace", map[string]string{watchNamespaceEnv: fakeNamespace}, fakeNamespace},
+		{"other namespace", map[string]string{watchNamespaceEnv: fakeOtherNamespace, "POD_NAMESPACE": fakeNamespace},
+			""},
+		{"list", map[string]string{watchNamespaceEnv: fakeNamespace + ", " + fakeOtherNamespace}, ""},
+		{"all namespaces", map[string]string{watchNamespaceEnv: ""}, ""},
+		{"selector", map[string]string{watchNamespaceEnv: fakeNamespace, namespaceSelectorEnv: "perf=true"}, ""},
+	}
+	defer setEnv(t, nil)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setEnv(t, tt.env)
+			ns, err := GetCacheNamespace()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, ns)
+		})
+	}
+}
+
+func TestGetCacheNamespace_ShouldFailWithoutWatchNamespace(t *testing.T) {
+	setEnv(t, nil)
+
+	_, err := GetCacheNamespace()
+	assert.Error(t, err)
+}
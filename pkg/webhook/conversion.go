@@ -0,0 +1,129 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"net/http"
+)
+
+// conversionReview mirrors the apiextensions.k8s.io/v1beta1 ConversionReview sent by the API server.
+type conversionReview struct {
+	metav1.TypeMeta `json:",inline"`
+	Request         *conversionRequest  `json:"request,omitempty"`
+	Response        *conversionResponse `json:"response,omitempty"`
+}
+
+type conversionRequest struct {
+	UID               types.UID              `json:"uid"`
+	DesiredAPIVersion string                 `json:"desiredAPIVersion"`
+	Objects           []runtime.RawExtension `json:"objects"`
+}
+
+type conversionResponse struct {
+	UID              types.UID              `json:"uid"`
+	ConvertedObjects []runtime.RawExtension `json:"convertedObjects"`
+	Result           metav1.Status          `json:"result"`
+}
+
+// statusKeys maps the snake_case status fields of v1alpha1 to their v1 names.
+var statusKeys = map[string]string{
+	"last_time_updated": "lastTimeUpdated",
+	"detailed_message":  "detailedMessage",
+	"project_id":        "projectId",
+	"project_path":      "projectPath",
+}
+
+// conversionHandler converts perf CRs between v1alpha1 and v1. Both versions have the same fields except of
+// the renamed status ones and the time fields, which are RFC 3339 strings in both of them,
+// so the objects are converted as maps and keep the fields unknown to the operator.
+type conversionHandler struct{}
+
+var _ http.Handler = conversionHandler{}
+
+func (h conversionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	review := conversionReview{}
+	if err := json.NewDecoder(r.Body).Decode(&review); err != nil || review.Request == nil {
+		http.Error(w, "couldn't decode conversion review", http.StatusBadRequest)
+		return
+	}
+
+	review.Response = h.review(review.Request)
+	review.Request = nil
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		log.Error(err, "couldn't write conversion response")
+	}
+}
+
+func (h conversionHandler) review(req *conversionRequest) *conversionResponse {
+	resp := &conversionResponse{UID: req.UID}
+	for _, o := range req.Objects {
+		raw, err := convertRaw(o.Raw, req.DesiredAPIVersion)
+		if err != nil {
+			log.Info("couldn't convert perf CR", "apiVersion", req.DesiredAPIVersion, "error", err.Error())
+			resp.Result = metav1.Status{Status: metav1.StatusFailure, Message: err.Error()}
+			return resp
+		}
+		resp.ConvertedObjects = append(resp.ConvertedObjects, runtime.RawExtension{Raw: raw})
+	}
+	resp.Result = metav1.Status{Status: metav1.StatusSuccess}
+	return resp
+}
+
+func convertRaw(raw []byte, apiVersion string) ([]byte, error) {
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil, errors.Wrap(err, "couldn't decode object")
+	}
+	if err := convert(obj, apiVersion); err != nil {
+		return nil, err
+	}
+	return json.Marshal(obj)
+}
+
+// convert converts the perf CR to the apiVersion in place.
+func convert(obj map[string]interface{}, apiVersion string) error {
+	from, _ := obj["apiVersion"].(string)
+	if !isPerfVersion(from) {
+		return fmt.Errorf("%v isn't a perf API version", from)
+	}
+	if !isPerfVersion(apiVersion) {
+		return fmt.Errorf("%v isn't a perf API version", apiVersion)
+	}
+
+	obj["apiVersion"] = apiVersion
+	if from == apiVersion {
+		return nil
+	}
+	status, ok := obj["status"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	for alpha, ga := range statusKeys {
+		if apiVersion == v1.SchemeGroupVersion.String() {
+			renameKey(status, alpha, ga)
+		} else {
+			renameKey(status, ga, alpha)
+		}
+	}
+	return nil
+}
+
+func renameKey(m map[string]interface{}, from, to string) {
+	v, ok := m[from]
+	if !ok {
+		return
+	}
+	delete(m, from)
+	m[to] = v
+}
+
+func isPerfVersion(apiVersion string) bool {
+	return apiVersion == v1alpha1.SchemeGroupVersion.String() || apiVersion == v1.SchemeGroupVersion.String()
+}
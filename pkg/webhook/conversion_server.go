@@ -0,0 +1,288 @@
+package webhook
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1"
+	"github.com/pkg/errors"
+	coreV1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"math/big"
+	"net/http"
+	"os"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"strconv"
+	"time"
+)
+
+const (
+	conversionEnabledEnv = "PERF_CONVERSION_ENABLED"
+	conversionPortEnv    = "PERF_CONVERSION_PORT"
+
+	defaultConversionPort = 9444
+	conversionPath        = "/convert"
+	certValidity          = 10 * 365 * 24 * time.Hour
+	// certRenewBefore is the remaining validity the stored certificate is replaced with a new one at.
+	certRenewBefore = 30 * 24 * time.Hour
+)
+
+var crdResource = schema.GroupVersionResource{
+	Group:    "apiextensions.k8s.io",
+	Version:  "v1beta1",
+	Resource: "customresourcedefinitions",
+}
+
+// conversionServer serves the conversion webhook of the perf CRDs with a self-signed certificate
+// and injects the certificate as the CA bundle of the webhook configured in the CRDs by the chart.
+// The certificate is kept in a Secret, so all the operator pods serve the same one and the CA bundle
+// of the CRDs stays valid for each of them.
+type conversionServer struct {
+	client     dynamic.Interface
+	secrets    client.Client
+	port       int32
+	service    types.NamespacedName
+	certSecret types.NamespacedName
+	crds       []string
+}
+
+// AddConversion adds the conversion webhook server to the manager if it's enabled with PERF_CONVERSION_ENABLED.
+// The CRDs are cluster-wide, so the conversion has to be enabled in a single operator instance of the cluster.
+func AddConversion(mgr manager.Manager, namespace string) error {
+	enabled, _ := strconv.ParseBool(os.Getenv(conversionEnabledEnv))
+	if !enabled {
+		log.Info("PERF conversion webhook is disabled")
+		return nil
+	}
+
+	port := int32(defaultConversionPort)
+	if v := os.Getenv(conversionPortEnv); v != "" {
+		p, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return errors.Wrapf(err, "couldn't parse %v conversion port", v)
+		}
+		port = int32(p)
+	}
+
+	dc, err := dynamic.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return errors.Wrap(err, "couldn't create dynamic client for CRDs")
+	}
+
+	// the certificate Secret is read before the manager cache is started
+	c, err := client.New(mgr.GetConfig(), client.Options{Scheme: mgr.GetScheme()})
+	if err != nil {
+		return errors.Wrap(err, "couldn't create client for conversion webhook certificate")
+	}
+
+	operatorName := getEnv(operatorNameEnv, defaultOperatorName)
+	operatorNamespace := getEnv(podNamespaceEnv, namespace)
+	s := &conversionServer{
+		client:  dc,
+		secrets: c,
+		port:    port,
+		service: types.NamespacedName{
+			Namespace: operatorNamespace,
+			Name:      operatorName + "-conversion",
+		},
+		certSecret: types.NamespacedName{
+			Namespace: operatorNamespace,
+			Name:      operatorName + "-conversion-cert",
+		},
+	}
+	for _, r := range perfResources {
+		s.crds = append(s.crds, fmt.Sprintf("%v.%v", r, v1.SchemeGroupVersion.Group))
+	}
+	return mgr.Add(s)
+}
+
+// Start serves the conversion webhook until the stop channel is closed.
+func (s *conversionServer) Start(stop <-chan struct{}) error {
+	host := fmt.Sprintf("%v.%v.svc", s.service.Name, s.service.Namespace)
+	certPem, keyPem, err := s.getCert(host)
+	if err != nil {
+		return err
+	}
+	cert, err := tls.X509KeyPair(certPem, keyPem)
+	if err != nil {
+		return errors.Wrap(err, "couldn't load conversion webhook certificate")
+	}
+	caBundle := certPem
+
+	mux := http.NewServeMux()
+	mux.Handle(conversionPath, conversionHandler{})
+	srv := &http.Server{
+		Addr:      fmt.Sprintf(":%v", s.port),
+		Handler:   mux,
+		TLSConfig: &tls.Config{Certificates: []tls.Certificate{cert}},
+	}
+	errs := make(chan error, 1)
+	go func() {
+		errs <- srv.ListenAndServeTLS("", "")
+	}()
+
+	for _, crd := range s.crds {
+		if err := s.injectCABundle(crd, caBundle); err != nil {
+			_ = srv.Close()
+			return err
+		}
+	}
+	log.Info("PERF conversion webhook has been started", "port", s.port, "service", host)
+
+	select {
+	case <-stop:
+		log.Info("PERF conversion webhook has been stopped")
+		return srv.Shutdown(context.Background())
+	case err := <-errs:
+		return errors.Wrap(err, "PERF conversion webhook has failed")
+	}
+}
+
+// getCert returns the certificate and key stored in the Secret. A new certificate is stored if the Secret
+// is missing, or if its certificate doesn't suit the host or expires soon. If another pod has stored
+// the certificate meanwhile, that one is used.
+func (s *conversionServer) getCert(host string) ([]byte, []byte, error) {
+	secret := &coreV1.Secret{}
+	err := s.secrets.Get(context.TODO(), s.certSecret, secret)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, nil, errors.Wrapf(err, "couldn't get %v conversion webhook certificate", s.certSecret.Name)
+	}
+	found := err == nil
+	if found && isValidCert(secret.Data[coreV1.TLSCertKey], host) {
+		return secret.Data[coreV1.TLSCertKey], secret.Data[coreV1.TLSPrivateKeyKey], nil
+	}
+
+	certPem, keyPem, err := newSelfSignedCert(host)
+	if err != nil {
+		return nil, nil, err
+	}
+	secret.Name = s.certSecret.Name
+	secret.Namespace = s.certSecret.Namespace
+	secret.Type = coreV1.SecretTypeTLS
+	secret.Data = map[string][]byte{
+		coreV1.TLSCertKey:       certPem,
+		coreV1.TLSPrivateKeyKey: keyPem,
+	}
+
+	if found {
+		err = s.secrets.Update(context.TODO(), secret)
+	} else {
+		err = s.secrets.Create(context.TODO(), secret)
+	}
+	if k8serrors.IsAlreadyExists(err) || k8serrors.IsConflict(err) {
+		log.Info("conversion webhook certificate has been stored by another pod", "name", s.certSecret.Name)
+		return s.getStoredCert()
+	}
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "couldn't store %v conversion webhook certificate", s.certSecret.Name)
+	}
+	log.Info("conversion webhook certificate has been stored", "name", s.certSecret.Name)
+	return certPem, keyPem, nil
+}
+
+func (s *conversionServer) getStoredCert() ([]byte, []byte, error) {
+	secret := &coreV1.Secret{}
+	if err := s.secrets.Get(context.TODO(), s.certSecret, secret); err != nil {
+		return nil, nil, errors.Wrapf(err, "couldn't get %v conversion webhook certificate", s.certSecret.Name)
+	}
+	return secret.Data[coreV1.TLSCertKey], secret.Data[coreV1.TLSPrivateKeyKey], nil
+}
+
+// isValidCert reports if the PEM certificate is issued for the host and doesn't expire soon.
+func isValidCert(certPem []byte, host string) bool {
+	b, _ := pem.Decode(certPem)
+	if b == nil {
+		return false
+	}
+	cert, err := x509.ParseCertificate(b.Bytes)
+	if err != nil {
+		return false
+	}
+	return cert.VerifyHostname(host) == nil && time.Now().Add(certRenewBefore).Before(cert.NotAfter)
+}
+
+// injectCABundle sets the CA bundle of the conversion webhook the chart has configured in the CRD.
+func (s *conversionServer) injectCABundle(name string, caBundle []byte) error {
+	crd, err := s.client.Resource(crdResource).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "couldn't get %v CRD", name)
+	}
+
+	strategy, _, err := unstructured.NestedString(crd.Object, "spec", "conversion", "strategy")
+	if err != nil {
+		return errors.Wrapf(err, "couldn't get conversion strategy of %v CRD", name)
+	}
+	if strategy != "Webhook" {
+		return errors.Errorf("conversion webhook isn't configured in %v CRD", name)
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(caBundle)
+	current, _, err := unstructured.NestedString(crd.Object, "spec", "conversion", "webhookClientConfig", "caBundle")
+	if err != nil {
+		return errors.Wrapf(err, "couldn't get CA bundle of %v CRD", name)
+	}
+	if current == encoded {
+		log.Info("CRD already has conversion webhook CA bundle", "name", name)
+		return nil
+	}
+	if err := unstructured.SetNestedField(crd.Object, encoded, "spec", "conversion", "webhookClientConfig", "caBundle"); err != nil {
+		return errors.Wrapf(err, "couldn't set CA bundle of %v CRD", name)
+	}
+
+	if _, err := s.client.Resource(crdResource).Update(crd, metav1.UpdateOptions{}); err != nil {
+		return errors.Wrapf(err, "couldn't update %v CRD", name)
+	}
+	log.Info("conversion webhook CA bundle has been injected to CRD", "name", name)
+	return nil
+}
+
+// newSelfSignedCert returns the PEM of a serving certificate for the host, which is the CA bundle as well,
+// and of its key.
+func newSelfSignedCert(host string) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "couldn't generate conversion webhook key")
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "couldn't generate certificate serial number")
+	}
+
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: host},
+		DNSNames:              []string{host},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(certValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "couldn't create conversion webhook certificate")
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "couldn't encode conversion webhook key")
+	}
+
+	certPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPem := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+	return certPem, keyPem, nil
+}
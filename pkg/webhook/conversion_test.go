@@ -0,0 +1,291 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+const (
+	alphaVersion       = "v2.edp.epam.com/v1alpha1"
+	gaVersion          = "v2.edp.epam.com/v1"
+	fakeConversionHost = "perf-operator-conversion.ns.svc"
+)
+
+func createAlphaPerfServer() map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": alphaVersion,
+		"kind":       "PerfServer",
+		"metadata":   map[string]interface{}{"name": fakeName, "namespace": fakeNamespace},
+		"spec": map[string]interface{}{
+			"apiUrl":      "https://perf.example.com",
+			"projectName": "EPMD-EDP",
+			"unknown":     "kept",
+		},
+		"status": map[string]interface{}{
+			"available":         true,
+			"last_time_updated": "2020-03-04T10:20:30.123456789Z",
+			"detailed_message":  "connected",
+			"project_id":        float64(42),
+			"project_path":      "EPAM/EPMD-EDP",
+		},
+	}
+}
+
+func TestConvert_ShouldRenameStatusFieldsToV1(t *testing.T) {
+	obj := createAlphaPerfServer()
+
+	assert.NoError(t, convert(obj, gaVersion))
+	assert.Equal(t, gaVersion, obj["apiVersion"])
+	assert.Equal(t, map[string]interface{}{
+		"available":       true,
+		"lastTimeUpdated": "2020-03-04T10:20:30.123456789Z",
+		"detailedMessage": "connected",
+		"projectId":       float64(42),
+		"projectPath":     "EPAM/EPMD-EDP",
+	}, obj["status"])
+	assert.Equal(t, "kept", obj["spec"].(map[string]interface{})["unknown"])
+}
+
+func TestConvert_ShouldRoundTripLosslessly(t *testing.T) {
+	obj := createAlphaPerfServer()
+
+	assert.NoError(t, convert(obj, gaVersion))
+	assert.NoError(t, convert(obj, alphaVersion))
+	assert.Equal(t, createAlphaPerfServer(), obj)
+}
+
+func TestConvert_ShouldRejectOtherGroups(t *testing.T) {
+	obj := createAlphaPerfServer()
+	obj["apiVersion"] = "v1"
+
+	assert.Error(t, convert(obj, gaVersion))
+	assert.Error(t, convert(createAlphaPerfServer(), "v2.edp.epam.com/v2"))
+}
+
+func TestConversionHandler_ShouldConvertReviewObjects(t *testing.T) {
+	raw, err := json.Marshal(createAlphaPerfServer())
+	assert.NoError(t, err)
+	body, err := json.Marshal(conversionReview{
+		Request: &conversionRequest{
+			UID:               "uid",
+			DesiredAPIVersion: gaVersion,
+			Objects:           []runtime.RawExtension{{Raw: raw}},
+		},
+	})
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	conversionHandler{}.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, conversionPath, bytes.NewReader(body)))
+
+	review := conversionReview{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &review))
+	assert.Equal(t, "uid", string(review.Response.UID))
+	assert.Equal(t, "Success", review.Response.Result.Status)
+	assert.Len(t, review.Response.ConvertedObjects, 1)
+
+	converted := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(review.Response.ConvertedObjects[0].Raw, &converted))
+	assert.Equal(t, gaVersion, converted["apiVersion"])
+	assert.Equal(t, float64(42), converted["status"].(map[string]interface{})["projectId"])
+}
+
+func TestNewSelfSignedCert_ShouldIssueCertForHost(t *testing.T) {
+	certPem, keyPem, err := newSelfSignedCert(fakeConversionHost)
+	assert.NoError(t, err)
+	assert.True(t, isValidCert(certPem, fakeConversionHost))
+	assert.False(t, isValidCert(certPem, "other.ns.svc"))
+
+	cert, err := tls.X509KeyPair(certPem, keyPem)
+	assert.NoError(t, err)
+	assert.Len(t, cert.Certificate, 1)
+}
+
+func createConversionServer(objs ...runtime.Object) *conversionServer {
+	return &conversionServer{
+		secrets:    fake.NewFakeClient(objs...),
+		certSecret: types.NamespacedName{Namespace: fakeNamespace, Name: "perf-operator-conversion-cert"},
+	}
+}
+
+func TestConversionServer_GetCert_ShouldStoreAndReuseCert(t *testing.T) {
+	s := createConversionServer()
+
+	certPem, keyPem, err := s.getCert(fakeConversionHost)
+	assert.NoError(t, err)
+
+	secret := &coreV1.Secret{}
+	assert.NoError(t, s.secrets.Get(context.TODO(), s.certSecret, secret))
+	assert.Equal(t, certPem, secret.Data[coreV1.TLSCertKey])
+	assert.Equal(t, keyPem, secret.Data[coreV1.TLSPrivateKeyKey])
+
+	reused, _, err := s.getCert(fakeConversionHost)
+	assert.NoError(t, err)
+	assert.Equal(t, certPem, reused)
+}
+
+func TestConversionServer_GetCert_ShouldReplaceCertOfOtherHost(t *testing.T) {
+	otherPem, otherKey, err := newSelfSignedCert("other.ns.svc")
+	assert.NoError(t, err)
+	s := createConversionServer(&coreV1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: fakeNamespace, Name: "perf-operator-conversion-cert"},
+		Data:       map[string][]byte{coreV1.TLSCertKey: otherPem, coreV1.TLSPrivateKeyKey: otherKey},
+	})
+
+	certPem, _, err := s.getCert(fakeConversionHost)
+	assert.NoError(t, err)
+	assert.NotEqual(t, otherPem, certPem)
+	assert.True(t, isValidCert(certPem, fakeConversionHost))
+
+	secret := &coreV1.Secret{}
+	assert.NoError(t, s.secrets.Get(context.TODO(), s.certSecret, secret))
+	assert.Equal(t, certPem, secret.Data[coreV1.TLSCertKey])
+}
+
+func createPerfServerCrd(conversion map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apiextensions.k8s.io/v1beta1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]interface{}{"name": "perfservers.v2.edp.epam.com"},
+		"spec": map[string]interface{}{
+			"group":      "v2.edp.epam.com",
+			"conversion": conversion,
+		},
+	}}
+}
+
+func TestConversionServer_InjectCABundle_ShouldKeepWebhookOfChart(t *testing.T) {
+	s := &conversionServer{client: dynamicFake.NewSimpleDynamicClient(runtime.NewScheme(), createPerfServerCrd(
+		map[string]interface{}{
+			"strategy": "Webhook",
+			"webhookClientConfig": map[string]interface{}{
+				"service": map[string]interface{}{"namespace": "ns", "name": "perf-operator-conversion", "path": "/convert"},
+			},
+		}))}
+
+	assert.NoError(t, s.injectCABundle("perfservers.v2.edp.epam.com", []byte("ca")))
+
+	crd, err := s.client.Resource(crdResource).Get("perfservers.v2.edp.epam.com", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"strategy": "Webhook",
+		"webhookClientConfig": map[string]interface{}{
+			"service":  map[string]interface{}{"namespace": "ns", "name": "perf-operator-conversion", "path": "/convert"},
+			"caBundle": base64.StdEncoding.EncodeToString([]byte("ca")),
+		},
+	}, crd.Object["spec"].(map[string]interface{})["conversion"])
+}
+
+func TestConversionServer_InjectCABundle_ShouldFailWithoutWebhookStrategy(t *testing.T) {
+	s := &conversionServer{client: dynamicFake.NewSimpleDynamicClient(runtime.NewScheme(), createPerfServerCrd(
+		map[string]interface{}{"strategy": "None"}))}
+
+	err := s.injectCABundle("perfservers.v2.edp.epam.com", []byte("ca"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "conversion webhook isn't configured in perfservers.v2.edp.epam.com CRD")
+}
+
+// fillValue sets every exported field of the value, so a field missing in the other API version is lost in conversion.
+func fillValue(v reflect.Value, depth int) {
+	if depth > 5 {
+		return
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString("fake")
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(1)
+	case reflect.Ptr:
+		v.Set(reflect.New(v.Type().Elem()))
+		fillValue(v.Elem(), depth+1)
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+		fillValue(v.Index(0), depth+1)
+	case reflect.Map:
+		key := reflect.New(v.Type().Key()).Elem()
+		fillValue(key, depth+1)
+		val := reflect.New(v.Type().Elem()).Elem()
+		fillValue(val, depth+1)
+		v.Set(reflect.MakeMap(v.Type()))
+		v.SetMapIndex(key, val)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" {
+				fillValue(v.Field(i), depth+1)
+			}
+		}
+	}
+}
+
+// roundTrip converts the object with the filled spec to the other API version and back.
+func roundTrip(t *testing.T, obj, other runtime.Object, apiVersion, otherApiVersion string) runtime.Object {
+	fillValue(reflect.ValueOf(obj).Elem().FieldByName("Spec"), 0)
+	raw, err := json.Marshal(obj)
+	assert.NoError(t, err)
+	raw, err = convertRaw(raw, otherApiVersion)
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(raw, other))
+
+	raw, err = json.Marshal(other)
+	assert.NoError(t, err)
+	raw, err = convertRaw(raw, apiVersion)
+	assert.NoError(t, err)
+	res := reflect.New(reflect.TypeOf(obj).Elem()).Interface().(runtime.Object)
+	assert.NoError(t, json.Unmarshal(raw, res))
+	return res
+}
+
+// The webhooks decode v1 CRs as v1alpha1 and patch them, which is right only while both versions have the same spec.
+func TestConvert_ShouldKeepSpecOfAllKinds(t *testing.T) {
+	kinds := []struct {
+		alpha runtime.Object
+		ga    func() runtime.Object
+	}{
+		{&v1alpha1.PerfServer{}, func() runtime.Object { return &v1.PerfServer{} }},
+		{&v1alpha1.ClusterPerfServer{}, func() runtime.Object { return &v1.ClusterPerfServer{} }},
+		{&v1alpha1.PerfDataSourceJenkins{}, func() runtime.Object { return &v1.PerfDataSourceJenkins{} }},
+		{&v1alpha1.PerfDataSourceSonar{}, func() runtime.Object { return &v1.PerfDataSourceSonar{} }},
+		{&v1alpha1.PerfDataSourceGitLab{}, func() runtime.Object { return &v1.PerfDataSourceGitLab{} }},
+		{&v1alpha1.PerfDataSourceBitbucket{}, func() runtime.Object { return &v1.PerfDataSourceBitbucket{} }},
+		{&v1alpha1.PerfDataSourceAzureDevOps{}, func() runtime.Object { return &v1.PerfDataSourceAzureDevOps{} }},
+		{&v1alpha1.PerfDataSourceTekton{}, func() runtime.Object { return &v1.PerfDataSourceTekton{} }},
+		{&v1alpha1.PerfDoraMetrics{}, func() runtime.Object { return &v1.PerfDoraMetrics{} }},
+		{&v1alpha1.PerfReport{}, func() runtime.Object { return &v1.PerfReport{} }},
+	}
+	for _, k := range kinds {
+		kind := reflect.TypeOf(k.alpha).Elem().Name()
+		assert.NotNil(t, newObject(kind), kind)
+
+		alpha := k.alpha.DeepCopyObject()
+		reflect.ValueOf(alpha).Elem().FieldByName("TypeMeta").Set(reflect.ValueOf(metav1.TypeMeta{APIVersion: alphaVersion, Kind: kind}))
+		res := roundTrip(t, alpha, k.ga(), alphaVersion, gaVersion)
+		assert.Equal(t, reflect.ValueOf(alpha).Elem().FieldByName("Spec").Interface(),
+			reflect.ValueOf(res).Elem().FieldByName("Spec").Interface(), "v1alpha1 spec of %v", kind)
+
+		ga := k.ga()
+		reflect.ValueOf(ga).Elem().FieldByName("TypeMeta").Set(reflect.ValueOf(metav1.TypeMeta{APIVersion: gaVersion, Kind: kind}))
+		res = roundTrip(t, ga, k.alpha.DeepCopyObject(), gaVersion, alphaVersion)
+		assert.Equal(t, reflect.ValueOf(ga).Elem().FieldByName("Spec").Interface(),
+			reflect.ValueOf(res).Elem().FieldByName("Spec").Interface(), "v1 spec of %v", kind)
+	}
+}
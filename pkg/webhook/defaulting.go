@@ -0,0 +1,239 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/cluster"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/consts"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"strings"
+	"text/template"
+)
+
+const (
+	// defaultNameTemplate names a PERF data source after its CR.
+	defaultNameTemplate = "{{ .Name }}"
+	// codebaseLabel holds the name of the Codebase a CR belongs to if it isn't owned by the Codebase.
+	codebaseLabel = "app.edp.epam.com/codebase"
+	defaultScheme = "https://"
+)
+
+// defaultTypes are the data source types set for the kinds if spec.type is empty.
+var defaultTypes = map[string]string{
+	"PerfDataSourceJenkins":     "Jenkins",
+	"PerfDataSourceSonar":       "Sonar",
+	"PerfDataSourceGitLab":      "GitLab",
+	"PerfDataSourceBitbucket":   "Bitbucket",
+	"PerfDataSourceAzureDevOps": "Azure_DevOps",
+	"PerfDataSourceTekton":      "Custom",
+	"PerfDoraMetrics":           "Custom",
+}
+
+// nameTemplateData is passed to the template the empty spec.name of data sources is generated with.
+type nameTemplateData struct {
+	Name         string
+	Namespace    string
+	Kind         string
+	Type         string
+	CodebaseName string
+}
+
+// dataSourceFields points to the spec fields shared by the data source kinds, CodebaseName is nil if the kind has none.
+type dataSourceFields struct {
+	Name           *string
+	Type           *string
+	PerfServerName *string
+	CodebaseName   *string
+	PerfServerKind string
+}
+
+// defaulter fills in the omitted spec fields of perf CRs and normalizes their urls and entry lists.
+type defaulter struct {
+	client       client.Client
+	nameTemplate *template.Template
+}
+
+func newDefaulter(c client.Client, nameTemplate string) (defaulter, error) {
+	t, err := template.New("name").Funcs(template.FuncMap{
+		"lower": strings.ToLower,
+		"upper": strings.ToUpper,
+	}).Parse(nameTemplate)
+	if err != nil {
+		return defaulter{}, errors.Wrapf(err, "couldn't parse %v name template", nameTemplate)
+	}
+	return defaulter{client: c, nameTemplate: t}, nil
+}
+
+// setDefaults changes the object in place, objects of other kinds are left as is.
+func (d defaulter) setDefaults(kind string, obj runtime.Object) error {
+	switch o := obj.(type) {
+	case *v1alpha1.PerfServer:
+		defaultPerfServerSpec(&o.Spec)
+	case *v1alpha1.ClusterPerfServer:
+		defaultPerfServerSpec(&o.Spec.PerfServerSpec)
+	case *v1alpha1.PerfDataSourceJenkins:
+		o.Spec.Config.Url = normalizeUrl(o.Spec.Config.Url)
+		o.Spec.Config.JobNames = normalizeEntries(o.Spec.Config.JobNames)
+		if dd := o.Spec.Discovery; dd != nil {
+			dd.Folders = normalizeEntries(dd.Folders)
+			dd.Patterns = normalizeEntries(dd.Patterns)
+		}
+		return d.defaultDataSource(kind, o, dataSourceFields{&o.Spec.Name, &o.Spec.Type, &o.Spec.PerfServerName,
+			&o.Spec.CodebaseName, o.Spec.PerfServerKind})
+	case *v1alpha1.PerfDataSourceSonar:
+		o.Spec.Config.Url = normalizeUrl(o.Spec.Config.Url)
+		o.Spec.Config.ProjectKeys = normalizeEntries(o.Spec.Config.ProjectKeys)
+		if dd := o.Spec.Discovery; dd != nil {
+			dd.KeyPrefixes = normalizeEntries(dd.KeyPrefixes)
+			dd.Tags = normalizeEntries(dd.Tags)
+		}
+		return d.defaultDataSource(kind, o, dataSourceFields{&o.Spec.Name, &o.Spec.Type, &o.Spec.PerfServerName,
+			&o.Spec.CodebaseName, o.Spec.PerfServerKind})
+	case *v1alpha1.PerfDataSourceGitLab:
+		o.Spec.Config.Url = normalizeUrl(o.Spec.Config.Url)
+		o.Spec.Config.Repositories = normalizeEntries(o.Spec.Config.Repositories)
+		o.Spec.Config.Branches = normalizeEntries(o.Spec.Config.Branches)
+		if dd := o.Spec.Discovery; dd != nil {
+			dd.Groups = normalizeEntries(dd.Groups)
+			dd.Include = normalizeEntries(dd.Include)
+			dd.Exclude = normalizeEntries(dd.Exclude)
+		}
+		return d.defaultDataSource(kind, o, dataSourceFields{&o.Spec.Name, &o.Spec.Type, &o.Spec.PerfServerName,
+			&o.Spec.CodebaseName, o.Spec.PerfServerKind})
+	case *v1alpha1.PerfDataSourceBitbucket:
+		o.Spec.Config.Url = normalizeUrl(o.Spec.Config.Url)
+		o.Spec.Config.Repositories = normalizeEntries(o.Spec.Config.Repositories)
+		o.Spec.Config.Branches = normalizeEntries(o.Spec.Config.Branches)
+		return d.defaultDataSource(kind, o, dataSourceFields{&o.Spec.Name, &o.Spec.Type, &o.Spec.PerfServerName,
+			&o.Spec.CodebaseName, o.Spec.PerfServerKind})
+	case *v1alpha1.PerfDataSourceAzureDevOps:
+		o.Spec.Config.Url = normalizeUrl(o.Spec.Config.Url)
+		o.Spec.Config.Repositories = normalizeEntries(o.Spec.Config.Repositories)
+		o.Spec.Config.Branches = normalizeEntries(o.Spec.Config.Branches)
+		return d.defaultDataSource(kind, o, dataSourceFields{&o.Spec.Name, &o.Spec.Type, &o.Spec.PerfServerName,
+			&o.Spec.CodebaseName, o.Spec.PerfServerKind})
+	case *v1alpha1.PerfDataSourceTekton:
+		return d.defaultDataSource(kind, o, dataSourceFields{&o.Spec.Name, &o.Spec.Type, &o.Spec.PerfServerName, nil,
+			o.Spec.PerfServerKind})
+	case *v1alpha1.PerfDoraMetrics:
+		return d.defaultDataSource(kind, o, dataSourceFields{&o.Spec.Name, &o.Spec.Type, &o.Spec.PerfServerName, nil,
+			o.Spec.PerfServerKind})
+	case *v1alpha1.PerfReport:
+		o.Spec.Metrics = normalizeEntries(o.Spec.Metrics)
+		if o.Spec.PerfServerName == "" && o.Spec.PerfServerKind != consts.ClusterPerfServerKind {
+			name, err := d.getSinglePerfServer(o.Namespace)
+			if err != nil {
+				return err
+			}
+			o.Spec.PerfServerName = name
+		}
+	}
+	return nil
+}
+
+func defaultPerfServerSpec(spec *v1alpha1.PerfServerSpec) {
+	spec.ApiUrl = normalizeUrl(spec.ApiUrl)
+	spec.RootUrl = normalizeUrl(spec.RootUrl)
+	if spec.RootUrl == "" {
+		spec.RootUrl = spec.ApiUrl
+	}
+	spec.ExporterNodes = normalizeEntries(spec.ExporterNodes)
+	if a := spec.Auth; a != nil {
+		a.TokenUrl = normalizeUrl(a.TokenUrl)
+		a.Scopes = normalizeEntries(a.Scopes)
+	}
+	if l := spec.Luminate; l != nil {
+		l.ApiUrl = normalizeUrl(l.ApiUrl)
+	}
+}
+
+func (d defaulter) defaultDataSource(kind string, meta metav1.Object, f dataSourceFields) error {
+	*f.Name = strings.TrimSpace(*f.Name)
+	if *f.Type == "" {
+		*f.Type = defaultTypes[kind]
+	}
+	if f.CodebaseName != nil && *f.CodebaseName == "" {
+		*f.CodebaseName = getOwningCodebase(meta)
+	}
+	if *f.PerfServerName == "" && f.PerfServerKind != consts.ClusterPerfServerKind {
+		name, err := d.getSinglePerfServer(meta.GetNamespace())
+		if err != nil {
+			return err
+		}
+		*f.PerfServerName = name
+	}
+	if *f.Name != "" {
+		return nil
+	}
+
+	data := nameTemplateData{
+		Name:      meta.GetName(),
+		Namespace: meta.GetNamespace(),
+		Kind:      kind,
+		Type:      *f.Type,
+	}
+	if f.CodebaseName != nil {
+		data.CodebaseName = *f.CodebaseName
+	}
+	var name bytes.Buffer
+	if err := d.nameTemplate.Execute(&name, data); err != nil {
+		return errors.Wrap(err, "couldn't generate data source name")
+	}
+	*f.Name = strings.TrimSpace(name.String())
+	return nil
+}
+
+// getSinglePerfServer returns the name of the only PerfServer in the namespace, an empty string if there are several.
+// ClusterPerfServers are never chosen, they have to be referred to explicitly.
+func (d defaulter) getSinglePerfServer(namespace string) (string, error) {
+	list := &v1alpha1.PerfServerList{}
+	if err := d.client.List(context.TODO(), &client.ListOptions{Namespace: namespace}, list); err != nil {
+		return "", errors.Wrapf(err, "couldn't list PerfServers in %v namespace", namespace)
+	}
+	if len(list.Items) != 1 {
+		return "", nil
+	}
+	return list.Items[0].Name, nil
+}
+
+// getOwningCodebase returns the name of the Codebase owning the CR or the one in its codebase label.
+func getOwningCodebase(meta metav1.Object) string {
+	if or := cluster.GetOwnerReference(consts.CodebaseKind, meta.GetOwnerReferences()); or != nil {
+		return or.Name
+	}
+	return meta.GetLabels()[codebaseLabel]
+}
+
+// normalizeUrl trims the url, adds the https scheme if it's missing and removes the trailing slashes.
+func normalizeUrl(u string) string {
+	u = strings.TrimSpace(u)
+	if u == "" {
+		return ""
+	}
+	if !strings.Contains(u, "://") {
+		u = defaultScheme + u
+	}
+	return strings.TrimRight(u, "/")
+}
+
+// normalizeEntries trims the entries and drops the empty and duplicate ones keeping their order.
+func normalizeEntries(entries []string) []string {
+	if len(entries) == 0 {
+		return entries
+	}
+	res := make([]string, 0, len(entries))
+	seen := make(map[string]bool)
+	for _, e := range entries {
+		e = strings.TrimSpace(e)
+		if e == "" || seen[e] {
+			continue
+		}
+		seen[e] = true
+		res = append(res, e)
+	}
+	return res
+}
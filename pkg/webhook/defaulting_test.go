@@ -0,0 +1,130 @@
+package webhook
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/consts"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func createDefaulter(t *testing.T, nameTemplate string, perfServers ...string) defaulter {
+	var objs []runtime.Object
+	for _, n := range perfServers {
+		objs = append(objs, &v1alpha1.PerfServer{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      n,
+				Namespace: fakeNamespace,
+			},
+		})
+	}
+	scheme.Scheme.AddKnownTypes(v1.SchemeGroupVersion, &v1alpha1.PerfServer{}, &v1alpha1.PerfServerList{})
+
+	d, err := newDefaulter(fake.NewFakeClient(objs...), nameTemplate)
+	assert.NoError(t, err)
+	return d
+}
+
+func TestSetDefaults_ShouldDefaultDataSourceFields(t *testing.T) {
+	ds := &v1alpha1.PerfDataSourceJenkins{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "app-jenkins",
+			Namespace: fakeNamespace,
+			OwnerReferences: []v1.OwnerReference{
+				{Kind: consts.CodebaseKind, Name: "app"},
+			},
+		},
+		Spec: v1alpha1.PerfDataSourceJenkinsSpec{
+			Config: v1alpha1.DataSourceJenkinsConfig{
+				JobNames: []string{" /app/MASTER-Build-app", "", "/app/MASTER-Build-app "},
+				Url:      " jenkins.example.com/ ",
+			},
+		},
+	}
+
+	assert.NoError(t, createDefaulter(t, "{{ .CodebaseName }}-{{ lower .Type }}", fakeName).
+		setDefaults("PerfDataSourceJenkins", ds))
+	assert.Equal(t, "Jenkins", ds.Spec.Type)
+	assert.Equal(t, "app", ds.Spec.CodebaseName)
+	assert.Equal(t, fakeName, ds.Spec.PerfServerName)
+	assert.Equal(t, "app-jenkins", ds.Spec.Name)
+	assert.Equal(t, "https://jenkins.example.com", ds.Spec.Config.Url)
+	assert.Equal(t, []string{"/app/MASTER-Build-app"}, ds.Spec.Config.JobNames)
+}
+
+func TestSetDefaults_ShouldKeepSetFields(t *testing.T) {
+	ds := &v1alpha1.PerfDataSourceGitLab{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+			Labels:    map[string]string{codebaseLabel: "app"},
+		},
+		Spec: v1alpha1.PerfDataSourceGitLabSpec{
+			Name:           "gitlab",
+			Type:           "GITLAB",
+			PerfServerName: "other",
+			CodebaseName:   "backend",
+			Config: v1alpha1.DataSourceGitLabConfig{
+				Url: "http://gitlab.example.com",
+			},
+		},
+	}
+
+	assert.NoError(t, createDefaulter(t, defaultNameTemplate, fakeName).setDefaults("PerfDataSourceGitLab", ds))
+	assert.Equal(t, "gitlab", ds.Spec.Name)
+	assert.Equal(t, "GITLAB", ds.Spec.Type)
+	assert.Equal(t, "other", ds.Spec.PerfServerName)
+	assert.Equal(t, "backend", ds.Spec.CodebaseName)
+	assert.Equal(t, "http://gitlab.example.com", ds.Spec.Config.Url)
+}
+
+func TestSetDefaults_ShouldNotChoosePerfServerFromSeveral(t *testing.T) {
+	dm := &v1alpha1.PerfDoraMetrics{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+	}
+
+	assert.NoError(t, createDefaulter(t, defaultNameTemplate, "first", "second").setDefaults("PerfDoraMetrics", dm))
+	assert.Empty(t, dm.Spec.PerfServerName)
+	assert.Equal(t, "Custom", dm.Spec.Type)
+	assert.Equal(t, fakeName, dm.Spec.Name)
+}
+
+func TestSetDefaults_ShouldNotChoosePerfServerForClusterKind(t *testing.T) {
+	r := &v1alpha1.PerfReport{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Spec: v1alpha1.PerfReportSpec{
+			PerfServerKind: consts.ClusterPerfServerKind,
+		},
+	}
+
+	assert.NoError(t, createDefaulter(t, defaultNameTemplate, fakeName).setDefaults("PerfReport", r))
+	assert.Empty(t, r.Spec.PerfServerName)
+}
+
+func TestSetDefaults_ShouldNormalizePerfServerUrls(t *testing.T) {
+	ps := &v1alpha1.PerfServer{
+		Spec: v1alpha1.PerfServerSpec{
+			ApiUrl:        "perf.example.com/",
+			ExporterNodes: []string{"Backend", " Backend"},
+		},
+	}
+
+	assert.NoError(t, createDefaulter(t, defaultNameTemplate).setDefaults("PerfServer", ps))
+	assert.Equal(t, "https://perf.example.com", ps.Spec.ApiUrl)
+	assert.Equal(t, "https://perf.example.com", ps.Spec.RootUrl)
+	assert.Equal(t, []string{"Backend"}, ps.Spec.ExporterNodes)
+}
+
+func TestNewDefaulter_ShouldFailOnInvalidTemplate(t *testing.T) {
+	_, err := newDefaulter(nil, "{{ .Name ")
+	assert.Error(t, err)
+}
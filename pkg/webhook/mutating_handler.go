@@ -0,0 +1,40 @@
+package webhook
+
+import (
+	"context"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/watch"
+	"net/http"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	atypes "sigs.k8s.io/controller-runtime/pkg/webhook/admission/types"
+)
+
+// mutatingHandler defaults the omitted spec fields of perf CRs and normalizes their urls and entry lists.
+type mutatingHandler struct {
+	defaulter  defaulter
+	namespaces *watch.Filter
+}
+
+var _ admission.Handler = &mutatingHandler{}
+
+func (h *mutatingHandler) Handle(_ context.Context, req atypes.Request) atypes.Response {
+	ar := req.AdmissionRequest
+	if served, err := h.namespaces.Serves(ar.Namespace); err != nil {
+		return admission.ErrorResponse(http.StatusInternalServerError, err)
+	} else if !served {
+		return admission.ValidationResponse(true, "")
+	}
+
+	original, err := decodeObject(ar)
+	if err != nil {
+		return admission.ErrorResponse(http.StatusBadRequest, err)
+	}
+	if original == nil {
+		return admission.ValidationResponse(true, "")
+	}
+
+	current := original.DeepCopyObject()
+	if err := h.defaulter.setDefaults(ar.Kind.Kind, current); err != nil {
+		return admission.ErrorResponse(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponse(original, current)
+}
@@ -0,0 +1,46 @@
+package webhook
+
+import (
+	"context"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/watch"
+	"github.com/stretchr/testify/assert"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"testing"
+)
+
+func TestMutatingHandler_ShouldPatchDefaults(t *testing.T) {
+	ds := createJenkinsDataSource()
+	ds.Spec.Type = ""
+	ds.Spec.Config.Url = "jenkins.example.com/"
+
+	h := &mutatingHandler{
+		defaulter:  createDefaulter(t, defaultNameTemplate, fakeName),
+		namespaces: watch.NewFilter(nil, []string{fakeNamespace}, nil),
+	}
+	resp := h.Handle(context.TODO(), createRequest(t, admissionv1beta1.Create, fakeNamespace, ds, nil))
+
+	assert.True(t, resp.Response.Allowed)
+	var paths []string
+	for _, p := range resp.Patches {
+		paths = append(paths, p.Path)
+	}
+	assert.ElementsMatch(t, []string{"/spec/type", "/spec/config/url"}, paths)
+}
+
+func TestMutatingHandler_ShouldPatchDefaultsOfGaVersion(t *testing.T) {
+	ds := createJenkinsDataSource()
+	ds.Spec.Type = ""
+
+	h := &mutatingHandler{
+		defaulter:  createDefaulter(t, defaultNameTemplate, fakeName),
+		namespaces: watch.NewFilter(nil, []string{fakeNamespace}, nil),
+	}
+	resp := h.Handle(context.TODO(), createGaRequest(t, admissionv1beta1.Create, fakeNamespace, ds))
+
+	assert.True(t, resp.Response.Allowed)
+	var paths []string
+	for _, p := range resp.Patches {
+		paths = append(paths, p.Path)
+	}
+	assert.Equal(t, []string{"/spec/type"}, paths)
+}
@@ -0,0 +1,138 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/watch"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"net/http"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	atypes "sigs.k8s.io/controller-runtime/pkg/webhook/admission/types"
+)
+
+// validatingHandler rejects perf CRs with invalid specs, reporting every invalid field by its path.
+type validatingHandler struct {
+	validator  validator
+	namespaces *watch.Filter
+}
+
+var _ admission.Handler = &validatingHandler{}
+
+func newValidatingHandler(c client.Client, namespaces *watch.Filter) *validatingHandler {
+	return &validatingHandler{
+		validator:  validator{client: c},
+		namespaces: namespaces,
+	}
+}
+
+func (h *validatingHandler) Handle(_ context.Context, req atypes.Request) atypes.Response {
+	ar := req.AdmissionRequest
+	if served, err := h.namespaces.Serves(ar.Namespace); err != nil {
+		return admission.ErrorResponse(http.StatusInternalServerError, err)
+	} else if !served {
+		return admission.ValidationResponse(true, "")
+	}
+
+	obj, err := decodeObject(ar)
+	if err != nil {
+		return admission.ErrorResponse(http.StatusBadRequest, err)
+	}
+	if obj == nil {
+		return admission.ValidationResponse(true, "")
+	}
+
+	if ar.Operation == admissionv1beta1.Update && !specChanged(ar) {
+		return admission.ValidationResponse(true, "")
+	}
+
+	errs := h.validator.validate(obj)
+	if len(errs) == 0 {
+		return admission.ValidationResponse(true, "")
+	}
+	log.Info("perf CR has been rejected", "kind", ar.Kind.Kind, "namespace", ar.Namespace, "name", ar.Name,
+		"errors", errs.ToAggregate().Error())
+	status := k8serrors.NewInvalid(schema.GroupKind{Group: ar.Kind.Group, Kind: ar.Kind.Kind}, ar.Name, errs).ErrStatus
+	return atypes.Response{
+		Response: &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result:  &status,
+		},
+	}
+}
+
+// decodeObject returns the perf CR of the request with the namespace of the request, nil for other kinds.
+// v1 CRs are converted to v1alpha1 first, both versions have the same spec.
+func decodeObject(ar *admissionv1beta1.AdmissionRequest) (runtime.Object, error) {
+	obj := newObject(ar.Kind.Kind)
+	if obj == nil {
+		return nil, nil
+	}
+	raw := ar.Object.Raw
+	switch ar.Kind.Version {
+	case v1alpha1.SchemeGroupVersion.Version:
+	case v1.SchemeGroupVersion.Version:
+		var err error
+		if raw, err = convertRaw(raw, v1alpha1.SchemeGroupVersion.String()); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, nil
+	}
+	if err := json.Unmarshal(raw, obj); err != nil {
+		return nil, err
+	}
+	if m, ok := obj.(metav1.Object); ok && m.GetNamespace() == "" {
+		m.SetNamespace(ar.Namespace)
+	}
+	return obj, nil
+}
+
+// newObject returns an empty object of the perf kind, nil for other kinds.
+func newObject(kind string) runtime.Object {
+	switch kind {
+	case "PerfServer":
+		return &v1alpha1.PerfServer{}
+	case "ClusterPerfServer":
+		return &v1alpha1.ClusterPerfServer{}
+	case "PerfDataSourceJenkins":
+		return &v1alpha1.PerfDataSourceJenkins{}
+	case "PerfDataSourceSonar":
+		return &v1alpha1.PerfDataSourceSonar{}
+	case "PerfDataSourceGitLab":
+		return &v1alpha1.PerfDataSourceGitLab{}
+	case "PerfDataSourceBitbucket":
+		return &v1alpha1.PerfDataSourceBitbucket{}
+	case "PerfDataSourceAzureDevOps":
+		return &v1alpha1.PerfDataSourceAzureDevOps{}
+	case "PerfDataSourceTekton":
+		return &v1alpha1.PerfDataSourceTekton{}
+	case "PerfDoraMetrics":
+		return &v1alpha1.PerfDoraMetrics{}
+	case "PerfReport":
+		return &v1alpha1.PerfReport{}
+	}
+	return nil
+}
+
+// specChanged reports if the update changes the spec. Updates of status and metadata aren't validated,
+// so the operator can still update CRs whose references have gone.
+func specChanged(ar *admissionv1beta1.AdmissionRequest) bool {
+	var o, n struct {
+		Spec interface{} `json:"spec"`
+	}
+	if err := json.Unmarshal(ar.OldObject.Raw, &o); err != nil {
+		return true
+	}
+	if err := json.Unmarshal(ar.Object.Raw, &n); err != nil {
+		return true
+	}
+	return !reflect.DeepEqual(o.Spec, n.Spec)
+}
@@ -0,0 +1,134 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/watch"
+	"github.com/stretchr/testify/assert"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	atypes "sigs.k8s.io/controller-runtime/pkg/webhook/admission/types"
+	"testing"
+)
+
+func createRequest(t *testing.T, op admissionv1beta1.Operation, namespace string, obj, old interface{}) atypes.Request {
+	raw, err := json.Marshal(obj)
+	assert.NoError(t, err)
+	ar := &admissionv1beta1.AdmissionRequest{
+		Kind:      v1.GroupVersionKind{Group: "v2.edp.epam.com", Version: "v1alpha1", Kind: "PerfDataSourceJenkins"},
+		Namespace: namespace,
+		Name:      fakeName,
+		Operation: op,
+		Object:    runtime.RawExtension{Raw: raw},
+	}
+	if old != nil {
+		oldRaw, err := json.Marshal(old)
+		assert.NoError(t, err)
+		ar.OldObject = runtime.RawExtension{Raw: oldRaw}
+	}
+	return atypes.Request{AdmissionRequest: ar}
+}
+
+// createGaRequest returns the request of the object sent through the v1 API.
+func createGaRequest(t *testing.T, op admissionv1beta1.Operation, namespace string, obj interface{}) atypes.Request {
+	req := createRequest(t, op, namespace, obj, nil)
+	o := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(req.AdmissionRequest.Object.Raw, &o))
+	o["apiVersion"] = gaVersion
+	raw, err := json.Marshal(o)
+	assert.NoError(t, err)
+	req.AdmissionRequest.Object.Raw = raw
+	req.AdmissionRequest.Kind.Version = "v1"
+	return req
+}
+
+func createValidatingHandler() *validatingHandler {
+	return &validatingHandler{
+		validator:  createValidator(),
+		namespaces: watch.NewFilter(nil, []string{fakeNamespace}, nil),
+	}
+}
+
+func TestValidatingHandler_ShouldRejectWithFieldCauses(t *testing.T) {
+	ds := createJenkinsDataSource()
+	ds.Spec.PerfServerName = "missing"
+
+	resp := createValidatingHandler().Handle(context.TODO(),
+		createRequest(t, admissionv1beta1.Create, fakeNamespace, ds, nil))
+
+	assert.False(t, resp.Response.Allowed)
+	assert.Equal(t, v1.StatusReasonInvalid, resp.Response.Result.Reason)
+	assert.Len(t, resp.Response.Result.Details.Causes, 1)
+	assert.Equal(t, "spec.perfServerName", resp.Response.Result.Details.Causes[0].Field)
+}
+
+func TestValidatingHandler_ShouldAllowValidDataSource(t *testing.T) {
+	resp := createValidatingHandler().Handle(context.TODO(),
+		createRequest(t, admissionv1beta1.Create, fakeNamespace, createJenkinsDataSource(), nil))
+
+	assert.True(t, resp.Response.Allowed)
+}
+
+func TestValidatingHandler_ShouldValidateGaVersion(t *testing.T) {
+	ds := createJenkinsDataSource()
+	ds.Spec.PerfServerName = "missing"
+
+	resp := createValidatingHandler().Handle(context.TODO(),
+		createGaRequest(t, admissionv1beta1.Create, fakeNamespace, ds))
+
+	assert.False(t, resp.Response.Allowed)
+	assert.Equal(t, "spec.perfServerName", resp.Response.Result.Details.Causes[0].Field)
+
+	resp = createValidatingHandler().Handle(context.TODO(),
+		createGaRequest(t, admissionv1beta1.Create, fakeNamespace, createJenkinsDataSource()))
+	assert.True(t, resp.Response.Allowed)
+}
+
+func TestValidatingHandler_ShouldSkipUpdatesWithoutSpecChange(t *testing.T) {
+	ds := createJenkinsDataSource()
+	ds.Spec.PerfServerName = "deleted"
+	updated := ds.DeepCopy()
+	updated.Status.Status = "error"
+
+	resp := createValidatingHandler().Handle(context.TODO(),
+		createRequest(t, admissionv1beta1.Update, fakeNamespace, updated, ds))
+
+	assert.True(t, resp.Response.Allowed)
+}
+
+func TestValidatingHandler_ShouldSkipOtherNamespaces(t *testing.T) {
+	ds := createJenkinsDataSource()
+	ds.Namespace = "other-namespace"
+	ds.Spec.PerfServerName = "missing"
+
+	resp := createValidatingHandler().Handle(context.TODO(),
+		createRequest(t, admissionv1beta1.Create, "other-namespace", ds, nil))
+
+	assert.True(t, resp.Response.Allowed)
+}
+
+func TestValidatingHandler_ShouldSkipNamespacesNotMatchingSelector(t *testing.T) {
+	ns := &coreV1.Namespace{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   fakeNamespace,
+			Labels: map[string]string{"app.edp.epam.com/perf": "false"},
+		},
+	}
+	ds := createJenkinsDataSource()
+	ds.Spec.PerfServerName = "missing"
+	h := createValidatingHandler()
+
+	h.namespaces = watch.NewFilter(fake.NewFakeClient(ns), nil,
+		labels.SelectorFromSet(labels.Set{"app.edp.epam.com/perf": "true"}))
+	resp := h.Handle(context.TODO(), createRequest(t, admissionv1beta1.Create, fakeNamespace, ds, nil))
+	assert.True(t, resp.Response.Allowed)
+
+	h.namespaces = watch.NewFilter(fake.NewFakeClient(ns), nil,
+		labels.SelectorFromSet(labels.Set{"app.edp.epam.com/perf": "false"}))
+	resp = h.Handle(context.TODO(), createRequest(t, admissionv1beta1.Create, fakeNamespace, ds, nil))
+	assert.False(t, resp.Response.Allowed)
+}
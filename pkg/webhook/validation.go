@@ -0,0 +1,389 @@
+package webhook
+
+import (
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/client/perf"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/cluster"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/consts"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/datasource"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"net/url"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"strings"
+	"time"
+)
+
+// Data source types expected for the kinds, they're compared case-insensitively.
+const (
+	jenkinsType     = "jenkins"
+	sonarType       = "sonar"
+	gitLabType      = "gitlab"
+	bitbucketType   = "bitbucket"
+	azureDevOpsType = "azure_devops"
+	customType      = "custom"
+)
+
+var (
+	specPath   = field.NewPath("spec")
+	configPath = specPath.Child("config")
+
+	authTypes              = []string{perf.SsoAuthType, perf.LuminateAuthType, perf.BearerAuthType, perf.OAuth2AuthType}
+	validationPolicies     = []string{v1alpha1.ValidationSkip, v1alpha1.ValidationBlock, v1alpha1.ValidationWarn}
+	edpComponentVisibility = []string{v1alpha1.EdpComponentVisible, v1alpha1.EdpComponentHidden,
+		v1alpha1.EdpComponentAvailable}
+	perfServerKinds = []string{consts.PerfServerKind, consts.ClusterPerfServerKind}
+)
+
+// validator checks the spec of perf CRs and the objects they refer to in the namespace of the CR.
+type validator struct {
+	client client.Client
+}
+
+// validate returns the field errors of the object, nil if the object isn't a perf CR.
+func (v validator) validate(obj runtime.Object) field.ErrorList {
+	switch o := obj.(type) {
+	case *v1alpha1.PerfServer:
+		return validatePerfServerSpec(o.Spec)
+	case *v1alpha1.ClusterPerfServer:
+		return validateClusterPerfServer(o)
+	case *v1alpha1.PerfDataSourceJenkins:
+		return v.validateJenkins(o)
+	case *v1alpha1.PerfDataSourceSonar:
+		return v.validateSonar(o)
+	case *v1alpha1.PerfDataSourceGitLab:
+		return v.validateGitLab(o)
+	case *v1alpha1.PerfDataSourceBitbucket:
+		return v.validateBitbucket(o)
+	case *v1alpha1.PerfDataSourceAzureDevOps:
+		return v.validateAzureDevOps(o)
+	case *v1alpha1.PerfDataSourceTekton:
+		return v.validateTekton(o)
+	case *v1alpha1.PerfDoraMetrics:
+		return v.validateDoraMetrics(o)
+	case *v1alpha1.PerfReport:
+		return v.validateReport(o)
+	}
+	return nil
+}
+
+func validatePerfServerSpec(spec v1alpha1.PerfServerSpec) field.ErrorList {
+	errs := validateUrl(specPath.Child("apiUrl"), spec.ApiUrl, true)
+	errs = append(errs, validateUrl(specPath.Child("rootUrl"), spec.RootUrl, true)...)
+	errs = append(errs, validateUnique(specPath.Child("exporterNodes"), spec.ExporterNodes)...)
+	if spec.ProjectId < 0 {
+		errs = append(errs, field.Invalid(specPath.Child("projectId"), spec.ProjectId, "must be positive"))
+	}
+
+	authType := perf.LuminateAuthType
+	if a := spec.Auth; a != nil {
+		p := specPath.Child("auth")
+		errs = append(errs, validateEnum(p.Child("type"), a.Type, authTypes)...)
+		authType = a.Type
+		if (a.Type == perf.BearerAuthType || a.Type == perf.OAuth2AuthType) && a.SecretName == "" {
+			errs = append(errs, field.Required(p.Child("secretName"), "is required for "+a.Type+" auth"))
+		}
+		errs = append(errs, validateUrl(p.Child("tokenUrl"), a.TokenUrl, a.Type == perf.OAuth2AuthType)...)
+	}
+	if (authType == perf.SsoAuthType || authType == perf.LuminateAuthType) && spec.CredentialName == "" {
+		errs = append(errs, field.Required(specPath.Child("credentialName"), "is required for "+authType+" auth"))
+	}
+
+	if l := spec.Luminate; l != nil {
+		p := specPath.Child("luminate")
+		errs = append(errs, validateUrl(p.Child("apiUrl"), l.ApiUrl, true)...)
+		errs = append(errs, validateRequired(p.Child("credentialName"), l.CredentialName)...)
+	}
+	if t := spec.Transport; t != nil {
+		errs = append(errs, validateUrl(specPath.Child("transport", "proxyUrl"), t.ProxyUrl, false)...)
+	}
+	if ec := spec.EdpComponent; ec != nil {
+		errs = append(errs, validateEnum(specPath.Child("edpComponent", "visibility"), ec.Visibility,
+			edpComponentVisibility)...)
+	}
+	return errs
+}
+
+func validateClusterPerfServer(cps *v1alpha1.ClusterPerfServer) field.ErrorList {
+	errs := validatePerfServerSpec(cps.Spec.PerfServerSpec)
+	p := specPath.Child("allowedNamespaces")
+	if cps.Spec.AllowedNamespaces == nil {
+		return append(errs, field.Required(p, "use {} to allow all namespaces"))
+	}
+	return append(errs, validateSelector(p, cps.Spec.AllowedNamespaces)...)
+}
+
+func (v validator) validateJenkins(ds *v1alpha1.PerfDataSourceJenkins) field.ErrorList {
+	errs := v.validateDataSource(ds.Namespace, ds.Spec.Name, ds.Spec.Type, jenkinsType, ds.Spec.PerfServerKind,
+		ds.Spec.PerfServerName)
+	errs = append(errs, v.validateCodebase(ds.Namespace, ds.Spec.CodebaseName)...)
+	errs = append(errs, validateToolUrl(ds.Spec.Config.Url, ds.Spec.EdpComponent, specPath.Child("jenkinsName"), ds.Spec.JenkinsName)...)
+	errs = append(errs, validateUnique(configPath.Child("jobNames"), ds.Spec.Config.JobNames)...)
+	errs = append(errs, validateEnum(specPath.Child("validation"), ds.Spec.Validation, validationPolicies)...)
+	if d := ds.Spec.Discovery; d != nil {
+		p := specPath.Child("discovery")
+		errs = append(errs, validateUnique(p.Child("folders"), d.Folders)...)
+		errs = append(errs, validatePatterns(p.Child("patterns"), d.Patterns)...)
+		errs = append(errs, validateDuration(p.Child("interval"), d.Interval)...)
+	}
+	return errs
+}
+
+func (v validator) validateSonar(ds *v1alpha1.PerfDataSourceSonar) field.ErrorList {
+	errs := v.validateDataSource(ds.Namespace, ds.Spec.Name, ds.Spec.Type, sonarType, ds.Spec.PerfServerKind,
+		ds.Spec.PerfServerName)
+	errs = append(errs, v.validateCodebase(ds.Namespace, ds.Spec.CodebaseName)...)
+	errs = append(errs, validateToolUrl(ds.Spec.Config.Url, ds.Spec.EdpComponent, specPath.Child("sonarName"), ds.Spec.SonarName)...)
+	errs = append(errs, validateUnique(configPath.Child("projectKeys"), ds.Spec.Config.ProjectKeys)...)
+	errs = append(errs, validateEnum(specPath.Child("validation"), ds.Spec.Validation, validationPolicies)...)
+	if d := ds.Spec.Discovery; d != nil {
+		p := specPath.Child("discovery")
+		errs = append(errs, validateUnique(p.Child("keyPrefixes"), d.KeyPrefixes)...)
+		errs = append(errs, validateUnique(p.Child("tags"), d.Tags)...)
+		errs = append(errs, validateDuration(p.Child("interval"), d.Interval)...)
+	}
+	return errs
+}
+
+func (v validator) validateGitLab(ds *v1alpha1.PerfDataSourceGitLab) field.ErrorList {
+	errs := v.validateDataSource(ds.Namespace, ds.Spec.Name, ds.Spec.Type, gitLabType, ds.Spec.PerfServerKind,
+		ds.Spec.PerfServerName)
+	errs = append(errs, v.validateCodebase(ds.Namespace, ds.Spec.CodebaseName)...)
+	errs = append(errs, validateToolUrl(ds.Spec.Config.Url, ds.Spec.EdpComponent, nil, "")...)
+	errs = append(errs, validateUnique(configPath.Child("repositories"), ds.Spec.Config.Repositories)...)
+	errs = append(errs, validateUnique(configPath.Child("branches"), ds.Spec.Config.Branches)...)
+	errs = append(errs, validateEnum(specPath.Child("validation"), ds.Spec.Validation, validationPolicies)...)
+	if d := ds.Spec.Discovery; d != nil {
+		p := specPath.Child("discovery")
+		if len(d.Groups) == 0 {
+			errs = append(errs, field.Required(p.Child("groups"), ""))
+		}
+		errs = append(errs, validateUnique(p.Child("groups"), d.Groups)...)
+		errs = append(errs, validatePatterns(p.Child("include"), d.Include)...)
+		errs = append(errs, validatePatterns(p.Child("exclude"), d.Exclude)...)
+		errs = append(errs, validateDuration(p.Child("interval"), d.Interval)...)
+	}
+	return errs
+}
+
+func (v validator) validateBitbucket(ds *v1alpha1.PerfDataSourceBitbucket) field.ErrorList {
+	errs := v.validateDataSource(ds.Namespace, ds.Spec.Name, ds.Spec.Type, bitbucketType, ds.Spec.PerfServerKind,
+		ds.Spec.PerfServerName)
+	errs = append(errs, v.validateCodebase(ds.Namespace, ds.Spec.CodebaseName)...)
+	errs = append(errs, validateUrl(configPath.Child("url"), ds.Spec.Config.Url, true)...)
+	errs = append(errs, validateRequired(configPath.Child("workspace"), ds.Spec.Config.Workspace)...)
+	errs = append(errs, validateUnique(configPath.Child("repositories"), ds.Spec.Config.Repositories)...)
+	errs = append(errs, validateUnique(configPath.Child("branches"), ds.Spec.Config.Branches)...)
+	return errs
+}
+
+func (v validator) validateAzureDevOps(ds *v1alpha1.PerfDataSourceAzureDevOps) field.ErrorList {
+	errs := v.validateDataSource(ds.Namespace, ds.Spec.Name, ds.Spec.Type, azureDevOpsType, ds.Spec.PerfServerKind,
+		ds.Spec.PerfServerName)
+	errs = append(errs, v.validateCodebase(ds.Namespace, ds.Spec.CodebaseName)...)
+	errs = append(errs, validateUrl(configPath.Child("url"), ds.Spec.Config.Url, true)...)
+	errs = append(errs, validateRequired(configPath.Child("project"), ds.Spec.Config.Project)...)
+	errs = append(errs, validateUnique(configPath.Child("repositories"), ds.Spec.Config.Repositories)...)
+	errs = append(errs, validateUnique(configPath.Child("branches"), ds.Spec.Config.Branches)...)
+	return errs
+}
+
+func (v validator) validateTekton(ds *v1alpha1.PerfDataSourceTekton) field.ErrorList {
+	errs := v.validateDataSource(ds.Namespace, ds.Spec.Name, ds.Spec.Type, customType, ds.Spec.PerfServerKind,
+		ds.Spec.PerfServerName)
+	errs = append(errs, validateSelector(configPath.Child("codebaseSelector"), ds.Spec.Config.CodebaseSelector)...)
+	errs = append(errs, validateSelector(configPath.Child("pipelineRunSelector"), ds.Spec.Config.PipelineRunSelector)...)
+	errs = append(errs, validateDuration(configPath.Child("window"), ds.Spec.Config.Window)...)
+	errs = append(errs, validateDuration(configPath.Child("interval"), ds.Spec.Config.Interval)...)
+	return errs
+}
+
+func (v validator) validateDoraMetrics(dm *v1alpha1.PerfDoraMetrics) field.ErrorList {
+	errs := v.validateDataSource(dm.Namespace, dm.Spec.Name, dm.Spec.Type, customType, dm.Spec.PerfServerKind,
+		dm.Spec.PerfServerName)
+	p := specPath.Child("stages")
+	if len(dm.Spec.Stages) == 0 {
+		errs = append(errs, field.Required(p, ""))
+	}
+	names := make(map[string]bool)
+	for i, s := range dm.Spec.Stages {
+		errs = append(errs, validateRequired(p.Index(i).Child("name"), s.Name)...)
+		errs = append(errs, validateRequired(p.Index(i).Child("namespace"), s.Namespace)...)
+		if s.Name != "" && names[s.Name] {
+			errs = append(errs, field.Duplicate(p.Index(i).Child("name"), s.Name))
+		}
+		names[s.Name] = true
+	}
+	errs = append(errs, validateDuration(specPath.Child("window"), dm.Spec.Window)...)
+	errs = append(errs, validateDuration(specPath.Child("interval"), dm.Spec.Interval)...)
+	return errs
+}
+
+func (v validator) validateReport(r *v1alpha1.PerfReport) field.ErrorList {
+	errs := v.validatePerfServerRef(r.Namespace, r.Spec.PerfServerKind, r.Spec.PerfServerName)
+	errs = append(errs, validateUnique(specPath.Child("metrics"), r.Spec.Metrics)...)
+	errs = append(errs, validateDuration(specPath.Child("interval"), r.Spec.Interval)...)
+	return errs
+}
+
+// validateDataSource checks the fields shared by all data source kinds.
+func (v validator) validateDataSource(namespace, name, dsType, expectedType, perfServerKind,
+	perfServerName string) field.ErrorList {
+	errs := validateRequired(specPath.Child("name"), name)
+	if dsType == "" {
+		errs = append(errs, field.Required(specPath.Child("type"), ""))
+	} else if !strings.EqualFold(dsType, expectedType) {
+		errs = append(errs, field.NotSupported(specPath.Child("type"), dsType, []string{expectedType}))
+	}
+	return append(errs, v.validatePerfServerRef(namespace, perfServerKind, perfServerName)...)
+}
+
+func (v validator) validatePerfServerRef(namespace, kind, name string) field.ErrorList {
+	p := specPath.Child("perfServerName")
+	if errs := validateEnum(specPath.Child("perfServerKind"), kind, perfServerKinds); errs != nil {
+		return errs
+	}
+	if name == "" {
+		return field.ErrorList{field.Required(p, "")}
+	}
+	if kind != consts.ClusterPerfServerKind {
+		if _, err := cluster.GetPerfServerCr(v.client, kind, name, namespace); err != nil {
+			return field.ErrorList{getReferenceError(p, name, err)}
+		}
+		return nil
+	}
+
+	cps, err := cluster.GetClusterPerfServerCr(v.client, name)
+	if err != nil {
+		return field.ErrorList{getReferenceError(p, name, err)}
+	}
+	allowed, err := cluster.IsNamespaceAllowed(v.client, cps, namespace)
+	if err != nil {
+		return field.ErrorList{field.InternalError(p, err)}
+	}
+	if !allowed {
+		return field.ErrorList{field.Forbidden(p, namespace+" namespace isn't allowed to use the ClusterPerfServer")}
+	}
+	return nil
+}
+
+func (v validator) validateCodebase(namespace, name string) field.ErrorList {
+	if name == "" {
+		return nil
+	}
+	if _, err := cluster.GetCodebase(v.client, name, namespace); err != nil {
+		return field.ErrorList{getReferenceError(specPath.Child("codebaseName"), name, err)}
+	}
+	return nil
+}
+
+func getReferenceError(p *field.Path, name string, err error) *field.Error {
+	if k8serrors.IsNotFound(err) {
+		return field.NotFound(p, name)
+	}
+	return field.InternalError(p, err)
+}
+
+// validateToolUrl checks the url of the data sources whose url may be taken from an EDPComponent
+// or, if the operator path is set, from the CR of the tool operator.
+func validateToolUrl(u, edpComponent string, operatorPath *field.Path, operatorName string) field.ErrorList {
+	if u == "" && edpComponent == "" && operatorName == "" {
+		msg := "either url or spec.edpComponent is required"
+		if operatorPath != nil {
+			msg = "either url, spec.edpComponent or " + operatorPath.String() + " is required"
+		}
+		return field.ErrorList{field.Required(configPath.Child("url"), msg)}
+	}
+	return validateUrl(configPath.Child("url"), u, false)
+}
+
+func validateUrl(p *field.Path, u string, required bool) field.ErrorList {
+	if u == "" {
+		if required {
+			return field.ErrorList{field.Required(p, "")}
+		}
+		return nil
+	}
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return field.ErrorList{field.Invalid(p, u, err.Error())}
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return field.ErrorList{field.Invalid(p, u, "must be an absolute http or https url")}
+	}
+	if parsed.Host == "" {
+		return field.ErrorList{field.Invalid(p, u, "must have a host")}
+	}
+	return nil
+}
+
+func validateRequired(p *field.Path, value string) field.ErrorList {
+	if strings.TrimSpace(value) == "" {
+		return field.ErrorList{field.Required(p, "")}
+	}
+	return nil
+}
+
+func validateEnum(p *field.Path, value string, allowed []string) field.ErrorList {
+	if value == "" {
+		return nil
+	}
+	for _, a := range allowed {
+		if value == a {
+			return nil
+		}
+	}
+	return field.ErrorList{field.NotSupported(p, value, allowed)}
+}
+
+func validateUnique(p *field.Path, entries []string) field.ErrorList {
+	var errs field.ErrorList
+	seen := make(map[string]bool)
+	for i, e := range entries {
+		if strings.TrimSpace(e) == "" {
+			errs = append(errs, field.Required(p.Index(i), "must not be empty"))
+			continue
+		}
+		if seen[e] {
+			errs = append(errs, field.Duplicate(p.Index(i), e))
+		}
+		seen[e] = true
+	}
+	return errs
+}
+
+func validatePatterns(p *field.Path, patterns []string) field.ErrorList {
+	errs := validateUnique(p, patterns)
+	for i, pattern := range patterns {
+		if err := datasource.ValidatePattern(pattern); err != nil {
+			errs = append(errs, field.Invalid(p.Index(i), pattern, err.Error()))
+		}
+	}
+	return errs
+}
+
+func validateDuration(p *field.Path, value string) field.ErrorList {
+	if value == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return field.ErrorList{field.Invalid(p, value, "must be a duration, e.g. 15m")}
+	}
+	if d <= 0 {
+		return field.ErrorList{field.Invalid(p, value, "must be positive")}
+	}
+	return nil
+}
+
+func validateSelector(p *field.Path, s *metav1.LabelSelector) field.ErrorList {
+	if s == nil {
+		return nil
+	}
+	if _, err := metav1.LabelSelectorAsSelector(s); err != nil {
+		return field.ErrorList{field.Invalid(p, s, err.Error())}
+	}
+	return nil
+}
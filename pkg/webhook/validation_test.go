@@ -0,0 +1,214 @@
+package webhook
+
+import (
+	codebaseApi "github.com/epmd-edp/codebase-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/consts"
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+const (
+	fakeName      = "fake-name"
+	fakeNamespace = "fake-namespace"
+)
+
+func createValidator() validator {
+	ps := &v1alpha1.PerfServer{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+	}
+	cb := &codebaseApi.Codebase{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+	}
+	scheme.Scheme.AddKnownTypes(v1.SchemeGroupVersion, ps, cb)
+	return validator{client: fake.NewFakeClient([]runtime.Object{ps, cb}...)}
+}
+
+func createJenkinsDataSource() *v1alpha1.PerfDataSourceJenkins {
+	return &v1alpha1.PerfDataSourceJenkins{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Spec: v1alpha1.PerfDataSourceJenkinsSpec{
+			Name: "jenkins",
+			Type: "Jenkins",
+			Config: v1alpha1.DataSourceJenkinsConfig{
+				JobNames: []string{"/app/MASTER-Build-app", "/app/MASTER-Code-review-app"},
+				Url:      "https://jenkins.example.com",
+			},
+			PerfServerName: fakeName,
+			CodebaseName:   fakeName,
+		},
+	}
+}
+
+func getFields(errs field.ErrorList) []string {
+	var res []string
+	for _, e := range errs {
+		res = append(res, e.Field+": "+string(e.Type))
+	}
+	return res
+}
+
+func TestValidate_ShouldAcceptValidDataSource(t *testing.T) {
+	assert.Empty(t, createValidator().validate(createJenkinsDataSource()))
+}
+
+func TestValidate_ShouldReportEveryInvalidField(t *testing.T) {
+	ds := createJenkinsDataSource()
+	ds.Spec.Type = "Sonar"
+	ds.Spec.PerfServerName = "missing"
+	ds.Spec.CodebaseName = "missing"
+	ds.Spec.Config.Url = "jenkins.example.com"
+	ds.Spec.Config.JobNames = []string{"/app/MASTER-Build-app", "", "/app/MASTER-Build-app"}
+	ds.Spec.Validation = "ignore"
+	ds.Spec.Discovery = &v1alpha1.JenkinsJobDiscovery{
+		Patterns: []string{"regex:("},
+		Interval: "15",
+	}
+
+	assert.Equal(t, []string{
+		"spec.type: FieldValueNotSupported",
+		"spec.perfServerName: FieldValueNotFound",
+		"spec.codebaseName: FieldValueNotFound",
+		"spec.config.url: FieldValueInvalid",
+		"spec.config.jobNames[1]: FieldValueRequired",
+		"spec.config.jobNames[2]: FieldValueDuplicate",
+		"spec.validation: FieldValueNotSupported",
+		"spec.discovery.patterns[0]: FieldValueInvalid",
+		"spec.discovery.interval: FieldValueInvalid",
+	}, getFields(createValidator().validate(ds)))
+}
+
+func TestValidate_ShouldRequireUrlOrEdpComponent(t *testing.T) {
+	ds := &v1alpha1.PerfDataSourceSonar{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Spec: v1alpha1.PerfDataSourceSonarSpec{
+			Name:           "sonar",
+			Type:           "SONAR",
+			PerfServerName: fakeName,
+		},
+	}
+	v := createValidator()
+
+	assert.Equal(t, []string{"spec.config.url: FieldValueRequired"}, getFields(v.validate(ds)))
+
+	ds.Spec.EdpComponent = "sonar"
+	assert.Empty(t, v.validate(ds))
+
+	ds.Spec.EdpComponent = ""
+	ds.Spec.SonarName = "sonar"
+	assert.Empty(t, v.validate(ds))
+}
+
+func TestValidate_ShouldCheckPerfServerAuth(t *testing.T) {
+	ps := &v1alpha1.PerfServer{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Spec: v1alpha1.PerfServerSpec{
+			ApiUrl:  "https://perf.example.com",
+			RootUrl: "https://perf.example.com",
+			Auth: &v1alpha1.PerfServerAuth{
+				Type:     "oauth2",
+				TokenUrl: "ftp://sso.example.com",
+			},
+			ExporterNodes: []string{"Backend", "Backend"},
+		},
+	}
+
+	assert.Equal(t, []string{
+		"spec.exporterNodes[1]: FieldValueDuplicate",
+		"spec.auth.secretName: FieldValueRequired",
+		"spec.auth.tokenUrl: FieldValueInvalid",
+	}, getFields(createValidator().validate(ps)))
+}
+
+func TestValidate_ShouldCheckDoraStages(t *testing.T) {
+	dm := &v1alpha1.PerfDoraMetrics{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+		Spec: v1alpha1.PerfDoraMetricsSpec{
+			Name:           "dora",
+			Type:           "custom",
+			PerfServerName: fakeName,
+			Stages: []v1alpha1.DoraStage{
+				{Name: "dev", Namespace: "edp-dev"},
+				{Name: "dev", Namespace: ""},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{
+		"spec.stages[1].namespace: FieldValueRequired",
+		"spec.stages[1].name: FieldValueDuplicate",
+	}, getFields(createValidator().validate(dm)))
+}
+
+func TestValidate_ShouldCheckClusterPerfServerRef(t *testing.T) {
+	ns := &coreV1.Namespace{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   fakeNamespace,
+			Labels: map[string]string{"team": "backend"},
+		},
+	}
+	cps := &v1alpha1.ClusterPerfServer{
+		ObjectMeta: v1.ObjectMeta{
+			Name: fakeName,
+		},
+		Spec: v1alpha1.ClusterPerfServerSpec{
+			AllowedNamespaces: &v1.LabelSelector{MatchLabels: map[string]string{"team": "frontend"}},
+		},
+	}
+	scheme.Scheme.AddKnownTypes(v1.SchemeGroupVersion, cps)
+	ds := createJenkinsDataSource()
+	ds.Spec.CodebaseName = ""
+	ds.Spec.PerfServerKind = consts.ClusterPerfServerKind
+
+	v := validator{client: fake.NewFakeClient(ns, cps)}
+	assert.Equal(t, []string{"spec.perfServerName: FieldValueForbidden"}, getFields(v.validate(ds)))
+
+	cps.Spec.AllowedNamespaces = &v1.LabelSelector{}
+	v = validator{client: fake.NewFakeClient(ns, cps)}
+	assert.Empty(t, v.validate(ds))
+
+	ds.Spec.PerfServerKind = "PerfServers"
+	assert.Equal(t, []string{"spec.perfServerKind: FieldValueNotSupported"}, getFields(v.validate(ds)))
+}
+
+func TestValidate_ShouldRequireAllowedNamespaces(t *testing.T) {
+	cps := &v1alpha1.ClusterPerfServer{
+		ObjectMeta: v1.ObjectMeta{
+			Name: fakeName,
+		},
+		Spec: v1alpha1.ClusterPerfServerSpec{
+			PerfServerSpec: v1alpha1.PerfServerSpec{
+				ApiUrl:         "https://perf.example.com",
+				RootUrl:        "https://perf.example.com",
+				CredentialName: "perf-user",
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"spec.allowedNamespaces: FieldValueRequired"},
+		getFields(createValidator().validate(cps)))
+}
@@ -0,0 +1,147 @@
+package webhook
+
+import (
+	"fmt"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/perf-operator/v2/pkg/util/watch"
+	"github.com/pkg/errors"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"os"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	ctrlWebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission/builder"
+	"strconv"
+)
+
+const (
+	enabledEnv      = "PERF_WEBHOOK_ENABLED"
+	portEnv         = "PERF_WEBHOOK_PORT"
+	nameTemplateEnv = "PERF_WEBHOOK_NAME_TEMPLATE"
+	podNamespaceEnv = "POD_NAMESPACE"
+	operatorNameEnv = "OPERATOR_NAME"
+
+	defaultPort         = 9443
+	defaultOperatorName = "perf-operator"
+	certDir             = "/tmp/cert"
+	validatingPath      = "/validate-perf"
+	mutatingPath        = "/mutate-perf"
+)
+
+var (
+	log = logf.Log.WithName("perf_webhook")
+
+	// perfResources are the CRs checked by the webhooks.
+	perfResources = []string{"perfservers", "clusterperfservers", "perfdatasourcejenkinses", "perfdatasourcesonars",
+		"perfdatasourcegitlabs", "perfdatasourcebitbuckets", "perfdatasourceazuredevopses", "perfdatasourcetektons",
+		"perfdorametrics", "perfreports"}
+)
+
+// Add starts the admission webhook server of the operator if it's enabled with PERF_WEBHOOK_ENABLED.
+// The server certificate, its Service and the webhook configurations are created by the server itself.
+func Add(mgr manager.Manager, namespace string) error {
+	enabled, _ := strconv.ParseBool(os.Getenv(enabledEnv))
+	if !enabled {
+		log.Info("PERF webhook is disabled")
+		return nil
+	}
+
+	port := int32(defaultPort)
+	if v := os.Getenv(portEnv); v != "" {
+		p, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return errors.Wrapf(err, "couldn't parse %v webhook port", v)
+		}
+		port = int32(p)
+	}
+
+	namespaces, err := watch.GetFilter(mgr.GetClient())
+	if err != nil {
+		return err
+	}
+
+	operatorName := getEnv(operatorNameEnv, defaultOperatorName)
+	operatorNamespace := getEnv(podNamespaceEnv, namespace)
+	// the webhook configurations are cluster-wide, so they're named after the operator instance
+	instance := fmt.Sprintf("%v-%v", operatorName, operatorNamespace)
+
+	svr, err := ctrlWebhook.NewServer(operatorName+"-admission-server", mgr, ctrlWebhook.ServerOptions{
+		Port:    port,
+		CertDir: certDir,
+		BootstrapOptions: &ctrlWebhook.BootstrapOptions{
+			ValidatingWebhookConfigName: instance + "-validating",
+			MutatingWebhookConfigName:   instance + "-mutating",
+			Secret: &types.NamespacedName{
+				Namespace: operatorNamespace,
+				Name:      operatorName + "-webhook-cert",
+			},
+			Service: &ctrlWebhook.Service{
+				Namespace: operatorNamespace,
+				Name:      operatorName + "-webhook",
+				Selectors: map[string]string{"name": operatorName},
+			},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "couldn't create webhook server")
+	}
+
+	validating, err := builder.NewWebhookBuilder().
+		Name(fmt.Sprintf("validating.%v.%v", instance, v1alpha1.SchemeGroupVersion.Group)).
+		Path(validatingPath).
+		Validating().
+		Rules(getPerfRules()).
+		FailurePolicy(admissionregistrationv1beta1.Ignore).
+		WithManager(mgr).
+		Handlers(newValidatingHandler(mgr.GetClient(), namespaces)).
+		Build()
+	if err != nil {
+		return errors.Wrap(err, "couldn't build validating webhook")
+	}
+
+	d, err := newDefaulter(mgr.GetClient(), getEnv(nameTemplateEnv, defaultNameTemplate))
+	if err != nil {
+		return err
+	}
+	mutating, err := builder.NewWebhookBuilder().
+		Name(fmt.Sprintf("mutating.%v.%v", instance, v1alpha1.SchemeGroupVersion.Group)).
+		Path(mutatingPath).
+		Mutating().
+		Rules(getPerfRules()).
+		FailurePolicy(admissionregistrationv1beta1.Ignore).
+		WithManager(mgr).
+		Handlers(&mutatingHandler{defaulter: d, namespaces: namespaces}).
+		Build()
+	if err != nil {
+		return errors.Wrap(err, "couldn't build mutating webhook")
+	}
+
+	if err := svr.Register(mutating, validating); err != nil {
+		return errors.Wrap(err, "couldn't register webhooks")
+	}
+	log.Info("PERF webhook server has been added", "port", port)
+	return nil
+}
+
+func getPerfRules() admissionregistrationv1beta1.RuleWithOperations {
+	return admissionregistrationv1beta1.RuleWithOperations{
+		Operations: []admissionregistrationv1beta1.OperationType{
+			admissionregistrationv1beta1.Create,
+			admissionregistrationv1beta1.Update,
+		},
+		Rule: admissionregistrationv1beta1.Rule{
+			APIGroups:   []string{v1alpha1.SchemeGroupVersion.Group},
+			APIVersions: []string{v1alpha1.SchemeGroupVersion.Version, v1.SchemeGroupVersion.Version},
+			Resources:   perfResources,
+		},
+	}
+}
+
+func getEnv(name, defaultValue string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return defaultValue
+}